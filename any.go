@@ -0,0 +1,127 @@
+package partial
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// AnyPartial is a Partial of any type, for code such as logging or audit middleware that
+// handles partials of many types together without being generic over each of them:
+//
+//	audit.Record([]partial.AnyPartial{incident.Any(), organisation.Any()})
+type AnyPartial interface {
+	TypeName() string     // Incident
+	FieldNames() []string // the tracked fields
+	Empty() bool
+	Subject() any // the T holding the tracked values
+	Ops() []FieldOp
+	Err() error
+}
+
+// Any returns the partial as an AnyPartial, registering T with ApplyAny.
+func (m Partial[T]) Any() AnyPartial {
+	RegisterApplier[T]()
+
+	return anyPartial[T]{model: m}
+}
+
+// anyPartial adapts a Partial to AnyPartial, which it can't implement directly as
+// FieldNames and Subject are already fields.
+type anyPartial[T any] struct {
+	model Partial[T]
+}
+
+func (a anyPartial[T]) TypeName() string {
+	return reflect.TypeOf(a.model.Subject).Name()
+}
+
+func (a anyPartial[T]) FieldNames() []string {
+	return append([]string{}, a.model.FieldNames...)
+}
+
+func (a anyPartial[T]) Empty() bool {
+	return a.model.Empty()
+}
+
+func (a anyPartial[T]) Subject() any {
+	return a.model.Subject
+}
+
+func (a anyPartial[T]) Ops() []FieldOp {
+	return a.model.Ops()
+}
+
+func (a anyPartial[T]) Err() error {
+	return a.model.Err()
+}
+
+var (
+	appliersMu sync.RWMutex
+	appliers   = map[reflect.Type]func(p AnyPartial, base any) (any, error){}
+)
+
+// RegisterApplier lets ApplyAny apply partials of T. Converting a partial with Any
+// registers its type already, so this is only needed for implementations of AnyPartial
+// of your own, whose Subject is a T.
+func RegisterApplier[T any]() {
+	subjectType := reflect.TypeOf((*T)(nil)).Elem()
+
+	appliersMu.RLock()
+	_, ok := appliers[subjectType]
+	appliersMu.RUnlock()
+	if ok {
+		return
+	}
+
+	appliersMu.Lock()
+	defer appliersMu.Unlock()
+
+	appliers[subjectType] = func(p AnyPartial, base any) (any, error) {
+		var baseValue T
+		switch typedBase := base.(type) {
+		case T:
+			baseValue = typedBase
+		case *T:
+			if typedBase == nil {
+				return nil, errors.New(fmt.Sprintf("cannot apply partial of %s to a nil *%s", p.TypeName(), p.TypeName()))
+			}
+			baseValue = *typedBase
+		default:
+			return nil, errors.New(fmt.Sprintf("cannot apply partial of %s to %T", p.TypeName(), base))
+		}
+
+		// Partials we converted keep everything they track, such as increments
+		model, ok := p.(anyPartial[T])
+		if !ok {
+			model = anyPartial[T]{model: newTracking(p.Subject().(T), p.FieldNames())}
+		}
+
+		return model.model.Apply(baseValue), nil
+	}
+}
+
+// ApplyAny applies a partial to base, which must be a T or *T for a partial of T, and
+// returns the result as a *T. This is for code that handles values of many types as any,
+// such as job runners and event processors, which can then apply patches without a type
+// switch over every model.
+//
+// The type of the partial must be registered, which Any does for partials it converts.
+func ApplyAny(p AnyPartial, base any) (any, error) {
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+
+	subjectType := reflect.TypeOf(p.Subject())
+
+	appliersMu.RLock()
+	applier, ok := appliers[subjectType]
+	appliersMu.RUnlock()
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("no applier registered for partials of %s", subjectType))
+	}
+
+	return applier(p, base)
+}
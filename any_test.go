@@ -0,0 +1,94 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AnyPartial", func() {
+	It("holds partials of different types together", func() {
+		models := []partial.AnyPartial{
+			test.OrganisationBuilder(
+				test.OrganisationBuilder.Name("My Org"),
+			).Any(),
+			test.IncidentBuilder(
+				test.IncidentBuilder.OrganisationID("org-id"),
+			).Any(),
+		}
+
+		Expect(models[0].TypeName()).To(Equal("Organisation"))
+		Expect(models[0].FieldNames()).To(Equal([]string{"Name"}))
+		Expect(models[0].Subject()).To(BeAssignableToTypeOf(test.Organisation{}))
+		Expect(models[1].TypeName()).To(Equal("Incident"))
+		Expect(models[1].Ops()).To(Equal([]partial.FieldOp{
+			{FieldName: "OrganisationID", JSONName: "organisation_id", Kind: partial.FieldOpSet, Value: "org-id"},
+		}))
+		Expect(models[1].Empty()).To(BeFalse())
+		Expect(models[1].Err()).NotTo(HaveOccurred())
+	})
+})
+
+// recordedPartial is an AnyPartial of our own, such as one read back from a queue.
+type recordedPartial struct {
+	subject    any
+	fieldNames []string
+}
+
+func (r recordedPartial) TypeName() string       { return "Recorded" }
+func (r recordedPartial) FieldNames() []string   { return r.fieldNames }
+func (r recordedPartial) Empty() bool            { return len(r.fieldNames) == 0 }
+func (r recordedPartial) Subject() any           { return r.subject }
+func (r recordedPartial) Ops() []partial.FieldOp { return nil }
+func (r recordedPartial) Err() error             { return nil }
+
+type unregisteredSubject struct {
+	Name string
+}
+
+var _ = Describe("ApplyAny", func() {
+	model := test.OrganisationBuilder(
+		test.OrganisationBuilder.Name("Renamed"),
+	).Increment("IncidentCount", 2).Any()
+
+	It("applies to values and pointers of the partial's type", func() {
+		for _, base := range []any{
+			test.Organisation{ID: "org-id", IncidentCount: 1},
+			&test.Organisation{ID: "org-id", IncidentCount: 1},
+		} {
+			applied, err := partial.ApplyAny(model, base)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applied).To(Equal(&test.Organisation{ID: "org-id", Name: "Renamed", IncidentCount: 3}))
+		}
+	})
+
+	It("returns errors for bases of another type", func() {
+		_, err := partial.ApplyAny(model, test.Incident{})
+
+		Expect(err).To(MatchError("cannot apply partial of Organisation to test.Incident"))
+	})
+
+	It("applies registered types of partials it didn't convert", func() {
+		partial.RegisterApplier[test.Action]()
+
+		applied, err := partial.ApplyAny(recordedPartial{
+			subject:    test.Action{ID: "ignored", Description: "Updated"},
+			fieldNames: []string{"Description"},
+		}, test.Action{ID: "action-id"})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(test.ActionMatcher(
+			test.ActionMatcher.ID("action-id"),
+			test.ActionMatcher.Description("Updated"),
+		))
+	})
+
+	It("returns errors for unregistered types", func() {
+		_, err := partial.ApplyAny(recordedPartial{subject: unregisteredSubject{}}, unregisteredSubject{})
+
+		Expect(err).To(MatchError("no applier registered for partials of partial_test.unregisteredSubject"))
+	})
+})
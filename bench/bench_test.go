@@ -0,0 +1,135 @@
+package bench_test
+
+import (
+	"testing"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/bench"
+)
+
+func BenchmarkNew(b *testing.B) {
+	b.Run("Small", func(b *testing.B) {
+		small := bench.NewSmall()
+		for i := 0; i < b.N; i++ {
+			if _, err := partial.New(&small); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Large", func(b *testing.B) {
+		large := bench.NewLarge()
+		for i := 0; i < b.N; i++ {
+			if _, err := partial.New(&large); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkBuilder(b *testing.B) {
+	b.Run("Small", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bench.SmallBuilder(
+				bench.SmallBuilder.Name("Small"),
+				bench.SmallBuilder.Count(3),
+			)
+		}
+	})
+
+	b.Run("Large", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bench.LargeBuilder(
+				bench.LargeBuilder.Name("Large"),
+				bench.LargeBuilder.SummaryValue("Everything is on fire"),
+				bench.LargeBuilder.Status("fixing"),
+				bench.LargeBuilder.UpdateCount(13),
+			)
+		}
+	})
+}
+
+func BenchmarkAdd(b *testing.B) {
+	b.Run("Small", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			partial.NewWithCapacity[bench.Small](2).
+				Add(bench.SmallBuilder.Name("Small")).
+				Add(bench.SmallBuilder.Count(3))
+		}
+	})
+
+	b.Run("Large", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			partial.NewWithCapacity[bench.Large](4).
+				Add(bench.LargeBuilder.Name("Large")).
+				Add(bench.LargeBuilder.SummaryValue("Everything is on fire")).
+				Add(bench.LargeBuilder.Status("fixing")).
+				Add(bench.LargeBuilder.UpdateCount(13))
+		}
+	})
+}
+
+func BenchmarkApply(b *testing.B) {
+	b.Run("Small", func(b *testing.B) {
+		model := mustNew(b, bench.NewSmall())
+		for i := 0; i < b.N; i++ {
+			model.Apply(bench.Small{})
+		}
+	})
+
+	b.Run("Large", func(b *testing.B) {
+		model := mustNew(b, bench.NewLarge())
+		for i := 0; i < b.N; i++ {
+			model.Apply(bench.Large{})
+		}
+	})
+}
+
+func BenchmarkMerge(b *testing.B) {
+	b.Run("Small", func(b *testing.B) {
+		model := mustNew(b, bench.NewSmall())
+		other := bench.SmallBuilder(bench.SmallBuilder.Count(4))
+		for i := 0; i < b.N; i++ {
+			model.Merge(other)
+		}
+	})
+
+	b.Run("Large", func(b *testing.B) {
+		model := mustNew(b, bench.NewLarge())
+		other := bench.LargeBuilder(bench.LargeBuilder.Status("fixing"), bench.LargeBuilder.UpdateCount(13))
+		for i := 0; i < b.N; i++ {
+			model.Merge(other)
+		}
+	})
+}
+
+func BenchmarkMatch(b *testing.B) {
+	b.Run("Small", func(b *testing.B) {
+		small := bench.NewSmall()
+		model := mustNew(b, small)
+		for i := 0; i < b.N; i++ {
+			if !model.Match(&small) {
+				b.Fatal("expected match")
+			}
+		}
+	})
+
+	b.Run("Large", func(b *testing.B) {
+		large := bench.NewLarge()
+		model := mustNew(b, large)
+		for i := 0; i < b.N; i++ {
+			if !model.Match(&large) {
+				b.Fatal("expected match")
+			}
+		}
+	})
+}
+
+func mustNew[T any](b *testing.B, subject T) partial.Partial[T] {
+	model, err := partial.New(&subject)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return model
+}
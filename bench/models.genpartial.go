@@ -0,0 +1,1277 @@
+// Code generated by github.com/incident-io/partial/gen, DO NOT EDIT.
+// partial generator version: 8
+
+package bench
+
+import (
+	"time"
+
+	"github.com/incident-io/partial"
+	null "gopkg.in/guregu/null.v3"
+)
+
+func init() {
+	partial.RequireGeneratorVersion("models.genpartial.go", 8)
+}
+
+// LargeBuilder initialises a Large struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Setters: ID, OrganisationID, Name, Summary, SummaryValue, SummaryNull, ClearSummary,
+// Description, Status, Severity, Mode, ExternalID, ExternalIDValue, ExternalIDNull,
+// ClearExternalID, Reference, SlackChannelID, SlackTeamID, CreatorID, LeadID,
+// LeadIDValue, LeadIDNull, ClearLeadID, PostmortemURL, PostmortemURLValue,
+// PostmortemURLNull, ClearPostmortemURL, Visibility, Private, Test, Archived,
+// UpdateCount, ActionCount, FollowUpCount, AttachmentCount, Duration, Score, ReportedAt,
+// AcceptedAt, AcceptedAtValue, AcceptedAtNull, ClearAcceptedAt, ResolvedAt,
+// ResolvedAtValue, ResolvedAtNull, ClearResolvedAt, CreatedAt, UpdatedAt.
+//
+// For example:
+//
+//	model := LargeBuilder(
+//		LargeBuilder.ID(id),
+//	)
+var LargeBuilder = LargeBuilderFunc(func(opts ...partial.Option[Large]) partial.Partial[Large] {
+	apply := func(base Large) partial.Partial[Large] {
+		model := partial.Partial[Large]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(Large{})
+	model.SetApply(func(base Large) *Large {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type LargeBuilderFunc func(opts ...partial.Option[Large]) partial.Partial[Large]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/bench", "Large", "builder",
+		"ID",
+		"OrganisationID",
+		"Name",
+		"Summary",
+		"SummaryValue",
+		"SummaryNull",
+		"ClearSummary",
+		"Description",
+		"Status",
+		"Severity",
+		"Mode",
+		"ExternalID",
+		"ExternalIDValue",
+		"ExternalIDNull",
+		"ClearExternalID",
+		"Reference",
+		"SlackChannelID",
+		"SlackTeamID",
+		"CreatorID",
+		"LeadID",
+		"LeadIDValue",
+		"LeadIDNull",
+		"ClearLeadID",
+		"PostmortemURL",
+		"PostmortemURLValue",
+		"PostmortemURLNull",
+		"ClearPostmortemURL",
+		"Visibility",
+		"Private",
+		"Test",
+		"Archived",
+		"UpdateCount",
+		"ActionCount",
+		"FollowUpCount",
+		"AttachmentCount",
+		"Duration",
+		"Score",
+		"ReportedAt",
+		"AcceptedAt",
+		"AcceptedAtValue",
+		"AcceptedAtNull",
+		"ClearAcceptedAt",
+		"ResolvedAt",
+		"ResolvedAtValue",
+		"ResolvedAtNull",
+		"ClearResolvedAt",
+		"CreatedAt",
+		"UpdatedAt",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b LargeBuilderFunc) From(existing Large) partial.Option[Large] {
+	return func(subject *Large) []string {
+		subject.ID = existing.ID
+		subject.OrganisationID = existing.OrganisationID
+		subject.Name = existing.Name
+		subject.Summary = existing.Summary
+		subject.Description = existing.Description
+		subject.Status = existing.Status
+		subject.Severity = existing.Severity
+		subject.Mode = existing.Mode
+		subject.ExternalID = existing.ExternalID
+		subject.Reference = existing.Reference
+		subject.SlackChannelID = existing.SlackChannelID
+		subject.SlackTeamID = existing.SlackTeamID
+		subject.CreatorID = existing.CreatorID
+		subject.LeadID = existing.LeadID
+		subject.PostmortemURL = existing.PostmortemURL
+		subject.Visibility = existing.Visibility
+		subject.Private = existing.Private
+		subject.Test = existing.Test
+		subject.Archived = existing.Archived
+		subject.UpdateCount = existing.UpdateCount
+		subject.ActionCount = existing.ActionCount
+		subject.FollowUpCount = existing.FollowUpCount
+		subject.AttachmentCount = existing.AttachmentCount
+		subject.Duration = existing.Duration
+		subject.Score = existing.Score
+		subject.ReportedAt = existing.ReportedAt
+		subject.AcceptedAt = existing.AcceptedAt
+		subject.ResolvedAt = existing.ResolvedAt
+		subject.CreatedAt = existing.CreatedAt
+		subject.UpdatedAt = existing.UpdatedAt
+
+		return []string{
+			"ID",
+			"OrganisationID",
+			"Name",
+			"Summary",
+			"Description",
+			"Status",
+			"Severity",
+			"Mode",
+			"ExternalID",
+			"Reference",
+			"SlackChannelID",
+			"SlackTeamID",
+			"CreatorID",
+			"LeadID",
+			"PostmortemURL",
+			"Visibility",
+			"Private",
+			"Test",
+			"Archived",
+			"UpdateCount",
+			"ActionCount",
+			"FollowUpCount",
+			"AttachmentCount",
+			"Duration",
+			"Score",
+			"ReportedAt",
+			"AcceptedAt",
+			"ResolvedAt",
+			"CreatedAt",
+			"UpdatedAt",
+		}
+	}
+}
+
+func (b LargeBuilderFunc) ID(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ID")
+
+	return func(subject *Large) []string {
+		subject.ID = value
+
+		return []string{
+			"ID",
+		}
+	}
+}
+
+// IDIfSet sets ID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) IDIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.ID(*value)
+}
+
+func (b LargeBuilderFunc) OrganisationID(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "OrganisationID")
+
+	return func(subject *Large) []string {
+		subject.OrganisationID = value
+
+		return []string{
+			"OrganisationID",
+		}
+	}
+}
+
+// OrganisationIDIfSet sets OrganisationID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) OrganisationIDIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.OrganisationID(*value)
+}
+
+func (b LargeBuilderFunc) Name(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Name")
+
+	return func(subject *Large) []string {
+		subject.Name = value
+
+		return []string{
+			"Name",
+		}
+	}
+}
+
+// NameIfSet sets Name to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) NameIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Name(*value)
+}
+
+func (b LargeBuilderFunc) Summary(value null.String) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Summary")
+
+	return func(subject *Large) []string {
+		subject.Summary = value
+
+		return []string{
+			"Summary",
+		}
+	}
+}
+
+// SummaryValue sets Summary to a valid null.String holding value.
+func (b LargeBuilderFunc) SummaryValue(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "SummaryValue")
+
+	return func(subject *Large) []string {
+		subject.Summary = null.StringFrom(value)
+
+		return []string{
+			"Summary",
+		}
+	}
+}
+
+// SummaryNull sets Summary to null.
+func (b LargeBuilderFunc) SummaryNull() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "SummaryNull")
+
+	return func(subject *Large) []string {
+		subject.Summary = null.String{}
+
+		return []string{
+			"Summary",
+		}
+	}
+}
+
+// ClearSummary sets Summary to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b LargeBuilderFunc) ClearSummary() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ClearSummary")
+
+	return func(subject *Large) []string {
+		subject.Summary = null.String{}
+
+		return []string{
+			"Summary",
+		}
+	}
+}
+
+// SummaryIfSet sets Summary to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) SummaryIfSet(value *null.String) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Summary(*value)
+}
+
+func (b LargeBuilderFunc) Description(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Description")
+
+	return func(subject *Large) []string {
+		subject.Description = value
+
+		return []string{
+			"Description",
+		}
+	}
+}
+
+// DescriptionIfSet sets Description to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) DescriptionIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Description(*value)
+}
+
+func (b LargeBuilderFunc) Status(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Status")
+
+	return func(subject *Large) []string {
+		subject.Status = value
+
+		return []string{
+			"Status",
+		}
+	}
+}
+
+// StatusIfSet sets Status to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) StatusIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Status(*value)
+}
+
+func (b LargeBuilderFunc) Severity(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Severity")
+
+	return func(subject *Large) []string {
+		subject.Severity = value
+
+		return []string{
+			"Severity",
+		}
+	}
+}
+
+// SeverityIfSet sets Severity to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) SeverityIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Severity(*value)
+}
+
+func (b LargeBuilderFunc) Mode(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Mode")
+
+	return func(subject *Large) []string {
+		subject.Mode = value
+
+		return []string{
+			"Mode",
+		}
+	}
+}
+
+// ModeIfSet sets Mode to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) ModeIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Mode(*value)
+}
+
+func (b LargeBuilderFunc) ExternalID(value null.String) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ExternalID")
+
+	return func(subject *Large) []string {
+		subject.ExternalID = value
+
+		return []string{
+			"ExternalID",
+		}
+	}
+}
+
+// ExternalIDValue sets ExternalID to a valid null.String holding value.
+func (b LargeBuilderFunc) ExternalIDValue(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ExternalIDValue")
+
+	return func(subject *Large) []string {
+		subject.ExternalID = null.StringFrom(value)
+
+		return []string{
+			"ExternalID",
+		}
+	}
+}
+
+// ExternalIDNull sets ExternalID to null.
+func (b LargeBuilderFunc) ExternalIDNull() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ExternalIDNull")
+
+	return func(subject *Large) []string {
+		subject.ExternalID = null.String{}
+
+		return []string{
+			"ExternalID",
+		}
+	}
+}
+
+// ClearExternalID sets ExternalID to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b LargeBuilderFunc) ClearExternalID() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ClearExternalID")
+
+	return func(subject *Large) []string {
+		subject.ExternalID = null.String{}
+
+		return []string{
+			"ExternalID",
+		}
+	}
+}
+
+// ExternalIDIfSet sets ExternalID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) ExternalIDIfSet(value *null.String) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.ExternalID(*value)
+}
+
+func (b LargeBuilderFunc) Reference(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Reference")
+
+	return func(subject *Large) []string {
+		subject.Reference = value
+
+		return []string{
+			"Reference",
+		}
+	}
+}
+
+// ReferenceIfSet sets Reference to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) ReferenceIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Reference(*value)
+}
+
+func (b LargeBuilderFunc) SlackChannelID(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "SlackChannelID")
+
+	return func(subject *Large) []string {
+		subject.SlackChannelID = value
+
+		return []string{
+			"SlackChannelID",
+		}
+	}
+}
+
+// SlackChannelIDIfSet sets SlackChannelID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) SlackChannelIDIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.SlackChannelID(*value)
+}
+
+func (b LargeBuilderFunc) SlackTeamID(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "SlackTeamID")
+
+	return func(subject *Large) []string {
+		subject.SlackTeamID = value
+
+		return []string{
+			"SlackTeamID",
+		}
+	}
+}
+
+// SlackTeamIDIfSet sets SlackTeamID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) SlackTeamIDIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.SlackTeamID(*value)
+}
+
+func (b LargeBuilderFunc) CreatorID(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "CreatorID")
+
+	return func(subject *Large) []string {
+		subject.CreatorID = value
+
+		return []string{
+			"CreatorID",
+		}
+	}
+}
+
+// CreatorIDIfSet sets CreatorID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) CreatorIDIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.CreatorID(*value)
+}
+
+func (b LargeBuilderFunc) LeadID(value null.String) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "LeadID")
+
+	return func(subject *Large) []string {
+		subject.LeadID = value
+
+		return []string{
+			"LeadID",
+		}
+	}
+}
+
+// LeadIDValue sets LeadID to a valid null.String holding value.
+func (b LargeBuilderFunc) LeadIDValue(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "LeadIDValue")
+
+	return func(subject *Large) []string {
+		subject.LeadID = null.StringFrom(value)
+
+		return []string{
+			"LeadID",
+		}
+	}
+}
+
+// LeadIDNull sets LeadID to null.
+func (b LargeBuilderFunc) LeadIDNull() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "LeadIDNull")
+
+	return func(subject *Large) []string {
+		subject.LeadID = null.String{}
+
+		return []string{
+			"LeadID",
+		}
+	}
+}
+
+// ClearLeadID sets LeadID to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b LargeBuilderFunc) ClearLeadID() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ClearLeadID")
+
+	return func(subject *Large) []string {
+		subject.LeadID = null.String{}
+
+		return []string{
+			"LeadID",
+		}
+	}
+}
+
+// LeadIDIfSet sets LeadID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) LeadIDIfSet(value *null.String) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.LeadID(*value)
+}
+
+func (b LargeBuilderFunc) PostmortemURL(value null.String) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "PostmortemURL")
+
+	return func(subject *Large) []string {
+		subject.PostmortemURL = value
+
+		return []string{
+			"PostmortemURL",
+		}
+	}
+}
+
+// PostmortemURLValue sets PostmortemURL to a valid null.String holding value.
+func (b LargeBuilderFunc) PostmortemURLValue(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "PostmortemURLValue")
+
+	return func(subject *Large) []string {
+		subject.PostmortemURL = null.StringFrom(value)
+
+		return []string{
+			"PostmortemURL",
+		}
+	}
+}
+
+// PostmortemURLNull sets PostmortemURL to null.
+func (b LargeBuilderFunc) PostmortemURLNull() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "PostmortemURLNull")
+
+	return func(subject *Large) []string {
+		subject.PostmortemURL = null.String{}
+
+		return []string{
+			"PostmortemURL",
+		}
+	}
+}
+
+// ClearPostmortemURL sets PostmortemURL to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b LargeBuilderFunc) ClearPostmortemURL() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ClearPostmortemURL")
+
+	return func(subject *Large) []string {
+		subject.PostmortemURL = null.String{}
+
+		return []string{
+			"PostmortemURL",
+		}
+	}
+}
+
+// PostmortemURLIfSet sets PostmortemURL to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) PostmortemURLIfSet(value *null.String) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.PostmortemURL(*value)
+}
+
+func (b LargeBuilderFunc) Visibility(value string) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Visibility")
+
+	return func(subject *Large) []string {
+		subject.Visibility = value
+
+		return []string{
+			"Visibility",
+		}
+	}
+}
+
+// VisibilityIfSet sets Visibility to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) VisibilityIfSet(value *string) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Visibility(*value)
+}
+
+func (b LargeBuilderFunc) Private(value bool) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Private")
+
+	return func(subject *Large) []string {
+		subject.Private = value
+
+		return []string{
+			"Private",
+		}
+	}
+}
+
+// PrivateIfSet sets Private to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) PrivateIfSet(value *bool) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Private(*value)
+}
+
+func (b LargeBuilderFunc) Test(value bool) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Test")
+
+	return func(subject *Large) []string {
+		subject.Test = value
+
+		return []string{
+			"Test",
+		}
+	}
+}
+
+// TestIfSet sets Test to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) TestIfSet(value *bool) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Test(*value)
+}
+
+func (b LargeBuilderFunc) Archived(value bool) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Archived")
+
+	return func(subject *Large) []string {
+		subject.Archived = value
+
+		return []string{
+			"Archived",
+		}
+	}
+}
+
+// ArchivedIfSet sets Archived to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) ArchivedIfSet(value *bool) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Archived(*value)
+}
+
+func (b LargeBuilderFunc) UpdateCount(value int) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "UpdateCount")
+
+	return func(subject *Large) []string {
+		subject.UpdateCount = value
+
+		return []string{
+			"UpdateCount",
+		}
+	}
+}
+
+// UpdateCountIfSet sets UpdateCount to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) UpdateCountIfSet(value *int) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.UpdateCount(*value)
+}
+
+func (b LargeBuilderFunc) ActionCount(value int) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ActionCount")
+
+	return func(subject *Large) []string {
+		subject.ActionCount = value
+
+		return []string{
+			"ActionCount",
+		}
+	}
+}
+
+// ActionCountIfSet sets ActionCount to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) ActionCountIfSet(value *int) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.ActionCount(*value)
+}
+
+func (b LargeBuilderFunc) FollowUpCount(value int) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "FollowUpCount")
+
+	return func(subject *Large) []string {
+		subject.FollowUpCount = value
+
+		return []string{
+			"FollowUpCount",
+		}
+	}
+}
+
+// FollowUpCountIfSet sets FollowUpCount to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) FollowUpCountIfSet(value *int) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.FollowUpCount(*value)
+}
+
+func (b LargeBuilderFunc) AttachmentCount(value int) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "AttachmentCount")
+
+	return func(subject *Large) []string {
+		subject.AttachmentCount = value
+
+		return []string{
+			"AttachmentCount",
+		}
+	}
+}
+
+// AttachmentCountIfSet sets AttachmentCount to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) AttachmentCountIfSet(value *int) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.AttachmentCount(*value)
+}
+
+func (b LargeBuilderFunc) Duration(value int64) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Duration")
+
+	return func(subject *Large) []string {
+		subject.Duration = value
+
+		return []string{
+			"Duration",
+		}
+	}
+}
+
+// DurationIfSet sets Duration to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) DurationIfSet(value *int64) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Duration(*value)
+}
+
+func (b LargeBuilderFunc) Score(value float64) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "Score")
+
+	return func(subject *Large) []string {
+		subject.Score = value
+
+		return []string{
+			"Score",
+		}
+	}
+}
+
+// ScoreIfSet sets Score to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) ScoreIfSet(value *float64) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.Score(*value)
+}
+
+func (b LargeBuilderFunc) ReportedAt(value time.Time) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ReportedAt")
+
+	return func(subject *Large) []string {
+		subject.ReportedAt = value
+
+		return []string{
+			"ReportedAt",
+		}
+	}
+}
+
+// ReportedAtIfSet sets ReportedAt to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) ReportedAtIfSet(value *time.Time) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.ReportedAt(*value)
+}
+
+func (b LargeBuilderFunc) AcceptedAt(value null.Time) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "AcceptedAt")
+
+	return func(subject *Large) []string {
+		subject.AcceptedAt = value
+
+		return []string{
+			"AcceptedAt",
+		}
+	}
+}
+
+// AcceptedAtValue sets AcceptedAt to a valid null.Time holding value.
+func (b LargeBuilderFunc) AcceptedAtValue(value time.Time) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "AcceptedAtValue")
+
+	return func(subject *Large) []string {
+		subject.AcceptedAt = null.TimeFrom(value)
+
+		return []string{
+			"AcceptedAt",
+		}
+	}
+}
+
+// AcceptedAtNull sets AcceptedAt to null.
+func (b LargeBuilderFunc) AcceptedAtNull() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "AcceptedAtNull")
+
+	return func(subject *Large) []string {
+		subject.AcceptedAt = null.Time{}
+
+		return []string{
+			"AcceptedAt",
+		}
+	}
+}
+
+// ClearAcceptedAt sets AcceptedAt to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b LargeBuilderFunc) ClearAcceptedAt() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ClearAcceptedAt")
+
+	return func(subject *Large) []string {
+		subject.AcceptedAt = null.Time{}
+
+		return []string{
+			"AcceptedAt",
+		}
+	}
+}
+
+// AcceptedAtIfSet sets AcceptedAt to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) AcceptedAtIfSet(value *null.Time) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.AcceptedAt(*value)
+}
+
+func (b LargeBuilderFunc) ResolvedAt(value null.Time) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ResolvedAt")
+
+	return func(subject *Large) []string {
+		subject.ResolvedAt = value
+
+		return []string{
+			"ResolvedAt",
+		}
+	}
+}
+
+// ResolvedAtValue sets ResolvedAt to a valid null.Time holding value.
+func (b LargeBuilderFunc) ResolvedAtValue(value time.Time) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ResolvedAtValue")
+
+	return func(subject *Large) []string {
+		subject.ResolvedAt = null.TimeFrom(value)
+
+		return []string{
+			"ResolvedAt",
+		}
+	}
+}
+
+// ResolvedAtNull sets ResolvedAt to null.
+func (b LargeBuilderFunc) ResolvedAtNull() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ResolvedAtNull")
+
+	return func(subject *Large) []string {
+		subject.ResolvedAt = null.Time{}
+
+		return []string{
+			"ResolvedAt",
+		}
+	}
+}
+
+// ClearResolvedAt sets ResolvedAt to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b LargeBuilderFunc) ClearResolvedAt() partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "ClearResolvedAt")
+
+	return func(subject *Large) []string {
+		subject.ResolvedAt = null.Time{}
+
+		return []string{
+			"ResolvedAt",
+		}
+	}
+}
+
+// ResolvedAtIfSet sets ResolvedAt to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) ResolvedAtIfSet(value *null.Time) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.ResolvedAt(*value)
+}
+
+func (b LargeBuilderFunc) CreatedAt(value time.Time) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "CreatedAt")
+
+	return func(subject *Large) []string {
+		subject.CreatedAt = value
+
+		return []string{
+			"CreatedAt",
+		}
+	}
+}
+
+// CreatedAtIfSet sets CreatedAt to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) CreatedAtIfSet(value *time.Time) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.CreatedAt(*value)
+}
+
+func (b LargeBuilderFunc) UpdatedAt(value time.Time) partial.Option[Large] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Large", "builder", "UpdatedAt")
+
+	return func(subject *Large) []string {
+		subject.UpdatedAt = value
+
+		return []string{
+			"UpdatedAt",
+		}
+	}
+}
+
+// UpdatedAtIfSet sets UpdatedAt to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b LargeBuilderFunc) UpdatedAtIfSet(value *time.Time) partial.Option[Large] {
+	if value == nil {
+		return func(*Large) []string {
+			return nil
+		}
+	}
+
+	return b.UpdatedAt(*value)
+}
+
+// SmallBuilder initialises a Small struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Setters: ID, Name, Count.
+//
+// For example:
+//
+//	model := SmallBuilder(
+//		SmallBuilder.ID(id),
+//	)
+var SmallBuilder = SmallBuilderFunc(func(opts ...partial.Option[Small]) partial.Partial[Small] {
+	apply := func(base Small) partial.Partial[Small] {
+		model := partial.Partial[Small]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(Small{})
+	model.SetApply(func(base Small) *Small {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type SmallBuilderFunc func(opts ...partial.Option[Small]) partial.Partial[Small]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/bench", "Small", "builder",
+		"ID",
+		"Name",
+		"Count",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b SmallBuilderFunc) From(existing Small) partial.Option[Small] {
+	return func(subject *Small) []string {
+		subject.ID = existing.ID
+		subject.Name = existing.Name
+		subject.Count = existing.Count
+
+		return []string{
+			"ID",
+			"Name",
+			"Count",
+		}
+	}
+}
+
+func (b SmallBuilderFunc) ID(value string) partial.Option[Small] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Small", "builder", "ID")
+
+	return func(subject *Small) []string {
+		subject.ID = value
+
+		return []string{
+			"ID",
+		}
+	}
+}
+
+// IDIfSet sets ID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b SmallBuilderFunc) IDIfSet(value *string) partial.Option[Small] {
+	if value == nil {
+		return func(*Small) []string {
+			return nil
+		}
+	}
+
+	return b.ID(*value)
+}
+
+func (b SmallBuilderFunc) Name(value string) partial.Option[Small] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Small", "builder", "Name")
+
+	return func(subject *Small) []string {
+		subject.Name = value
+
+		return []string{
+			"Name",
+		}
+	}
+}
+
+// NameIfSet sets Name to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b SmallBuilderFunc) NameIfSet(value *string) partial.Option[Small] {
+	if value == nil {
+		return func(*Small) []string {
+			return nil
+		}
+	}
+
+	return b.Name(*value)
+}
+
+func (b SmallBuilderFunc) Count(value int) partial.Option[Small] {
+	partial.RecordCoverage("github.com/incident-io/partial/bench", "Small", "builder", "Count")
+
+	return func(subject *Small) []string {
+		subject.Count = value
+
+		return []string{
+			"Count",
+		}
+	}
+}
+
+// CountIfSet sets Count to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b SmallBuilderFunc) CountIfSet(value *int) partial.Option[Small] {
+	if value == nil {
+		return func(*Small) []string {
+			return nil
+		}
+	}
+
+	return b.Count(*value)
+}
@@ -0,0 +1,96 @@
+//go:generate go run ../cmd/partial
+
+// Package bench benchmarks the core operations of partial against a small and a large
+// struct, so changes made for performance can be measured and regressions caught. Run
+// make bench-compare from the module root to compare against the main branch.
+package bench
+
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v3"
+)
+
+// codegen-partial:builder
+type Small struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// codegen-partial:builder
+type Large struct {
+	ID              string      `json:"id"`
+	OrganisationID  string      `json:"organisation_id"`
+	Name            string      `json:"name"`
+	Summary         null.String `json:"summary"`
+	Description     string      `json:"description"`
+	Status          string      `json:"status"`
+	Severity        string      `json:"severity"`
+	Mode            string      `json:"mode"`
+	ExternalID      null.String `json:"external_id"`
+	Reference       string      `json:"reference"`
+	SlackChannelID  string      `json:"slack_channel_id"`
+	SlackTeamID     string      `json:"slack_team_id"`
+	CreatorID       string      `json:"creator_id"`
+	LeadID          null.String `json:"lead_id"`
+	PostmortemURL   null.String `json:"postmortem_url"`
+	Visibility      string      `json:"visibility"`
+	Private         bool        `json:"private"`
+	Test            bool        `json:"test"`
+	Archived        bool        `json:"archived"`
+	UpdateCount     int         `json:"update_count"`
+	ActionCount     int         `json:"action_count"`
+	FollowUpCount   int         `json:"follow_up_count"`
+	AttachmentCount int         `json:"attachment_count"`
+	Duration        int64       `json:"duration"`
+	Score           float64     `json:"score"`
+	ReportedAt      time.Time   `json:"reported_at"`
+	AcceptedAt      null.Time   `json:"accepted_at"`
+	ResolvedAt      null.Time   `json:"resolved_at"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
+
+// NewSmall returns a Small with every field set.
+func NewSmall() Small {
+	return Small{ID: "small-id", Name: "Small", Count: 3}
+}
+
+// NewLarge returns a Large with every field set.
+func NewLarge() Large {
+	now := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	return Large{
+		ID:              "large-id",
+		OrganisationID:  "organisation-id",
+		Name:            "Large",
+		Summary:         null.StringFrom("Everything is on fire"),
+		Description:     "A long description of everything being on fire",
+		Status:          "triage",
+		Severity:        "major",
+		Mode:            "standard",
+		ExternalID:      null.StringFrom("external-id"),
+		Reference:       "INC-123",
+		SlackChannelID:  "C123",
+		SlackTeamID:     "T123",
+		CreatorID:       "creator-id",
+		LeadID:          null.StringFrom("lead-id"),
+		PostmortemURL:   null.StringFrom("https://example.com/postmortem"),
+		Visibility:      "public",
+		Private:         false,
+		Test:            true,
+		Archived:        false,
+		UpdateCount:     12,
+		ActionCount:     4,
+		FollowUpCount:   2,
+		AttachmentCount: 1,
+		Duration:        3600,
+		Score:           0.75,
+		ReportedAt:      now,
+		AcceptedAt:      null.TimeFrom(now),
+		ResolvedAt:      null.TimeFrom(now.Add(time.Hour)),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
@@ -0,0 +1,89 @@
+package partial
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/types"
+	"github.com/pkg/errors"
+)
+
+// EqualBytes matches a []byte or [N]byte holding exactly the expected bytes. Failure
+// messages show both values as hex and base64, rather than gomega's default dump of
+// each byte. This backs the generated matchers for binary fields.
+func EqualBytes(expected []byte) types.GomegaMatcher {
+	return &bytesMatcher{expected: expected}
+}
+
+// EqualHex is EqualBytes for an expected value written as a hex string.
+func EqualHex(expected string) types.GomegaMatcher {
+	decoded, err := hex.DecodeString(expected)
+	if err != nil {
+		return &bytesMatcher{err: errors.Wrap(err, "decoding expected hex")}
+	}
+
+	return &bytesMatcher{expected: decoded}
+}
+
+// EqualBase64 is EqualBytes for an expected value written as a standard base64 string.
+func EqualBase64(expected string) types.GomegaMatcher {
+	decoded, err := base64.StdEncoding.DecodeString(expected)
+	if err != nil {
+		return &bytesMatcher{err: errors.Wrap(err, "decoding expected base64")}
+	}
+
+	return &bytesMatcher{expected: decoded}
+}
+
+type bytesMatcher struct {
+	expected []byte
+	err      error // set if the expected value couldn't be decoded
+}
+
+func (m *bytesMatcher) Match(actual any) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+
+	actualBytes, err := bytesFrom(actual)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(actualBytes, m.expected), nil
+}
+
+func (m *bytesMatcher) FailureMessage(actual any) string {
+	return m.message(actual, "to equal")
+}
+
+func (m *bytesMatcher) NegatedFailureMessage(actual any) string {
+	return m.message(actual, "not to equal")
+}
+
+func (m *bytesMatcher) message(actual any, relation string) string {
+	actualBytes, _ := bytesFrom(actual)
+
+	return fmt.Sprintf("Expected\n%s\n%s\n%s", formatBytes(actualBytes), relation, formatBytes(m.expected))
+}
+
+// bytesFrom returns the contents of a []byte or [N]byte.
+func bytesFrom(actual any) ([]byte, error) {
+	value := reflect.ValueOf(actual)
+	if !value.IsValid() || (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) || value.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, errors.New(fmt.Sprintf("expected a []byte or [N]byte, got %T", actual))
+	}
+
+	actualBytes := make([]byte, value.Len())
+	reflect.Copy(reflect.ValueOf(actualBytes), value)
+
+	return actualBytes, nil
+}
+
+func formatBytes(value []byte) string {
+	return fmt.Sprintf("    <%d bytes>\n    hex:    %s\n    base64: %s",
+		len(value), hex.EncodeToString(value), base64.StdEncoding.EncodeToString(value))
+}
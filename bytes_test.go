@@ -0,0 +1,53 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Binary field matchers", func() {
+	org := &test.Organisation{
+		SigningKey: []byte("key"),
+		LogoDigest: [4]byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	It("matches byte slices and arrays", func() {
+		Expect(org).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.SigningKey([]byte("key")),
+			test.OrganisationMatcher.LogoDigest([4]byte{0xde, 0xad, 0xbe, 0xef}),
+		))
+	})
+
+	It("matches hex and base64 encoded values", func() {
+		Expect(org).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.SigningKeyBase64("a2V5"),
+			test.OrganisationMatcher.LogoDigestHex("deadbeef"),
+		))
+		Expect(org).NotTo(test.OrganisationMatcher(
+			test.OrganisationMatcher.LogoDigestHex("deadbeee"),
+		))
+	})
+
+	It("shows hex and base64 in failure messages", func() {
+		matcher := test.OrganisationMatcher(
+			test.OrganisationMatcher.LogoDigestHex("00000000"),
+		)
+
+		Expect(matcher.Match(org)).To(BeFalse())
+		Expect(matcher.FailureMessage(org)).To(And(
+			ContainSubstring("hex:    deadbeef"),
+			ContainSubstring("base64: 3q2+7w=="),
+		))
+	})
+
+	It("fails to match invalid encodings", func() {
+		matcher := test.OrganisationMatcher(
+			test.OrganisationMatcher.LogoDigestHex("not hex"),
+		)
+
+		Expect(matcher.Match(org)).To(BeFalse())
+		Expect(matcher.FailureMessage(org)).To(ContainSubstring("decoding expected hex"))
+	})
+})
@@ -0,0 +1,99 @@
+package partial
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// CacheKeySuffix returns a hash of the tracked fields and their values, which is the same
+// for any two partials that would write the same thing, regardless of the order their
+// fields were tracked in. This is for deduplicating identical pending patches, such as
+// those queued in an outbox or debounced before writing.
+//
+// Values of encrypted fields never reach the hash, as a plain hash of a guessable secret
+// can be reversed. If the registered Encryptor is a KeyDeriver, they're replaced by an
+// HMAC keyed from its encryption key, so rotating a secret is never mistaken for a
+// duplicate. Otherwise they're redacted, and patches that differ only in the value of an
+// encrypted field share a key.
+func (m Partial[T]) CacheKeySuffix() string {
+	hash := sha256.New()
+	m.writeCanonical(hash)
+
+	return hex.EncodeToString(hash.Sum(nil)[:16])
+}
+
+// writeCanonical writes each op in order of field name, so the output depends only on
+// what the partial would write, never on the order fields were tracked in.
+func (m Partial[T]) writeCanonical(w io.Writer) {
+	subjectType := reflect.TypeOf(m.Subject)
+
+	ops := m.Ops()
+	sort.SliceStable(ops, func(i, j int) bool {
+		return ops[i].FieldName < ops[j].FieldName
+	})
+
+	var macKey []byte
+	for _, op := range ops {
+		value := canonicalValue(op.Value)
+		if info, ok := schemaFieldFor(subjectType, op.FieldName); ok && info.Encrypted {
+			if macKey == nil {
+				macKey = cacheKeyMACKey()
+			}
+
+			value = canonicalEncryptedValue(macKey, value)
+		}
+
+		// Quoting each part keeps their boundaries unambiguous
+		fmt.Fprintf(w, "%q %q %q\n", op.FieldName, op.Kind, value)
+	}
+}
+
+// cacheKeyMACKey derives the key we HMAC encrypted values with from the registered
+// Encryptor, returning an empty key if it can't derive one.
+func cacheKeyMACKey() []byte {
+	encryptor, err := registeredEncryptor()
+	if err != nil {
+		return []byte{}
+	}
+
+	deriver, ok := encryptor.(KeyDeriver)
+	if !ok {
+		return []byte{}
+	}
+
+	key, err := deriver.DeriveKey("partial cache key")
+	if err != nil || key == nil {
+		return []byte{}
+	}
+
+	return key
+}
+
+// canonicalEncryptedValue stands in for the value of an encrypted field, which is an HMAC
+// of it when we have a key, and redacted when we don't.
+func canonicalEncryptedValue(macKey []byte, value string) string {
+	if len(macKey) == 0 {
+		return "[redacted]"
+	}
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write([]byte(value))
+
+	return "[hmac " + hex.EncodeToString(mac.Sum(nil)) + "]"
+}
+
+// canonicalValue encodes a value for writeCanonical, using JSON where we can as it
+// encodes what pointers point to, where fmt would give their address.
+func canonicalValue(value any) string {
+	if encoded, err := json.Marshal(value); err == nil {
+		return string(encoded)
+	}
+
+	return fmt.Sprintf("%#v", value)
+}
@@ -0,0 +1,90 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// derivingEncryptor derives keys from its own, as an encryptor wrapping a data key might
+// with HKDF.
+type derivingEncryptor struct {
+	reverseEncryptor
+	key string
+}
+
+func (e derivingEncryptor) DeriveKey(purpose string) ([]byte, error) {
+	return []byte(e.key + ":" + purpose), nil
+}
+
+var _ = Describe("CacheKeySuffix", func() {
+	It("is the same for partials that write the same thing in any order", func() {
+		first := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("x"),
+			test.OrganisationBuilder.IncidentCount(3),
+		)
+		second := test.OrganisationBuilder(
+			test.OrganisationBuilder.IncidentCount(3),
+			test.OrganisationBuilder.Name("x"),
+		)
+
+		Expect(first.CacheKeySuffix()).To(Equal(second.CacheKeySuffix()))
+		Expect(first.CacheKeySuffix()).To(HaveLen(32))
+	})
+
+	It("differs for different values, fields or operations", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.IncidentCount(3),
+		)
+
+		Expect(model.CacheKeySuffix()).NotTo(Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.IncidentCount(4),
+		).CacheKeySuffix()))
+		Expect(model.CacheKeySuffix()).NotTo(Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.IncidentCount(3),
+			test.OrganisationBuilder.Name(""),
+		).CacheKeySuffix()))
+		Expect(model.CacheKeySuffix()).NotTo(Equal(test.OrganisationBuilder().Increment("IncidentCount", 3).CacheKeySuffix()))
+	})
+
+	Describe("encrypted fields", func() {
+		var first, second partial.Partial[test.Organisation]
+
+		BeforeEach(func() {
+			first = test.OrganisationBuilder(
+				test.OrganisationBuilder.WebhookSecret("secret"),
+			)
+			second = test.OrganisationBuilder(
+				test.OrganisationBuilder.WebhookSecret("other"),
+			)
+		})
+
+		AfterEach(func() {
+			partial.RegisterEncryptor(nil)
+		})
+
+		It("differs for different values when the encryptor derives keys", func() {
+			partial.RegisterEncryptor(derivingEncryptor{key: "key"})
+
+			Expect(first.CacheKeySuffix()).NotTo(Equal(second.CacheKeySuffix()))
+			Expect(first.Fingerprint()).NotTo(Equal(second.Fingerprint()))
+		})
+
+		It("depends on the encryption key, so can't be reversed by hashing guesses", func() {
+			partial.RegisterEncryptor(derivingEncryptor{key: "key"})
+			suffix := first.CacheKeySuffix()
+
+			partial.RegisterEncryptor(derivingEncryptor{key: "rotated"})
+			Expect(first.CacheKeySuffix()).NotTo(Equal(suffix))
+		})
+
+		It("redacts values when the encryptor can't derive keys", func() {
+			partial.RegisterEncryptor(reverseEncryptor{})
+
+			Expect(first.CacheKeySuffix()).To(Equal(second.CacheKeySuffix()))
+			Expect(first.Fingerprint()).To(Equal(second.Fingerprint()))
+		})
+	})
+})
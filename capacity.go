@@ -0,0 +1,61 @@
+package partial
+
+import (
+	"sync/atomic"
+)
+
+// NewWithCapacity builds an empty Partial with room to track capacity fields before
+// FieldNames needs to grow, for hot paths that build a partial up one Add at a time.
+func NewWithCapacity[T any](capacity int) Partial[T] {
+	fieldNames := make([]string, 0, capacity)
+
+	return Partial[T]{
+		FieldNames: fieldNames,
+		apply: func(thing T) *T {
+			return &thing
+		},
+		buffer: newFieldNameBuffer(fieldNames),
+	}
+}
+
+// fieldNameBuffer records how much of the array backing FieldNames is in use.
+//
+// Partials are values, so two built from the same base share its FieldNames, and
+// appending to both in place would see one overwrite the other's fields. Only the first
+// to claim the spare capacity beyond a slice appends in place, and any others copy.
+type fieldNameBuffer struct {
+	data *string // the start of the backing array
+	used atomic.Int64
+}
+
+func newFieldNameBuffer(fieldNames []string) *fieldNameBuffer {
+	if cap(fieldNames) == 0 {
+		return nil
+	}
+
+	buffer := &fieldNameBuffer{data: &fieldNames[:1][0]}
+	buffer.used.Store(int64(len(fieldNames)))
+
+	return buffer
+}
+
+// appendFieldNames appends to FieldNames, in place if this partial can claim the spare
+// capacity, and otherwise into a new buffer.
+func (m *Partial[T]) appendFieldNames(fieldNames []string) {
+	if len(fieldNames) == 0 {
+		return
+	}
+
+	length := len(m.FieldNames)
+	if m.buffer != nil && length+len(fieldNames) <= cap(m.FieldNames) && m.buffer.data == &m.FieldNames[:1][0] {
+		if m.buffer.used.CompareAndSwap(int64(length), int64(length+len(fieldNames))) {
+			m.FieldNames = append(m.FieldNames, fieldNames...)
+			return
+		}
+	}
+
+	grown := make([]string, length, 2*(length+len(fieldNames)))
+	copy(grown, m.FieldNames)
+	m.FieldNames = append(grown, fieldNames...)
+	m.buffer = newFieldNameBuffer(m.FieldNames)
+}
@@ -0,0 +1,48 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewWithCapacity", func() {
+	It("adds fields without growing", func() {
+		model := partial.NewWithCapacity[test.Organisation](4).Add(
+			test.OrganisationBuilder.Name("Peanuts"),
+		).Add(
+			test.OrganisationBuilder.ID("id"),
+		)
+
+		Expect(model.FieldNames).To(Equal([]string{"Name", "ID"}))
+		Expect(cap(model.FieldNames)).To(Equal(4))
+
+		org := model.Apply(test.Organisation{ID: "old-id", Name: "Old"})
+		Expect(org.ID).To(Equal("id"))
+		Expect(org.Name).To(Equal("Peanuts"))
+	})
+
+	It("doesn't let partials built from the same base overwrite each other", func() {
+		base := partial.NewWithCapacity[test.Organisation](4).Add(
+			test.OrganisationBuilder.Name("Peanuts"),
+		)
+
+		withID := base.Add(test.OrganisationBuilder.ID("id"))
+		withFlag := base.Add(test.OrganisationBuilder.BoolFlag(true))
+
+		Expect(base.FieldNames).To(Equal([]string{"Name"}))
+		Expect(withID.FieldNames).To(Equal([]string{"Name", "ID"}))
+		Expect(withFlag.FieldNames).To(Equal([]string{"Name", "BoolFlag"}))
+	})
+
+	It("grows past the capacity", func() {
+		model := partial.NewWithCapacity[test.Organisation](1).Add(
+			test.OrganisationBuilder.Name("Peanuts"),
+			test.OrganisationBuilder.ID("id"),
+		)
+
+		Expect(model.FieldNames).To(Equal([]string{"Name", "ID"}))
+	})
+})
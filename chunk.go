@@ -0,0 +1,71 @@
+package partial
+
+// Chunk splits the partial into several, each tracking at most maxFields of its fields
+// in their original order. Use this when a downstream API limits how many fields can be
+// sent per request:
+//
+//	for _, chunk := range model.Chunk(10) {
+//		if err := client.Patch(ctx, id, chunk); err != nil {
+//			return err
+//		}
+//	}
+//
+// Each chunk applies only its own fields, keeping their values and whether they are
+// set or incremented, so applying every chunk in order has the same result as applying
+// the original. Derived fields stay in whichever chunk they fall, and are recomputed
+// when that chunk is applied. Every chunk runs the interceptors added with Before and
+// After. An empty partial produces no chunks.
+//
+// Chunk panics if maxFields is less than one.
+func (m Partial[T]) Chunk(maxFields int) []Partial[T] {
+	if maxFields < 1 {
+		panic("partial: chunks must have at least one field")
+	}
+
+	chunks := []Partial[T]{}
+	for start := 0; start < len(m.FieldNames); start += maxFields {
+		end := start + maxFields
+		if end > len(m.FieldNames) {
+			end = len(m.FieldNames)
+		}
+
+		chunks = append(chunks, m.subset(m.FieldNames[start:end]))
+	}
+
+	return chunks
+}
+
+// subset builds a Partial that applies only the given fields, taking their values from
+// the Subject.
+func (m Partial[T]) subset(fieldNames []string) Partial[T] {
+	subject := m.Subject
+
+	setFieldNames, incrementedFieldNames := []string{}, []string{}
+	for _, fieldName := range fieldNames {
+		if m.increments[fieldName] {
+			incrementedFieldNames = append(incrementedFieldNames, fieldName)
+		} else {
+			setFieldNames = append(setFieldNames, fieldName)
+		}
+	}
+
+	subsetModel := Partial[T]{
+		Subject:    subject,
+		FieldNames: append([]string{}, fieldNames...),
+		apply: func(base T) *T {
+			patched := base
+			copyFields(&patched, subject, setFieldNames)
+			incrementFields(&patched, base, subject, incrementedFieldNames)
+
+			return &patched
+		},
+		err:    m.err,
+		before: m.before,
+		after:  m.after,
+	}
+	if len(incrementedFieldNames) > 0 {
+		subsetModel.increments = copyIncrements(nil, incrementedFieldNames...)
+	}
+
+	return subsetModel
+}
@@ -0,0 +1,59 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Chunk", func() {
+	var model partial.Partial[test.Organisation]
+
+	BeforeEach(func() {
+		model = test.OrganisationBuilder(
+			test.OrganisationBuilder.ID("org-id"),
+			test.OrganisationBuilder.Name("My Org"),
+			test.OrganisationBuilder.BoolFlag(true),
+		).Increment("IncidentCount", 2)
+	})
+
+	It("splits fields into chunks of at most the given size", func() {
+		chunks := model.Chunk(3)
+
+		Expect(chunks).To(HaveLen(2))
+		Expect(chunks[0].FieldNames).To(Equal([]string{"ID", "Name", "BoolFlag"}))
+		Expect(chunks[1].FieldNames).To(Equal([]string{"IncidentCount"}))
+	})
+
+	It("applies only the fields in each chunk", func() {
+		chunks := model.Chunk(1)
+
+		Expect(chunks[1].Apply(test.Organisation{ID: "existing-id"})).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.ID("existing-id"),
+			test.OrganisationMatcher.Name("My Org"),
+			test.OrganisationMatcher.BoolFlag(false),
+		))
+	})
+
+	It("has the same result as the original when every chunk is applied", func() {
+		existing := test.Organisation{IncidentCount: 5}
+
+		result := existing
+		for _, chunk := range model.Chunk(2) {
+			result = *chunk.Apply(result)
+		}
+
+		Expect(result).To(Equal(*model.Apply(existing)))
+		Expect(result.IncidentCount).To(Equal(7))
+	})
+
+	It("returns no chunks for an empty partial", func() {
+		Expect(test.OrganisationBuilder().Chunk(2)).To(BeEmpty())
+	})
+
+	It("panics when chunks can't hold any fields", func() {
+		Expect(func() { model.Chunk(0) }).To(Panic())
+	})
+})
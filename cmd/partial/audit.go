@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// auditProblem is a field, or a whole package, that the generator can't handle.
+type auditProblem struct {
+	Position string // incident.go:12, relative to the audited directory
+	Subject  string // Incident.Parent, or the package directory
+	Reason   string
+}
+
+// runAudit walks every package under the directory, printing each annotated field that
+// the generator can't handle along with why, so adoption can be planned up front rather
+// than discovered one go generate at a time. It fails if there were any problems.
+func runAudit(dir string, args []string) error {
+	flags := flag.NewFlagSet("audit", flag.ExitOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	root := dir
+	if flags.NArg() > 0 {
+		root = filepath.Join(dir, flags.Arg(0))
+	}
+
+	problems, err := audit(root)
+	if err != nil {
+		return err
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("%s: %s: %s\n", problem.Position, problem.Subject, problem.Reason)
+	}
+
+	if len(problems) > 0 {
+		return errors.New(fmt.Sprintf("found %d unsupported fields", len(problems)))
+	}
+
+	return nil
+}
+
+func audit(root string) ([]auditProblem, error) {
+	dirs, err := walkPackageDirs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []auditProblem{}
+	for _, dir := range dirs {
+		dirProblems, err := auditDir(root, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		problems = append(problems, dirProblems...)
+	}
+
+	return problems, nil
+}
+
+// auditDir checks every field of each annotated type in a single package.
+func auditDir(root, dir string) ([]auditProblem, error) {
+	relativeTo := func(filename string) string {
+		if relative, err := filepath.Rel(root, filename); err == nil {
+			return relative
+		}
+
+		return filename
+	}
+
+	// A package we can't find targets in can't be generated at all, but we keep going to
+	// report on every other package.
+	targets, err := findTargets(dir)
+	if err != nil {
+		return []auditProblem{{
+			Position: relativeTo(dir),
+			Subject:  "package",
+			Reason:   err.Error(),
+		}}, nil
+	}
+
+	problems := []auditProblem{}
+	for _, target := range targets {
+		for _, field := range target.StructType.Fields.List {
+			// External types live outside the audited directory, so are reported in full
+			position := target.Fset.Position(field.Pos()).String()
+			if target.ImportPath == "" {
+				position = relativeTo(position)
+			}
+
+			if len(field.Names) == 0 {
+				problems = append(problems, auditProblem{
+					Position: position,
+					Subject:  target.Name,
+					Reason:   "embedded fields are skipped",
+				})
+
+				continue
+			}
+
+			if _, err := structFieldFor(target, field); err != nil {
+				problems = append(problems, auditProblem{
+					Position: position,
+					Subject:  fmt.Sprintf("%s.%s", target.Name, field.Names[0].Name),
+					Reason:   errors.Cause(err).Error(),
+				})
+			}
+		}
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("audit", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{
+			"thing.go": thingSource,
+			"broken.go": `package things
+
+// codegen-partial:builder
+type Broken struct {
+	Thing
+	Callback func() ` + "`json:\"callback\"`" + `
+}
+`,
+			"nested/nested.go": "package nested\n\ntype Nested struct {\n",
+		})
+	})
+
+	DescribeTable("reporting what the generator can't handle",
+		func(line string) {
+			output, err := captureStdout(func() error { return runAudit(dir, nil) })
+			Expect(err).To(MatchError("found 3 unsupported fields"))
+
+			Expect(output).To(ContainSubstring(line))
+		},
+		Entry("embedded fields", "broken.go:5:2: Broken: embedded fields are skipped\n"),
+		Entry("fields of unsupported types", "broken.go:6:2: Broken.Callback: "),
+		Entry("packages that don't parse", "nested: package: "),
+	)
+
+	It("passes when every field is supported", func() {
+		Expect(os.Remove(filepath.Join(dir, "broken.go"))).To(Succeed())
+		Expect(os.RemoveAll(filepath.Join(dir, "nested"))).To(Succeed())
+
+		output, err := captureStdout(func() error { return runAudit(dir, nil) })
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(BeEmpty())
+	})
+})
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// staleGenFiles describes each generated file that differs from what we'd generate now,
+// when running with -check.
+type staleGenFiles []string
+
+func (f staleGenFiles) Error() string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "%d generated file(s) are out of date, re-run go generate:", len(f))
+	for _, stale := range f {
+		fmt.Fprintf(&report, "\n  %s", stale)
+	}
+
+	return report.String()
+}
+
+// checkGenFile compares the generated source against the file on disk, returning a
+// summary of how they differ, or an empty string if the file is up to date. Nothing is
+// written.
+func checkGenFile(filename string, source []byte) (string, error) {
+	generated, err := formatGenSource(filename, source)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Sprintf("%s: missing", filename), nil
+		}
+
+		return "", err
+	}
+
+	if bytes.Equal(existing, generated) {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s: %s", filename, diffSummaryFor(existing, generated)), nil
+}
+
+// diffSummaryFor describes where two versions of a file first differ, and how many
+// lines each has, which is enough to point someone at the problem without a full diff.
+func diffSummaryFor(existing, generated []byte) string {
+	existingLines := strings.Split(string(existing), "\n")
+	generatedLines := strings.Split(string(generated), "\n")
+
+	line := 0
+	for line < len(existingLines) && line < len(generatedLines) && existingLines[line] == generatedLines[line] {
+		line++
+	}
+
+	return fmt.Sprintf("differs from line %d (%d lines on disk, %d generated)", line+1, len(existingLines), len(generatedLines))
+}
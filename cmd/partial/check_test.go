@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("-check", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{"thing.go": thingSource})
+		Expect(runGen(dir, nil)).To(Succeed())
+	})
+
+	DescribeTable("comparing against the files on disk",
+		func(change func(genFile string), message string) {
+			genFile := filepath.Join(dir, "thing.genpartial.go")
+			change(genFile)
+
+			before, _ := os.ReadFile(genFile)
+			for _, args := range [][]string{{"check"}, {"-check"}} {
+				err := runCommand(dir, args)
+				if message == "" {
+					Expect(err).NotTo(HaveOccurred(), "%v", args)
+				} else {
+					Expect(err).To(BeAssignableToTypeOf(staleGenFiles{}), "%v", args)
+					Expect(err).To(MatchError(ContainSubstring("1 generated file(s) are out of date")), "%v", args)
+					Expect(err).To(MatchError(ContainSubstring(message)), "%v", args)
+				}
+			}
+
+			after, _ := os.ReadFile(genFile)
+			Expect(after).To(Equal(before), "check should never write")
+		},
+		Entry("up to date", func(string) {}, ""),
+		Entry("edited", func(genFile string) {
+			source := readFixtureFile(dir, "thing.genpartial.go")
+			Expect(os.WriteFile(genFile, []byte(source+"\n// edited\n"), 0o644)).To(Succeed())
+		}, "thing.genpartial.go: differs from line"),
+		Entry("missing", func(genFile string) {
+			Expect(os.Remove(genFile)).To(Succeed())
+		}, "thing.genpartial.go: missing"),
+		Entry("for a type that's no longer annotated", func(string) {
+			Expect(os.WriteFile(filepath.Join(dir, "thing.go"), []byte("package things\n\ntype Thing struct{}\n"), 0o644)).To(Succeed())
+		}, "thing.genpartial.go"),
+	)
+
+	It("exits non-zero when files are stale", func() {
+		binary := filepath.Join(dir, "partial")
+		build := exec.Command("go", "build", "-o", binary, ".")
+		output, err := build.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+
+		check := exec.Command(binary, "check")
+		check.Dir = dir
+		Expect(check.Run()).To(Succeed())
+
+		Expect(os.Remove(filepath.Join(dir, "thing.genpartial.go"))).To(Succeed())
+
+		check = exec.Command(binary, "check")
+		check.Dir = dir
+		output, err = check.CombinedOutput()
+		Expect(err).To(BeAssignableToTypeOf(&exec.ExitError{}))
+		Expect(err.(*exec.ExitError).ExitCode()).To(Equal(1))
+		Expect(string(output)).To(ContainSubstring("thing.genpartial.go: missing"))
+	})
+})
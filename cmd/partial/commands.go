@@ -0,0 +1,220 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// commands are run by naming them as the first argument, such as partial list ./...
+// Anything else is passed to gen, which is what running partial with no command does, so
+// existing go:generate comments keep working.
+var commands = map[string]func(dir string, args []string) error{
+	"gen":           runGen,
+	"check":         runCheck,
+	"clean":         runClean,
+	"list":          runList,
+	"inspect":       runInspect,
+	"audit":         runAudit,
+	"prune-report":  runPruneReport,
+	"check-version": func(dir string, _ []string) error { return runCheckVersion(dir) },
+}
+
+func runCommand(dir string, args []string) error {
+	if len(args) > 0 {
+		if command, ok := commands[args[0]]; ok {
+			return command(dir, args[1:])
+		}
+	}
+
+	return runGen(dir, args)
+}
+
+// runGen generates code for every annotated type in the packages matching the arguments.
+func runGen(dir string, args []string) error {
+	opts, err := parseGenerateFlags(dir, args)
+	if err != nil {
+		return err
+	}
+	if opts.Check {
+		return runCheckFor(args, opts)
+	}
+
+	return runGenerationFor(opts.Dirs, opts)
+}
+
+// runCheck is gen -check, exiting non-zero if any generated file is out of date.
+func runCheck(dir string, args []string) error {
+	opts, err := parseGenerateFlags(dir, args)
+	if err != nil {
+		return err
+	}
+	opts.Check = true
+
+	return runCheckFor(args, opts)
+}
+
+// runCheckFor checks each package with the flags of its go:generate directive, such as
+// -guard-defaults, as otherwise files generated with them would always be reported as
+// stale. Flags given to check itself apply on top. Packages without a directive of their
+// own are checked with only the flags given to check.
+func runCheckFor(args []string, opts generateOptions) error {
+	flagArgs := args[:len(args)-len(opts.Patterns)]
+
+	failures, stale := generationFailures{}, staleGenFiles{}
+	for _, packageDir := range opts.Dirs {
+		dirOpts, err := checkOptionsFor(packageDir, flagArgs)
+		if err != nil {
+			return errors.Wrap(err, packageDir)
+		}
+
+		err = runGenerationFor([]string{packageDir}, dirOpts)
+		switch err := err.(type) {
+		case nil:
+		case staleGenFiles:
+			stale = append(stale, err...)
+		case generationFailures:
+			failures = append(failures, err...)
+		default:
+			return err
+		}
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+
+	if opts.FieldsJSON != "" {
+		summary, err := emitFieldsJSON(opts.FieldsJSON, opts.Dirs, true)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("emitting %s", opts.FieldsJSON))
+		}
+		if summary != "" {
+			stale = append(stale, summary)
+		}
+	}
+
+	if len(stale) > 0 {
+		return stale
+	}
+
+	return nil
+}
+
+// checkOptionsFor returns the options to check the package in dir with, which are those
+// of its go:generate directive overridden by flagArgs. Import aliases and templates are
+// global, so are reset before parsing either, rather than leaking between packages.
+func checkOptionsFor(dir string, flagArgs []string) (generateOptions, error) {
+	directiveArgs, _, err := generateDirectiveArgsFor(dir)
+	if err != nil {
+		return generateOptions{}, err
+	}
+
+	importAliases, templateOverrides = map[string]string{}, map[string]*template.Template{}
+
+	// The directive's own patterns are relative to its package, which is all we check
+	directiveOpts, err := parseGenerateFlags(dir, directiveArgs)
+	if err != nil {
+		return generateOptions{}, errors.Wrap(err, "parsing go:generate flags")
+	}
+	directiveOpts.Dirs, directiveOpts.Patterns = []string{dir}, nil
+
+	opts, err := parseGenerateFlags(dir, flagArgs)
+	if err != nil {
+		return generateOptions{}, err
+	}
+
+	opts = directiveOpts.overriddenBy(opts)
+	opts.Check = true
+
+	return opts, nil
+}
+
+// runClean removes every generated file from the packages matching the arguments, such
+// as before regenerating from scratch or removing the generator altogether.
+func runClean(dir string, args []string) error {
+	flags := flag.NewFlagSet("clean", flag.ExitOnError)
+	dryRun := flags.Bool("n", false, "print the files that would be removed, without removing them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dirs, err := packageDirsForArgs(dir, flags.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, packageDir := range dirs {
+		genFiles, err := staleGenFilesFor(packageDir, map[string]bool{})
+		if err != nil {
+			return err
+		}
+
+		for _, genFile := range genFiles {
+			if *dryRun {
+				fmt.Println(relativePathFor(dir, genFile))
+				continue
+			}
+
+			log.Printf("removing %s", genFile)
+			if err := os.Remove(genFile); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// runList prints every annotated type in the packages matching the arguments, with the
+// tags we'd generate for it and where it's declared.
+func runList(dir string, args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	dirs, err := packageDirsForArgs(dir, flags.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, packageDir := range dirs {
+		targets, err := findTargets(packageDir)
+		if err != nil {
+			return err
+		}
+
+		for _, target := range targets {
+			tagNames := []string{}
+			for _, tag := range target.Tags {
+				if tag.TestOnly {
+					tagNames = append(tagNames, tag.Name+"(testonly)")
+				} else {
+					tagNames = append(tagNames, tag.Name)
+				}
+			}
+
+			fmt.Printf("%s.%s\t%s\t%s\n", target.Package, target.Name, strings.Join(tagNames, ","), relativePathFor(dir, target.Filename))
+		}
+	}
+
+	return nil
+}
+
+// relativePathFor returns filename relative to dir when it's beneath it, which is easier
+// to read than the absolute paths we work with.
+func relativePathFor(dir, filename string) string {
+	relative, err := filepath.Rel(dir, filename)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return filename
+	}
+
+	return relative
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("check", func() {
+	It("passes on this repository's own tree", func() {
+		root, err := filepath.Abs("../..")
+		Expect(err).NotTo(HaveOccurred())
+
+		project, err = loadProjectConfig(root)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(runCheck(root, []string{"./..."})).To(Succeed())
+	})
+
+	Context("with a go:generate directive passing flags", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = writeFixturePackage(map[string]string{
+				"thing.go": "//go:generate go run ../../../cmd/partial -guard-defaults\n\n" + thingSource,
+			})
+		})
+
+		It("checks with the directive's flags", func() {
+			Expect(runGen(dir, []string{"-guard-defaults"})).To(Succeed())
+
+			Expect(runCheck(dir, nil)).To(Succeed())
+			Expect(runGen(dir, []string{"-check"})).To(Succeed())
+		})
+
+		It("reports files generated without them as stale", func() {
+			Expect(runGen(dir, nil)).To(Succeed())
+
+			Expect(runCheck(dir, nil)).To(MatchError(ContainSubstring("thing.genpartial.go: differs from line")))
+		})
+
+		It("applies flags given to check on top", func() {
+			Expect(runGen(dir, []string{"-guard-defaults"})).To(Succeed())
+
+			Expect(runCheck(dir, []string{"-only", "builder"})).To(MatchError(ContainSubstring("thing.genpartial.go: differs from line")))
+		})
+	})
+})
+
+var _ = Describe("generateDirectiveArgsFor", func() {
+	DescribeTable("finding the generator's arguments",
+		func(command string, expected []string) {
+			words, err := splitGenerateCommand(command)
+			Expect(err).NotTo(HaveOccurred())
+
+			args, ok := generatorArgsFor(words)
+			if expected == nil {
+				Expect(ok).To(BeFalse())
+				return
+			}
+
+			Expect(ok).To(BeTrue())
+			Expect(args).To(Equal(expected))
+		},
+		Entry("go run with a relative path", "go run ../cmd/partial -guard-defaults", []string{"-guard-defaults"}),
+		Entry("go run with the import path", "go run github.com/incident-io/partial/cmd/partial@v1.2.0 -deep-copy ./...", []string{"-deep-copy", "./..."}),
+		Entry("go run with flags of its own", "go run -mod=mod ../cmd/partial\t-only builder", []string{"-only", "builder"}),
+		Entry("a binary on the PATH", `partial -pragma "//nolint:all"`, []string{"-pragma", "//nolint:all"}),
+		Entry("with no arguments", "go run ../cmd/partial", []string{}),
+		Entry("another generator", "go run golang.org/x/tools/cmd/stringer -type=Severity", nil),
+		Entry("go run of a file", "go run gen.go", nil),
+	)
+
+	It("fails for an unterminated quoted string", func() {
+		_, err := splitGenerateCommand(`partial -pragma "//nolint`)
+		Expect(err).To(MatchError(ContainSubstring("unterminated quoted string")))
+	})
+
+	It("returns false for packages without a directive", func() {
+		dir := writeFixturePackage(map[string]string{"thing.go": thingSource})
+
+		_, ok, err := generateDirectiveArgsFor(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("clean and list", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{
+			"thing.go": thingSource,
+			"nested/widget.go": `package nested
+
+// codegen-partial:matcher(testonly)
+type Widget struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+		})
+
+		Expect(runGen(dir, []string{"./..."})).To(Succeed())
+	})
+
+	DescribeTable("running the subcommand",
+		func(args []string, expected string, removed bool) {
+			output, err := captureStdout(func() error { return runCommand(dir, args) })
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal(expected))
+
+			for _, genFile := range []string{"thing.genpartial.go", "nested/widget.genpartial_test.go"} {
+				_, err := os.Stat(filepath.Join(dir, genFile))
+				Expect(os.IsNotExist(err)).To(Equal(removed), genFile)
+			}
+		},
+		Entry("list", []string{"list", "./..."},
+			"things.Thing\tbuilder,matcher\tthing.go\nnested.Widget\tmatcher(testonly)\tnested/widget.go\n", false),
+		Entry("list of a single package", []string{"list", "nested"},
+			"nested.Widget\tmatcher(testonly)\tnested/widget.go\n", false),
+		Entry("clean -n", []string{"clean", "-n", "./..."},
+			"thing.genpartial.go\nnested/widget.genpartial_test.go\n", false),
+		Entry("clean", []string{"clean", "./..."}, "", true),
+	)
+})
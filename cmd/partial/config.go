@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// projectConfigFilename configures the generator for a whole module, and lives at the
+// module root alongside go.mod:
+//
+//	packages: [./models/..., ./domain]
+//	tags: [builder, matcher(testonly)]
+//	builder_suffix: Factory
+//	matcher_suffix: Matcher
+//	output_suffix: .gen
+//	file_per_type: true
+const projectConfigFilename = "partial.yaml"
+
+type projectConfig struct {
+	// Packages are generated for when partial is run from the module root with no
+	// packages of its own, and are the only packages that Tags apply to.
+	Packages []string `yaml:"packages"` // ./models/...
+
+	// Tags are generated for every exported struct in Packages that has no annotation of
+	// its own, so packages of models don't need annotating type by type.
+	Tags []string `yaml:"tags"` // builder, matcher(testonly)
+
+	BuilderSuffix string `yaml:"builder_suffix"` // IncidentBuilder
+	MatcherSuffix string `yaml:"matcher_suffix"` // IncidentMatcher
+	OutputSuffix  string `yaml:"output_suffix"`  // structs.genpartial.go
+
+	// FilePerType writes the code for each annotated type into a file named after it,
+	// rather than one per source file, keeping diffs reviewable when a single file of
+	// models declares dozens of types.
+	FilePerType bool `yaml:"file_per_type"` // incident_role.genpartial.go
+
+	root string          // the module root the config was loaded from
+	tags []codegenTag    // Tags, parsed
+	dirs map[string]bool // the directories matching Packages
+}
+
+// project is the configuration of the module we're running in, or the defaults if it
+// has no partial.yaml.
+var project = defaultProjectConfig()
+
+func defaultProjectConfig() *projectConfig {
+	return &projectConfig{
+		BuilderSuffix: "Builder",
+		MatcherSuffix: "Matcher",
+		OutputSuffix:  ".genpartial",
+		dirs:          map[string]bool{},
+	}
+}
+
+// loadProjectConfig reads the partial.yaml at the root of the module containing dir, if
+// there is one, falling back to the defaults for anything it doesn't set.
+func loadProjectConfig(dir string) (*projectConfig, error) {
+	config := defaultProjectConfig()
+
+	root, ok := moduleRootFor(dir)
+	if !ok {
+		return config, nil
+	}
+	config.root = root
+
+	data, err := os.ReadFile(filepath.Join(root, projectConfigFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+
+		return nil, err
+	}
+
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("parsing %s", projectConfigFilename))
+	}
+
+	for _, suffix := range []string{config.BuilderSuffix, config.MatcherSuffix} {
+		if !token.IsIdentifier(suffix) || !token.IsExported(suffix) {
+			return nil, errors.New(fmt.Sprintf("%s: suffix must be an exported identifier: %s", projectConfigFilename, suffix))
+		}
+	}
+	if !strings.HasPrefix(config.OutputSuffix, ".") || strings.HasSuffix(config.OutputSuffix, ".go") || strings.Contains(config.OutputSuffix, "/") {
+		return nil, errors.New(fmt.Sprintf("%s: output_suffix must start with a . and not include .go: %s", projectConfigFilename, config.OutputSuffix))
+	}
+
+	if len(config.Tags) > 0 {
+		config.tags, err = parseCodegenTags(strings.Join(config.Tags, ","))
+		if err != nil {
+			return nil, errors.Wrap(err, projectConfigFilename)
+		}
+	}
+	if len(config.tags) > 0 && len(config.Packages) == 0 {
+		return nil, errors.New(fmt.Sprintf("%s: tags need packages to apply to", projectConfigFilename))
+	}
+
+	for _, pattern := range config.Packages {
+		dirs, err := packageDirsFor(root, pattern)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("%s: finding packages matching %s", projectConfigFilename, pattern))
+		}
+
+		for _, dir := range dirs {
+			config.dirs[dir] = true
+		}
+	}
+
+	return config, nil
+}
+
+// moduleRootFor finds the nearest directory at or above dir with a go.mod.
+func moduleRootFor(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// defaultTagsFor returns the tags to generate for an unannotated type in the given
+// directory, which is none unless partial.yaml lists the package.
+func (c *projectConfig) defaultTagsFor(dir string) []codegenTag {
+	dir, err := filepath.Abs(dir)
+	if err != nil || !c.dirs[dir] {
+		return nil
+	}
+
+	return append([]codegenTag{}, c.tags...)
+}
+
+func builderNameFor(typeName string) string {
+	return typeName + project.BuilderSuffix
+}
+
+// matcherNameFor names the matcher of a type. Matchers are generated into the package
+// that references them, so any package the type is qualified with is dropped.
+func matcherNameFor(typeName string) string {
+	return typeName[strings.LastIndex(typeName, ".")+1:] + project.MatcherSuffix
+}
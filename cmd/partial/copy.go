@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// deepCopyFuncFor returns the body of a func that deep copies a value of the given type
+// from value, or an empty string if the type holds no references and can be copied by
+// assignment.
+//
+// Slices, maps, arrays and pointers are copied recursively, so a [][]string or
+// map[string]*Action shares nothing with the original. Anything else, such as a struct
+// or a named type, is copied by assignment, so a pointer to a struct copies the struct
+// but not any slices inside it.
+//
+// Defined types, such as type Tags []string, are copied as their underlying type, which
+// is assignable to them.
+func deepCopyFuncFor(typeName, underlyingTypeName string) (string, error) {
+	if underlyingTypeName != "" {
+		typeName = underlyingTypeName
+	}
+
+	expr, err := parser.ParseExpr(typeName)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("parsing type %s", typeName))
+	}
+
+	if !holdsReferences(expr) {
+		return "", nil
+	}
+
+	lines, err := deepCopyLinesFor("copied", "value", expr, 0)
+	if err != nil {
+		return "", err
+	}
+
+	lines = append([]string{fmt.Sprintf("var copied %s", typeName)}, lines...)
+
+	return strings.Join(append(lines, "", "return copied"), "\n"), nil
+}
+
+// holdsReferences is true if copying a value of the type by assignment would share
+// memory with the original.
+func holdsReferences(expr ast.Expr) bool {
+	switch typeExpr := expr.(type) {
+	case *ast.StarExpr, *ast.MapType:
+		return true
+
+	case *ast.ArrayType:
+		return typeExpr.Len == nil || holdsReferences(typeExpr.Elt)
+	}
+
+	return false
+}
+
+// deepCopyLinesFor returns statements that deep copy src into dst, which must already be
+// declared with the given type. Depth keeps the names of loop variables unique.
+func deepCopyLinesFor(dst, src string, expr ast.Expr, depth int) ([]string, error) {
+	if !holdsReferences(expr) {
+		return []string{fmt.Sprintf("%s = %s", dst, src)}, nil
+	}
+
+	typeName, err := typeNameFor(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		idx   = fmt.Sprintf("idx%d", depth)
+		key   = fmt.Sprintf("key%d", depth)
+		value = fmt.Sprintf("value%d", depth)
+	)
+
+	switch typeExpr := expr.(type) {
+	case *ast.StarExpr:
+		if !holdsReferences(typeExpr.X) {
+			return []string{
+				fmt.Sprintf("if %s != nil {", src),
+				fmt.Sprintf("%s := *%s", value, src),
+				fmt.Sprintf("%s = &%s", dst, value),
+				"}",
+			}, nil
+		}
+
+		elemTypeName, err := typeNameFor(typeExpr.X)
+		if err != nil {
+			return nil, err
+		}
+
+		elemLines, err := deepCopyLinesFor(value, fmt.Sprintf("(*%s)", src), typeExpr.X, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		lines := []string{fmt.Sprintf("if %s != nil {", src), fmt.Sprintf("var %s %s", value, elemTypeName)}
+		lines = append(lines, elemLines...)
+
+		return append(lines, fmt.Sprintf("%s = &%s", dst, value), "}"), nil
+
+	case *ast.ArrayType:
+		if typeExpr.Len != nil {
+			elemLines, err := deepCopyLinesFor(fmt.Sprintf("%s[%s]", dst, idx), fmt.Sprintf("%s[%s]", src, idx), typeExpr.Elt, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			lines := []string{fmt.Sprintf("for %s := range %s {", idx, src)}
+			lines = append(lines, elemLines...)
+
+			return append(lines, "}"), nil
+		}
+
+		lines := []string{fmt.Sprintf("if %s != nil {", src), fmt.Sprintf("%s = make(%s, len(%s))", dst, typeName, src)}
+		if !holdsReferences(typeExpr.Elt) {
+			return append(lines, fmt.Sprintf("copy(%s, %s)", dst, src), "}"), nil
+		}
+
+		elemLines, err := deepCopyLinesFor(fmt.Sprintf("%s[%s]", dst, idx), fmt.Sprintf("%s[%s]", src, idx), typeExpr.Elt, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, fmt.Sprintf("for %s := range %s {", idx, src))
+		lines = append(lines, elemLines...)
+
+		return append(lines, "}", "}"), nil
+
+	case *ast.MapType:
+		elemTypeName, err := typeNameFor(typeExpr.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		// Map elements aren't addressable, so we copy each into a variable first
+		elemLines, err := deepCopyLinesFor(value, fmt.Sprintf("%s[%s]", src, key), typeExpr.Value, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		lines := []string{
+			fmt.Sprintf("if %s != nil {", src),
+			fmt.Sprintf("%s = make(%s, len(%s))", dst, typeName, src),
+			fmt.Sprintf("for %s := range %s {", key, src),
+			fmt.Sprintf("var %s %s", value, elemTypeName),
+		}
+		lines = append(lines, elemLines...)
+
+		return append(lines, fmt.Sprintf("%s[%s] = %s", dst, key, value), "}", "}"), nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("cannot deep copy %s", typeName))
+}
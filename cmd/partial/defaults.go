@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// packageDefaultsDirective configures every annotated type in a package at once, saving
+// repeating the same tags and options on each of a family of types:
+//
+//	//go:partial-defaults builder,matcher exclude=CreatedAt,UpdatedAt
+//
+// Types annotated with a bare codegen-partial take the tags, and every type skips the
+// excluded fields. Types with tags or an exclude of their own use those instead.
+const packageDefaultsDirective = "//go:partial-defaults"
+
+// annotation is what we parse from a codegen-partial comment or package defaults
+// directive. Exclude is nil when the annotation doesn't set it, so we can tell that
+// apart from excluding nothing.
+type annotation struct {
+	Tags    []codegenTag
+	Exclude []string // CreatedAt
+}
+
+// annotationIn finds a codegen-partial annotation in a line of doc comment, returning
+// its tags and the key=value options that follow them. A bare codegen-partial, taking
+// its tags from the package defaults, must start the line.
+func annotationIn(line string) (string, []string, bool) {
+	fields := strings.Fields(line)
+	for idx, field := range fields {
+		tags, ok := strings.CutPrefix(field, "codegen-partial:")
+		if !ok {
+			if field != "codegen-partial" || idx != 0 {
+				continue
+			}
+
+			tags = ""
+		}
+
+		options := []string{}
+		for _, option := range fields[idx+1:] {
+			if !strings.Contains(option, "=") {
+				break
+			}
+
+			options = append(options, option)
+		}
+
+		return tags, options, true
+	}
+
+	return "", nil, false
+}
+
+// annotationFor finds the codegen-partial annotation in a doc comment.
+func annotationFor(doc string) (string, []string, bool) {
+	for _, line := range strings.Split(doc, "\n") {
+		if tags, options, ok := annotationIn(line); ok {
+			return tags, options, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// parseAnnotation parses the tags and any space separated key=value options that follow
+// them, such as "builder,matcher exclude=CreatedAt". Tags may be empty, for annotations
+// that take them from the package defaults.
+func parseAnnotation(tags string, options []string) (annotation, error) {
+	parsed := annotation{}
+	if tags != "" {
+		var err error
+		parsed.Tags, err = parseCodegenTags(tags)
+		if err != nil {
+			return parsed, err
+		}
+	}
+
+	for _, option := range options {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "exclude":
+			parsed.Exclude = []string{}
+			for _, fieldName := range strings.Split(value, ",") {
+				if !token.IsIdentifier(fieldName) {
+					return parsed, errors.New(fmt.Sprintf("invalid field name to exclude: %q", fieldName))
+				}
+
+				parsed.Exclude = append(parsed.Exclude, fieldName)
+			}
+		default:
+			return parsed, errors.New(fmt.Sprintf("unrecognised annotation option: %s", option))
+		}
+	}
+
+	return parsed, nil
+}
+
+// findPackageDefaults returns the defaults from the go:partial-defaults directive in any
+// of the files, or an empty annotation if there isn't one. A package may only have one.
+func findPackageDefaults(fset *token.FileSet, pkgs map[string]*ast.Package) (annotation, error) {
+	filenames := []string{}
+	files := map[string]*ast.File{}
+	for _, pkg := range pkgs {
+		for filename, file := range pkg.Files {
+			filenames = append(filenames, filename)
+			files[filename] = file
+		}
+	}
+	sort.Strings(filenames)
+
+	defaults, foundIn := annotation{}, ""
+	for _, filename := range filenames {
+		for _, group := range files[filename].Comments {
+			for _, comment := range group.List {
+				fields := strings.Fields(comment.Text)
+				if len(fields) == 0 || fields[0] != packageDefaultsDirective {
+					continue
+				}
+
+				position := fset.Position(comment.Pos())
+				if foundIn != "" {
+					return defaults, errors.New(fmt.Sprintf("%s: package defaults already set in %s", position, foundIn))
+				}
+				if len(fields) < 2 {
+					return defaults, errors.New(fmt.Sprintf("%s: package defaults need tags", position))
+				}
+
+				var err error
+				defaults, err = parseAnnotation(fields[1], fields[2:])
+				if err != nil {
+					return defaults, errors.Wrap(err, position.String())
+				}
+
+				foundIn = position.String()
+			}
+		}
+	}
+
+	return defaults, nil
+}
+
+// checkExcludedFields errors if any of the excluded fields aren't on the struct, which
+// would usually be a typo. Package defaults aren't checked, as they apply to types with
+// different fields.
+func checkExcludedFields(structType *ast.StructType, exclude []string) error {
+	fieldNames := map[string]bool{}
+	for _, field := range structType.Fields.List {
+		for _, name := range field.Names {
+			fieldNames[name.Name] = true
+		}
+	}
+
+	for _, fieldName := range exclude {
+		if !fieldNames[fieldName] {
+			return errors.New(fmt.Sprintf("cannot exclude unknown field %s", fieldName))
+		}
+	}
+
+	return nil
+}
+
+func excludedFieldsFor(exclude []string) map[string]bool {
+	excluded := map[string]bool{}
+	for _, fieldName := range exclude {
+		excluded[fieldName] = true
+	}
+
+	return excluded
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// generateDirectiveArgsFor finds the //go:generate directive running the generator in
+// the package in dir, returning the arguments it passes, such as -guard-defaults. This
+// lets check compare against what go generate would write, rather than what we'd write
+// with only the flags check was given. It returns false if the package has no such
+// directive.
+func generateDirectiveArgsFor(dir string) ([]string, bool, error) {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, false, err
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		if isGenFile(filename) {
+			continue
+		}
+
+		source, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, line := range strings.Split(string(source), "\n") {
+			command, ok := strings.CutPrefix(line, "//go:generate ")
+			if !ok {
+				continue
+			}
+
+			words, err := splitGenerateCommand(command)
+			if err != nil {
+				return nil, false, errors.Wrap(err, fmt.Sprintf("parsing go:generate in %s", filename))
+			}
+
+			if args, ok := generatorArgsFor(words); ok {
+				return args, true, nil
+			}
+		}
+	}
+
+	return nil, false, nil
+}
+
+// generatorArgsFor returns the arguments passed to the generator if the words of a
+// go:generate command run it, either as go run ../cmd/partial, go run with its import
+// path, or a partial binary on the PATH.
+func generatorArgsFor(words []string) ([]string, bool) {
+	if len(words) > 0 && words[0] == "partial" {
+		return words[1:], true
+	}
+
+	if len(words) < 3 || words[0] != "go" || words[1] != "run" {
+		return nil, false
+	}
+
+	// Flags to go run itself, such as -mod=mod, come before the package
+	idx := 2
+	for idx < len(words) && strings.HasPrefix(words[idx], "-") {
+		idx++
+	}
+	if idx == len(words) {
+		return nil, false
+	}
+
+	pkg, _, _ := strings.Cut(words[idx], "@")
+	if filepath.ToSlash(filepath.Clean(pkg)) != "cmd/partial" && !strings.HasSuffix(filepath.ToSlash(pkg), "/cmd/partial") {
+		return nil, false
+	}
+
+	return words[idx+1:], true
+}
+
+// splitGenerateCommand splits a go:generate command into words as go generate does,
+// where a double-quoted string is a single word.
+func splitGenerateCommand(command string) ([]string, error) {
+	words := []string{}
+	for {
+		command = strings.TrimLeft(command, " \t")
+		if command == "" {
+			return words, nil
+		}
+
+		if command[0] != '"' {
+			end := strings.IndexAny(command, " \t")
+			if end < 0 {
+				end = len(command)
+			}
+
+			words, command = append(words, command[:end]), command[end:]
+			continue
+		}
+
+		// Find the closing quote, skipping any that are escaped
+		end := 1
+		for end < len(command) && (command[end] != '"' || command[end-1] == '\\') {
+			end++
+		}
+		if end == len(command) {
+			return nil, errors.New(fmt.Sprintf("unterminated quoted string in %s", command))
+		}
+
+		word, err := strconv.Unquote(command[:end+1])
+		if err != nil {
+			return nil, err
+		}
+
+		words, command = append(words, word), command[end+1:]
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// packageDirsFor expands a pattern relative to dir into the package directories it
+// matches. As with the go tool, a pattern ending in /... matches the directory and every
+// package beneath it, and anything else is a single directory.
+func packageDirsFor(dir, pattern string) ([]string, error) {
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(dir, pattern)
+	}
+
+	if root, ok := strings.CutSuffix(filepath.ToSlash(pattern), "/..."); ok {
+		return walkPackageDirs(filepath.FromSlash(root))
+	}
+
+	return []string{pattern}, nil
+}
+
+// walkPackageDirs returns every directory under root that contains Go source, skipping
+// the directories the go tool would ignore when matching ./..., and any nested modules.
+func walkPackageDirs(root string) ([]string, error) {
+	dirs := []string{}
+	err := filepath.WalkDir(root, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+
+		if dir != root {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+
+		hasGoFiles, err := containsGoFiles(dir)
+		if err != nil {
+			return err
+		}
+		if hasGoFiles {
+			dirs = append(dirs, dir)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dirs, nil
+}
+
+// containsGoFiles is true if the directory has any Go source that isn't generated by us.
+func containsGoFiles(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go") && !isGenFile(entry.Name()) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("./... patterns", func() {
+	var dir string
+
+	BeforeEach(func() {
+		sourceIn := func(packageName string) string {
+			return strings.Replace(thingSource, "package things", "package "+packageName, 1)
+		}
+
+		dir = writeFixturePackage(map[string]string{
+			"thing.go":                  thingSource,
+			"nested/thing.go":           sourceIn("nested"),
+			"nested/deeper/thing.go":    sourceIn("deeper"),
+			"testdata/thing.go":         sourceIn("testdata"),
+			"_ignored/thing.go":         sourceIn("ignored"),
+			"module/go.mod":             "module example.com/module\n",
+			"module/thing.go":           sourceIn("module"),
+			"assets/readme.txt":         "no Go here",
+			"generated/x.genpartial.go": "package generated\n",
+		})
+	})
+
+	DescribeTable("expanding patterns into package directories",
+		func(pattern string, expected ...string) {
+			dirs, err := packageDirsFor(dir, pattern)
+			Expect(err).NotTo(HaveOccurred())
+
+			relativeDirs := []string{}
+			for _, packageDir := range dirs {
+				relativeDir, err := filepath.Rel(dir, packageDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				relativeDirs = append(relativeDirs, relativeDir)
+			}
+			Expect(relativeDirs).To(Equal(expected))
+		},
+		Entry("every package beneath the directory", "./...", ".", "nested", "nested/deeper"),
+		Entry("every package beneath a subdirectory", "nested/...", "nested", "nested/deeper"),
+		Entry("a single package", "nested", "nested"),
+		Entry("a directory that would otherwise be ignored", "testdata", "testdata"),
+	)
+
+	It("generates alongside each package", func() {
+		Expect(runGen(dir, []string{"./..."})).To(Succeed())
+
+		for _, packageDir := range []string{".", "nested", "nested/deeper"} {
+			Expect(readFixtureFile(filepath.Join(dir, packageDir), "thing.genpartial.go")).To(ContainSubstring("var ThingBuilder"))
+		}
+		for _, packageDir := range []string{"testdata", "_ignored", "module"} {
+			_, err := os.Stat(filepath.Join(dir, packageDir, "thing.genpartial.go"))
+			Expect(os.IsNotExist(err)).To(BeTrue(), packageDir)
+		}
+	})
+})
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// docWidth is roughly where we wrap generated doc comments, matching our source.
+const docWidth = 90
+
+// typeDoc builds the extra paragraphs of doc comment we add to generated builders and
+// matchers, so editor tooltips explain what's available without reading the source.
+type typeDoc struct {
+	SourceLines  []string // the doc comment of the annotated type
+	Options      []string // Name, Timestamps().CreatedAt
+	ExampleLines []string // Go code showing how to use the generated identifier
+}
+
+// sourceDocLinesFor returns the doc comment of the type, without the codegen annotation.
+func sourceDocLinesFor(target *codegenTarget) []string {
+	lines := []string{}
+	for _, line := range strings.Split(target.Doc, "\n") {
+		if _, _, ok := annotationIn(line); ok {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	// Drop blank lines that were only separating the doc from the annotation.
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+
+	return lines
+}
+
+// Lines renders the doc as comment lines, each starting with //.
+func (d typeDoc) Lines(optionsLabel string) []string {
+	paragraphs := [][]string{}
+	if len(d.SourceLines) > 0 {
+		paragraphs = append(paragraphs, d.SourceLines)
+	}
+	if len(d.Options) > 0 {
+		paragraphs = append(paragraphs, wrapWords(fmt.Sprintf("%s: %s.", optionsLabel, strings.Join(d.Options, ", "))))
+	}
+	if len(d.ExampleLines) > 0 {
+		example := []string{"For example:", ""}
+		for _, line := range d.ExampleLines {
+			example = append(example, "\t"+line)
+		}
+
+		paragraphs = append(paragraphs, example)
+	}
+
+	lines := []string{}
+	for _, paragraph := range paragraphs {
+		lines = append(lines, "//")
+		for _, line := range paragraph {
+			lines = append(lines, strings.TrimRight("// "+line, " "))
+		}
+	}
+
+	return lines
+}
+
+// wrapWords splits text into lines of at most docWidth characters, breaking on spaces.
+func wrapWords(text string) []string {
+	lines, line := []string{}, ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > docWidth-3 {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
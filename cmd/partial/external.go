@@ -0,0 +1,276 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// externalTypesFilename configures generation for types we don't own, such as vendored
+// or generated structs that we can't annotate, keyed by qualified type name:
+//
+//	github.com/foo/pkg.Bar: [builder, matcher(testonly)]
+const externalTypesFilename = "partial.types.yaml"
+
+// findExternalTargets loads the types configured in the partial.types.yaml file of the
+// given directory, if there is one. Code for them is generated into pkgName, alongside
+// the annotated types of the directory.
+func findExternalTargets(dir, pkgName string) ([]*codegenTarget, error) {
+	data, err := os.ReadFile(path.Join(dir, externalTypesFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	config := map[string][]string{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("parsing %s", externalTypesFilename))
+	}
+	if len(config) > 0 && pkgName == "" {
+		return nil, errors.New(fmt.Sprintf("%s must be alongside a Go package", externalTypesFilename))
+	}
+
+	qualifiedNames := []string{}
+	for qualifiedName := range config {
+		qualifiedNames = append(qualifiedNames, qualifiedName)
+	}
+	sort.Strings(qualifiedNames)
+
+	targets := []*codegenTarget{}
+	for _, qualifiedName := range qualifiedNames {
+		idx := strings.LastIndex(qualifiedName, ".")
+		if idx < 0 {
+			return nil, errors.New(fmt.Sprintf("external type %s must be qualified with its import path", qualifiedName))
+		}
+		importPath, typeName := qualifiedName[:idx], qualifiedName[idx+1:]
+
+		tags, err := parseCodegenTags(strings.Join(config[qualifiedName], ","))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("external type %s", qualifiedName))
+		}
+
+		importName, structType, fset, err := findExternalStruct(dir, importPath, typeName)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("external type %s", qualifiedName))
+		}
+
+		targets = append(targets, &codegenTarget{
+			Package:    pkgName,
+			Filename:   path.Join(dir, strings.TrimSuffix(externalTypesFilename, ".yaml")+".go"),
+			Tags:       tags,
+			Name:       typeName,
+			StructType: structType,
+			Fset:       fset,
+			ImportPath: importPath,
+			ImportName: importName,
+		})
+	}
+
+	return targets, nil
+}
+
+// findExternalStruct locates the source of the given package using the go tool, which
+// respects the module of the directory we're generating into, then parses it to find the
+// named struct.
+func findExternalStruct(dir, importPath, typeName string) (string, *ast.StructType, *token.FileSet, error) {
+	cmd := exec.Command("go", "list", "-f", "{{.Dir}}", importPath)
+	cmd.Dir, cmd.Stderr = dir, os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, fmt.Sprintf("finding package %s", importPath))
+	}
+
+	notTestFiles := func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && !isGenFile(info.Name())
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, strings.TrimSpace(string(output)), notTestFiles, parser.ParseComments)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	for pkgName, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					if typeSpec.Name.Name != typeName {
+						continue
+					}
+
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						return "", nil, nil, errors.New(fmt.Sprintf("%s is not a struct", typeName))
+					}
+
+					return pkgName, structType, fset, nil
+				}
+			}
+		}
+	}
+
+	return "", nil, nil, errors.New(fmt.Sprintf("could not find type %s in %s", typeName, importPath))
+}
+
+// qualifyTypeExpr qualifies each type declared in the external package with its name,
+// so an Ident of Status becomes pkg.Status, wherever in the package it was declared.
+// Builtin types, and those already qualified with a package, are left alone. Unexported
+// types can't be named from another package, so are an error.
+func qualifyTypeExpr(expr ast.Expr, importName string) (ast.Expr, error) {
+	switch fieldType := expr.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(fieldType.Name) != nil {
+			return fieldType, nil
+		}
+		if !ast.IsExported(fieldType.Name) {
+			return nil, errors.New(fmt.Sprintf("type %s.%s is unexported", importName, fieldType.Name))
+		}
+
+		return &ast.SelectorExpr{X: ast.NewIdent(importName), Sel: fieldType}, nil
+
+	case *ast.StarExpr:
+		elem, err := qualifyTypeExpr(fieldType.X, importName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.StarExpr{X: elem}, nil
+
+	case *ast.ArrayType:
+		elem, err := qualifyTypeExpr(fieldType.Elt, importName)
+		if err != nil {
+			return nil, err
+		}
+
+		length := fieldType.Len
+		if length != nil {
+			length, err = qualifyConstExpr(length, importName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return &ast.ArrayType{Len: length, Elt: elem}, nil
+
+	case *ast.MapType:
+		key, err := qualifyTypeExpr(fieldType.Key, importName)
+		if err != nil {
+			return nil, err
+		}
+		value, err := qualifyTypeExpr(fieldType.Value, importName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.MapType{Key: key, Value: value}, nil
+
+	case *ast.StructType:
+		fields := []*ast.Field{}
+		for _, field := range fieldType.Fields.List {
+			// Unexported fields tie an anonymous struct to the package that declared it
+			for _, name := range field.Names {
+				if !ast.IsExported(name.Name) {
+					return nil, errors.New(fmt.Sprintf("anonymous struct from %s has unexported field %s", importName, name.Name))
+				}
+			}
+
+			qualified, err := qualifyTypeExpr(field.Type, importName)
+			if err != nil {
+				return nil, err
+			}
+
+			fields = append(fields, &ast.Field{Names: field.Names, Type: qualified, Tag: field.Tag})
+		}
+
+		return &ast.StructType{Fields: &ast.FieldList{List: fields}}, nil
+
+	case *ast.IndexExpr:
+		genericType, err := qualifyTypeExpr(fieldType.X, importName)
+		if err != nil {
+			return nil, err
+		}
+		index, err := qualifyTypeExpr(fieldType.Index, importName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.IndexExpr{X: genericType, Index: index}, nil
+
+	case *ast.IndexListExpr:
+		genericType, err := qualifyTypeExpr(fieldType.X, importName)
+		if err != nil {
+			return nil, err
+		}
+
+		indices := []ast.Expr{}
+		for _, index := range fieldType.Indices {
+			qualified, err := qualifyTypeExpr(index, importName)
+			if err != nil {
+				return nil, err
+			}
+
+			indices = append(indices, qualified)
+		}
+
+		return &ast.IndexListExpr{X: genericType, Indices: indices}, nil
+	}
+
+	return expr, nil
+}
+
+// qualifyConstExpr qualifies each constant declared in the external package that is
+// referenced by a constant expression, such as the length of an array.
+func qualifyConstExpr(expr ast.Expr, importName string) (ast.Expr, error) {
+	switch constExpr := expr.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(constExpr.Name) != nil {
+			return constExpr, nil
+		}
+		if !ast.IsExported(constExpr.Name) {
+			return nil, errors.New(fmt.Sprintf("constant %s.%s is unexported", importName, constExpr.Name))
+		}
+
+		return &ast.SelectorExpr{X: ast.NewIdent(importName), Sel: constExpr}, nil
+
+	case *ast.ParenExpr:
+		inner, err := qualifyConstExpr(constExpr.X, importName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.ParenExpr{X: inner}, nil
+
+	case *ast.BinaryExpr:
+		left, err := qualifyConstExpr(constExpr.X, importName)
+		if err != nil {
+			return nil, err
+		}
+		right, err := qualifyConstExpr(constExpr.Y, importName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ast.BinaryExpr{X: left, Op: constExpr.Op, Y: right}, nil
+	}
+
+	return expr, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// fieldsJSONVersion is bumped whenever a breaking change is made to the output of
+// -emit-fields-json, so consumers can detect output they don't understand.
+const fieldsJSONVersion = 1
+
+// fieldsJSON describes which fields of each type a partial update may write, for tools
+// such as admin UIs that render the fields each endpoint can modify. Types are keyed by
+// package and name, such as domain.Incident, or import path for those configured in
+// partial.types.yaml, and their fields by Go name.
+type fieldsJSON struct {
+	Version int                                  `json:"version"`
+	Types   map[string]map[string]*fieldMetadata `json:"types"`
+}
+
+type fieldMetadata struct {
+	JSONName  string `json:"json_name,omitempty"`
+	Updatable bool   `json:"updatable"` // neither immutable nor readonly
+	Immutable bool   `json:"immutable"`
+	ReadOnly  bool   `json:"readonly"`
+	Sensitive bool   `json:"sensitive"` // encrypted at rest
+	Required  bool   `json:"required"`
+	Group     string `json:"group,omitempty"`
+}
+
+// emitFieldsJSON writes the fields of every annotated type in the directories to
+// filename, or with check, returns a summary of how the file on disk differs.
+func emitFieldsJSON(filename string, dirs []string, check bool) (string, error) {
+	output := &fieldsJSON{
+		Version: fieldsJSONVersion,
+		Types:   map[string]map[string]*fieldMetadata{},
+	}
+
+	declaredIn := map[string]string{}
+	for _, dir := range dirs {
+		targets, err := findTargets(dir)
+		if err != nil {
+			return "", err
+		}
+
+		for _, target := range targets {
+			typeName := fmt.Sprintf("%s.%s", target.Package, target.Name)
+			if target.ImportPath != "" {
+				typeName = fmt.Sprintf("%s.%s", target.ImportPath, target.Name)
+			}
+			if other, ok := declaredIn[typeName]; ok {
+				return "", errors.New(fmt.Sprintf("cannot emit fields for %s, declared in both %s and %s", typeName, other, target.Filename))
+			}
+			declaredIn[typeName] = target.Filename
+
+			fields, err := getFieldsFor(target)
+			if err != nil {
+				return "", errors.Wrap(err, fmt.Sprintf("emitting fields of %s in %s", target.Name, target.Filename))
+			}
+
+			output.Types[typeName] = map[string]*fieldMetadata{}
+			for _, field := range fields {
+				output.Types[typeName][field.FieldName] = &fieldMetadata{
+					JSONName:  field.JSONName,
+					Updatable: !field.Immutable && !field.ReadOnly,
+					Immutable: field.Immutable,
+					ReadOnly:  field.ReadOnly,
+					Sensitive: field.Encrypted,
+					Required:  field.Required,
+					Group:     field.Group,
+				}
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	data = append(data, '\n')
+
+	if check {
+		existing, err := os.ReadFile(filename)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Sprintf("%s: missing", filename), nil
+			}
+
+			return "", err
+		}
+
+		if bytes.Equal(existing, data) {
+			return "", nil
+		}
+
+		return fmt.Sprintf("%s: %s", filename, diffSummaryFor(existing, data)), nil
+	}
+
+	return "", writeFileAtomically(filename, data)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// Fixture packages are removed after every spec, and the generator's global state reset,
+// so no spec sees what another left behind.
+var _ = AfterEach(func() {
+	Expect(os.RemoveAll("testdata")).To(Succeed())
+
+	project = defaultProjectConfig()
+	importAliases, templateOverrides = map[string]string{}, map[string]*template.Template{}
+})
+
+// thingSource declares a type annotated for the builder and matcher, which most specs
+// generate for.
+const thingSource = `package things
+
+// codegen-partial:builder,matcher
+type Thing struct {
+	ID   string ` + "`json:\"id\" gorm:\"default:generate_ulid()\"`" + `
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+
+// writeFixturePackage writes a package of the given files into a new directory beneath
+// testdata, which is inside the module so the packages the files import resolve, but is
+// ignored by ./... patterns.
+func writeFixturePackage(files map[string]string) string {
+	Expect(os.MkdirAll("testdata", 0o755)).To(Succeed())
+
+	dir, err := os.MkdirTemp("testdata", "fixture")
+	Expect(err).NotTo(HaveOccurred())
+
+	dir, err = filepath.Abs(dir)
+	Expect(err).NotTo(HaveOccurred())
+
+	for filename, source := range files {
+		Expect(os.MkdirAll(filepath.Dir(filepath.Join(dir, filename)), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, filename), []byte(source), 0o644)).To(Succeed())
+	}
+
+	return dir
+}
+
+// readFixtureFile returns the contents of a file in a fixture package.
+func readFixtureFile(dir, filename string) string {
+	contents, err := os.ReadFile(filepath.Join(dir, filename))
+	Expect(err).NotTo(HaveOccurred())
+
+	return string(contents)
+}
+
+// captureStdout runs the command, returning what it printed along with its error.
+func captureStdout(run func() error) (string, error) {
+	reader, writer, err := os.Pipe()
+	Expect(err).NotTo(HaveOccurred())
+
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	output := make(chan string)
+	go func() {
+		contents, _ := io.ReadAll(reader)
+		output <- string(contents)
+	}()
+
+	runErr := run()
+	writer.Close()
+
+	return <-output, runErr
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strconv"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// templateFuncs are the only functions our templates need, which saves pulling in a
+// general purpose library (and its dependencies) for them.
+var templateFuncs = template.FuncMap{
+	// quote turns a string into a Go string literal: Name becomes "Name".
+	"quote": func(value string) string {
+		return strconv.Quote(value)
+	},
+	// untitle lowercases the first letter: APIKeyBuilder becomes aPIKeyBuilder.
+	"untitle": func(value string) string {
+		first, size := utf8.DecodeRuneInString(value)
+		if first == utf8.RuneError {
+			return value
+		}
+
+		return string(unicode.ToLower(first)) + value[size:]
+	},
+	// pkg returns the name to reference a runtime import by, which may be aliased.
+	"pkg": importNameFor,
+	// matcherName returns the name of the matcher for a type: IncidentMatcher.
+	"matcherName": matcherNameFor,
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+// typeParamsFor returns the type parameters of a generic type as declared, such as
+// [K comparable, V any], and as the arguments instantiating it with them, such as [K, V].
+// Both are empty for types that aren't generic.
+func typeParamsFor(fset *token.FileSet, spec *ast.TypeSpec) (string, string, error) {
+	if spec.TypeParams == nil || len(spec.TypeParams.List) == 0 {
+		return "", "", nil
+	}
+
+	params, args := []string{}, []string{}
+	for _, field := range spec.TypeParams.List {
+		constraint := &bytes.Buffer{}
+		if err := printer.Fprint(constraint, fset, field.Type); err != nil {
+			return "", "", err
+		}
+
+		names := []string{}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+
+		params = append(params, strings.Join(names, ", ")+" "+constraint.String())
+		args = append(args, names...)
+	}
+
+	return "[" + strings.Join(params, ", ") + "]", "[" + strings.Join(args, ", ") + "]", nil
+}
+
+// genericRef returns the expression referencing a generated builder or matcher of the
+// target. Package level vars can't have type parameters, so for generic types these are
+// funcs returning the builder or matcher for a given instantiation.
+func genericRef(target *codegenTarget, name string) string {
+	if target.TypeParams == "" {
+		return name
+	}
+
+	return name + target.TypeArgs + "()"
+}
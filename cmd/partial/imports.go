@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// runtimeImport is a package referenced by generated code, regardless of the fields of
+// the types we generate for.
+type runtimeImport struct {
+	Name string // the package name, which templates reference it by: gomega
+	Path string // github.com/onsi/gomega
+}
+
+var runtimeImports = []runtimeImport{
+	{"fmt", "fmt"},
+	{"partial", "github.com/incident-io/partial"},
+	{"gomega", "github.com/onsi/gomega"},
+	{"gstruct", "github.com/onsi/gomega/gstruct"},
+	{"types", "github.com/onsi/gomega/types"},
+	{"table", "github.com/onsi/ginkgo/extensions/table"},
+	{"pflag", "github.com/spf13/pflag"},
+}
+
+// importAliases maps the name of a runtime import to the name generated code should use
+// for it, when configured with -import-alias. This avoids clashing with packages of the
+// same name used by fields, such as a models/types package.
+var importAliases = map[string]string{}
+
+// importNameFor returns the name generated code uses to reference the runtime import.
+func importNameFor(name string) string {
+	if alias, ok := importAliases[name]; ok {
+		return alias
+	}
+
+	return name
+}
+
+// parseImportAlias parses a name=alias flag value, such as types=gomegatypes.
+func parseImportAlias(value string) (string, string, error) {
+	name, alias, ok := strings.Cut(value, "=")
+	if !ok || alias == "" {
+		return "", "", errors.New(fmt.Sprintf("import alias must be name=alias: %s", value))
+	}
+
+	for _, runtimeImport := range runtimeImports {
+		if runtimeImport.Name == name {
+			return name, alias, nil
+		}
+	}
+
+	return "", "", errors.New(fmt.Sprintf("unrecognised package for import alias: %s", name))
+}
+
+// importBlockFor returns an import block for every runtime package referenced by the
+// generated source, so the file compiles without having to search for them.
+// Packages used by field types are imported under the given names where referenced.
+func importBlockFor(source []byte, fieldImports map[string]string) string {
+	lines := []string{}
+	for _, runtimeImport := range runtimeImports {
+		name := importNameFor(runtimeImport.Name)
+		if !regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\.`).Match(source) {
+			continue
+		}
+
+		if name == runtimeImport.Name {
+			lines = append(lines, fmt.Sprintf("\t%q", runtimeImport.Path))
+		} else {
+			lines = append(lines, fmt.Sprintf("\t%s %q", name, runtimeImport.Path))
+		}
+	}
+
+	runtimePaths := map[string]bool{}
+	for _, runtimeImport := range runtimeImports {
+		runtimePaths[runtimeImport.Path] = true
+	}
+	for name, importPath := range fieldImports {
+		// Field types are always exported, which avoids matching the end of a sentence
+		// in a comment, such as "sets the field to null."
+		if runtimePaths[importPath] || !regexp.MustCompile(`\b`+regexp.QuoteMeta(name)+`\.\p{Lu}`).Match(source) {
+			continue
+		}
+
+		if name == path.Base(importPath) {
+			lines = append(lines, fmt.Sprintf("\t%q", importPath))
+		} else {
+			lines = append(lines, fmt.Sprintf("\t%s %q", name, importPath))
+		}
+	}
+	sort.Strings(lines)
+
+	return fmt.Sprintf("import (\n%s\n)\n", strings.Join(lines, "\n"))
+}
+
+// sourceImportsFor returns the imports of a source file by the name the file references
+// them with. Fields we fail to resolve keep their types as written, so importing these
+// explicitly means we never rely on goimports to guess the package, which goes wrong for
+// ambiguous names and vanity import paths.
+func sourceImportsFor(file *ast.File) map[string]string {
+	sourceImports := map[string]string{}
+	for _, importSpec := range file.Imports {
+		importPath, err := strconv.Unquote(importSpec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := assumedImportNameFor(importPath)
+		if importSpec.Name != nil {
+			// Dot and blank imports can't be referenced with a qualifier
+			if importSpec.Name.Name == "." || importSpec.Name.Name == "_" {
+				continue
+			}
+
+			name = importSpec.Name.Name
+		}
+
+		sourceImports[name] = importPath
+	}
+
+	return sourceImports
+}
+
+// assumedImportNameFor guesses the name of the package at importPath without loading it,
+// by the same convention as goimports: gopkg.in/guregu/null.v3 is null, and
+// github.com/foo/go-bar/v2 is bar.
+func assumedImportNameFor(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil && path.Dir(importPath) != "." {
+			base = path.Base(path.Dir(importPath))
+		}
+	}
+
+	base = strings.TrimPrefix(base, "go-")
+	if idx := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); idx >= 0 {
+		base = base[:idx]
+	}
+
+	return base
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("-import-alias", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{
+			"thing.go": thingSource + `
+// codegen-partial:builder,matcher
+type Shelf struct {
+	Things []Thing ` + "`json:\"things\"`" + `
+}
+`,
+		})
+	})
+
+	It("imports the aliased package once, under its alias", func() {
+		Expect(runGen(dir, []string{"-import-alias", "types=gomegatypes"})).To(Succeed())
+
+		source := readFixtureFile(dir, "thing.genpartial.go")
+		Expect(strings.Count(source, `"github.com/onsi/gomega/types"`)).To(Equal(1))
+		Expect(source).To(ContainSubstring(`gomegatypes "github.com/onsi/gomega/types"`))
+		Expect(source).To(ContainSubstring("MatchThingsConsistOf"))
+
+		build := exec.Command("go", "build", ".")
+		build.Dir = dir
+		output, err := build.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+	})
+})
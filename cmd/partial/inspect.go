@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// inspectVersion is bumped whenever a breaking change is made to the output of inspect, so
+// consumers can detect output they don't understand.
+const inspectVersion = 1
+
+type inspectOutput struct {
+	Version int              `json:"version"`
+	Types   []*inspectedType `json:"types"`
+}
+
+type inspectedType struct {
+	Package    string            `json:"package"`
+	Filename   string            `json:"filename"`
+	Name       string            `json:"name"`
+	ImportPath string            `json:"import_path,omitempty"`
+	Tags       []*inspectedTag   `json:"tags"`
+	Fields     []*inspectedField `json:"fields"`
+}
+
+type inspectedTag struct {
+	Name     string `json:"name"`
+	TestOnly bool   `json:"test_only"`
+}
+
+type inspectedField struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	StructTag string `json:"struct_tag"`
+	JSONName  string `json:"json_name,omitempty"`
+	Gorm      string `json:"gorm,omitempty"`
+	Immutable bool   `json:"immutable"`
+	ReadOnly  bool   `json:"readonly"`
+	Encrypted bool   `json:"encrypted"`
+	Required  bool   `json:"required"`
+	Group     string `json:"group,omitempty"`
+	Default   string `json:"default,omitempty"`
+	Accepts   string `json:"setter_accepts,omitempty"`
+	Parse     string `json:"parse,omitempty"`
+	Factory   string `json:"matcher_factory,omitempty"`
+}
+
+// runInspect prints every annotated type in the directory along with its fields, allowing
+// other tools to share the same annotations as their source of truth.
+func runInspect(dir string, args []string) error {
+	flags := flag.NewFlagSet("inspect", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print a machine-readable description as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	output, err := inspect(dir)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(output)
+	}
+
+	for _, inspected := range output.Types {
+		tagNames := []string{}
+		for _, tag := range inspected.Tags {
+			if tag.TestOnly {
+				tagNames = append(tagNames, tag.Name+"(testonly)")
+			} else {
+				tagNames = append(tagNames, tag.Name)
+			}
+		}
+
+		fmt.Printf("%s.%s (%s) in %s\n", inspected.Package, inspected.Name, strings.Join(tagNames, ","), inspected.Filename)
+		for _, field := range inspected.Fields {
+			fmt.Printf("  %s %s\n", field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
+
+func inspect(dir string) (*inspectOutput, error) {
+	targets, err := findTargets(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &inspectOutput{
+		Version: inspectVersion,
+		Types:   []*inspectedType{},
+	}
+	for _, target := range targets {
+		fields, err := getFieldsFor(target)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("inspecting %s in %s", target.Name, target.Filename))
+		}
+
+		inspected := &inspectedType{
+			Package:    target.Package,
+			Filename:   target.Filename,
+			Name:       target.Name,
+			ImportPath: target.ImportPath,
+			Tags:       []*inspectedTag{},
+			Fields:     []*inspectedField{},
+		}
+		for _, tag := range target.Tags {
+			inspected.Tags = append(inspected.Tags, &inspectedTag{
+				Name:     tag.Name,
+				TestOnly: tag.TestOnly,
+			})
+		}
+		for _, field := range fields {
+			inspected.Fields = append(inspected.Fields, &inspectedField{
+				Name:      field.FieldName,
+				Type:      field.FieldTypeName,
+				StructTag: string(field.Tag),
+				JSONName:  field.JSONName,
+				Gorm:      field.Tag.Get("gorm"),
+				Immutable: field.Immutable,
+				ReadOnly:  field.ReadOnly,
+				Encrypted: field.Encrypted,
+				Required:  field.Required,
+				Group:     field.Group,
+				Default:   field.Default,
+				Accepts:   field.SetterAccepts,
+				Parse:     field.Parse,
+				Factory:   field.Factory,
+			})
+		}
+
+		output.Types = append(output.Types, inspected)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("inspect", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{
+			"thing.go": thingSource + `
+// codegen-partial:matcher(testonly)
+type Widget struct {
+	Secret string ` + "`json:\"secret\" partial:\"immutable\"`" + `
+}
+`,
+		})
+	})
+
+	DescribeTable("describing each annotated type",
+		func(args []string, expected ...string) {
+			output, err := captureStdout(func() error { return runInspect(dir, args) })
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, line := range expected {
+				Expect(output).To(ContainSubstring(line))
+			}
+		},
+		Entry("as text", nil,
+			"things.Thing (builder,matcher) in ",
+			"/thing.go\n  ID string\n  Name string\n",
+			"things.Widget (matcher(testonly)) in ",
+		),
+		Entry("as JSON", []string{"-json"},
+			`"version": 1`,
+			`"gorm": "default:generate_ulid()"`,
+			`"immutable": true`,
+		),
+	)
+
+	It("describes fields and tags as JSON", func() {
+		output, err := captureStdout(func() error { return runInspect(dir, []string{"-json"}) })
+		Expect(err).NotTo(HaveOccurred())
+
+		var inspected inspectOutput
+		Expect(json.Unmarshal([]byte(output), &inspected)).To(Succeed())
+
+		Expect(inspected.Version).To(Equal(inspectVersion))
+		Expect(inspected.Types).To(ConsistOf(
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Package": Equal("things"),
+				"Name":    Equal("Thing"),
+				"Tags": ConsistOf(
+					PointTo(MatchAllFields(Fields{"Name": Equal("builder"), "TestOnly": BeFalse()})),
+					PointTo(MatchAllFields(Fields{"Name": Equal("matcher"), "TestOnly": BeFalse()})),
+				),
+				"Fields": ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{"Name": Equal("ID"), "JSONName": Equal("id"), "Gorm": Equal("default:generate_ulid()")})),
+					PointTo(MatchFields(IgnoreExtras, Fields{"Name": Equal("Name"), "Type": Equal("string"), "JSONName": Equal("name")})),
+				),
+			})),
+			PointTo(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("Widget"),
+				"Tags": ConsistOf(
+					PointTo(MatchAllFields(Fields{"Name": Equal("matcher"), "TestOnly": BeTrue()})),
+				),
+				"Fields": ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{"Name": Equal("Secret"), "JSONName": Equal("secret"), "Immutable": BeTrue()})),
+				),
+			})),
+		))
+	})
+})
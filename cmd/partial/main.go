@@ -2,32 +2,201 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"go/ast"
-	"go/doc"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"io/fs"
-	"io/ioutil"
 	"log"
 	"os"
-	"os/exec"
 	"path"
+	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
+	"unicode"
 
-	"github.com/Masterminds/sprig"
+	"github.com/incident-io/partial"
 	"github.com/pkg/errors"
 )
 
 type codegenTarget struct {
 	Package    string
 	Filename   string
-	Tags       []string
-	Type       *doc.Type
+	Tags       []codegenTag
+	Name       string // Incident
+	Doc        string // the doc comment of the type, including the annotation
 	StructType *ast.StructType
+	Fset       *token.FileSet // the files StructType was parsed from, for reporting positions
+
+	// TypeParams and TypeArgs are set for generic types, and are added to everything we
+	// generate for them that needs the same type parameters.
+	TypeParams string // [T any]
+	TypeArgs   string // [T]
+
+	// ImportPath and ImportName are set for types we don't own, configured through a
+	// partial.types.yaml file rather than annotated in place.
+	ImportPath string // github.com/foo/pkg
+	ImportName string // pkg
+
+	// OutputImportPath is the import path of the package we generate code into, which
+	// keys generated options for coverage, as found when resolving field types.
+	OutputImportPath string // github.com/incident-io/core/server/domain
+
+	// MatcherTypes are the names of all types in the same package that will have a
+	// matcher generated, so we can offer nested matchers for fields that reference them.
+	// The value is true if that matcher is test only.
+	MatcherTypes map[string]bool
+
+	// FieldTypes are the types of each field as resolved by type checking the package,
+	// which we prefer over the syntax of the field when set. FieldImports names the
+	// packages those types are qualified with.
+	FieldTypes   map[string]string // Assignee: null.String
+	FieldImports map[string]string // null: gopkg.in/guregu/null.v3
+
+	// SourceImports are the imports of the file the type is declared in, by the name the
+	// file references them with, for fields whose types we couldn't resolve.
+	SourceImports map[string]string // null: gopkg.in/guregu/null.v3
+
+	// FieldUnderlyingTypes are the underlying types of fields with defined types, such as
+	// string for a field of type Severity, from the same type checking.
+	FieldUnderlyingTypes map[string]string // Severity: string
+
+	// Exclude are fields we generate nothing for, from the annotation or the package
+	// defaults.
+	Exclude map[string]bool
+
+	// SkipUnsupported skips fields whose types we can't generate code for, warning about
+	// each once, rather than failing the whole type.
+	SkipUnsupported bool
+	skipped         map[string]bool
+}
+
+// warnSkipped warns that we're skipping a field we can't generate code for, once no
+// matter how many tags ask for its fields.
+func (t *codegenTarget) warnSkipped(fieldName string, err error) {
+	if t.skipped[fieldName] {
+		return
+	}
+	if t.skipped == nil {
+		t.skipped = map[string]bool{}
+	}
+	t.skipped[fieldName] = true
+
+	log.Printf("warning: skipping field %s on type %s: %s (add a // partial:skip comment to acknowledge it)", fieldName, t.Name, err)
+}
+
+// QualifiedName is the name used to reference the type from generated code, which must
+// be qualified by package for external types.
+func (t *codegenTarget) QualifiedName() string {
+	if t.ImportPath != "" {
+		return fmt.Sprintf("%s.%s%s", t.ImportName, t.Name, t.TypeArgs)
+	}
+
+	return t.Name + t.TypeArgs
+}
+
+func (t *codegenTarget) Tag(name string) (codegenTag, bool) {
+	for _, candidate := range t.Tags {
+		if candidate.Name == name {
+			return candidate, true
+		}
+	}
+
+	return codegenTag{}, false
+}
+
+// codegenTag is a single entry from a codegen-partial annotation, such as "matcher" or
+// "builder(testonly)".
+type codegenTag struct {
+	Name     string // builder
+	TestOnly bool   // (testonly)
+}
+
+// codegenTagNames are the tags we know how to generate.
+var codegenTagNames = map[string]bool{
+	"builder":     true,
+	"matcher":     true,
+	"diff":        true,
+	"event":       true,
+	"constructor": true,
+	"entry":       true,
+	"options":     true,
+	"flags":       true,
+	"params":      true,
+}
+
+// codegenTagPattern matches a single tag of an annotation, such as builder or
+// matcher(testonly).
+var codegenTagPattern = regexp.MustCompile(`^(\w+)(?:\((\w+)\))?$`)
+
+func parseCodegenTags(annotation string) ([]codegenTag, error) {
+	tags := []codegenTag{}
+	for _, entry := range strings.Split(annotation, ",") {
+		match := codegenTagPattern.FindStringSubmatch(entry)
+		if match == nil {
+			return nil, errors.New(fmt.Sprintf("invalid codegen tag: %s", entry))
+		}
+
+		tag := codegenTag{Name: match[1]}
+		switch match[2] {
+		case "":
+		case "testonly":
+			tag.TestOnly = true
+		default:
+			return nil, errors.New(fmt.Sprintf("unrecognised modifier for codegen tag %s: %s", tag.Name, match[2]))
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// genFilenameFor returns the file we should write generated code into, which is a
+// _test.go file for test only tags so the code is excluded from production builds. Go
+// only compiles those files into the package's own tests, so other packages can't use
+// test only code, and should generate into a package of their own with -out-dir.
+func genFilenameFor(target *codegenTarget, tag codegenTag) string {
+	base := strings.TrimSuffix(target.Filename, ".go")
+	if project.FilePerType {
+		base = path.Join(path.Dir(target.Filename), snakeCaseFor(target.Name))
+	}
+
+	if tag.TestOnly {
+		return base + project.OutputSuffix + "_test.go"
+	}
+
+	return base + project.OutputSuffix + ".go"
+}
+
+// snakeCaseFor turns a type name into the name of a file: APIKeyRole becomes
+// api_key_role.
+func snakeCaseFor(name string) string {
+	runes := []rune(name)
+
+	var snake strings.Builder
+	for idx, r := range runes {
+		if idx > 0 && unicode.IsUpper(r) {
+			previous := runes[idx-1]
+			if !unicode.IsUpper(previous) || idx+1 < len(runes) && unicode.IsLower(runes[idx+1]) {
+				snake.WriteRune('_')
+			}
+		}
+
+		snake.WriteRune(unicode.ToLower(r))
+	}
+
+	return snake.String()
+}
+
+func isGenFile(filename string) bool {
+	return strings.HasSuffix(filename, project.OutputSuffix+".go") || strings.HasSuffix(filename, project.OutputSuffix+"_test.go")
 }
 
 func main() {
@@ -37,362 +206,2567 @@ func main() {
 		return
 	}
 
-	runGeneration(dir)
+	project, err = loadProjectConfig(dir)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	if err := runCommand(dir, os.Args[1:]); err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// generateOptions configures how we write generated files.
+type generateOptions struct {
+	Header          string   // licence header to place at the top of each file
+	Pragmas         []string // //nolint:all, placed directly above the package clause
+	GuardDefaults   bool     // generate <Type>ForCreate, dropping zero values over gorm defaults
+	KeepGoing       bool     // skip types that fail to generate, rather than stopping
+	SkipUnsupported bool     // skip fields of types we can't generate code for, such as funcs
+	Only            []string // builder, generating only these tags whatever the annotations say
+	DeepCopy        bool     // deep copy every reference-typed value passed to a builder setter
+	Dirs            []string // package directories to generate for, from patterns such as ./...
+	Patterns        []string // ./..., the package patterns given after any flags
+	Check           bool     // compare against the files on disk rather than writing them
+	FieldsJSON      string   // file to write the fields of every type to, as JSON
+	OutDir          string   // ../modelstest, generating into another package
+	OutPackage      string   // modelstest, the name of the package in OutDir
+	VerifyImports   bool     // type check the generated files once written
+}
+
+// overriddenBy returns these options with any set in other taking precedence, such as a
+// package's go:generate flags overridden by those given to check.
+func (o generateOptions) overriddenBy(other generateOptions) generateOptions {
+	if other.Header != "" {
+		o.Header = other.Header
+	}
+	if len(other.Pragmas) > 0 {
+		o.Pragmas = other.Pragmas
+	}
+	if len(other.Only) > 0 {
+		o.Only = other.Only
+	}
+	if other.OutDir != "" {
+		o.OutDir, o.OutPackage = other.OutDir, other.OutPackage
+	}
+
+	o.GuardDefaults = o.GuardDefaults || other.GuardDefaults
+	o.KeepGoing = o.KeepGoing || other.KeepGoing
+	o.SkipUnsupported = o.SkipUnsupported || other.SkipUnsupported
+	o.DeepCopy = o.DeepCopy || other.DeepCopy
+	o.Check = o.Check || other.Check
+	o.VerifyImports = o.VerifyImports || other.VerifyImports
+
+	return o
+}
+
+func parseGenerateFlags(dir string, args []string) (generateOptions, error) {
+	var (
+		opts    generateOptions
+		pragmas stringsFlag
+		aliases stringsFlag
+	)
+
+	flags := flag.NewFlagSet("partial", flag.ExitOnError)
+	headerFile := flags.String("header", "", "file containing a licence header to add to generated files")
+	flags.Var(&pragmas, "pragma", "comment to add directly above the package clause, such as //nolint:all (repeatable)")
+	flags.BoolVar(&opts.GuardDefaults, "guard-defaults", false, "generate <Type>ForCreate funcs that drop zero values over columns with a gorm default")
+	flags.BoolVar(&opts.DeepCopy, "deep-copy", false, "deep copy slices, maps and pointers passed to builder setters, rather than only fields with a // partial:deep-copy comment")
+	flags.BoolVar(&opts.KeepGoing, "keep-going", false, "skip types that fail to generate, reporting every failure at the end")
+	flags.BoolVar(&opts.SkipUnsupported, "skip-unsupported", false, "skip fields of unsupported types such as funcs and channels with a warning, rather than failing their type")
+	flags.BoolVar(&opts.Check, "check", false, "exit non-zero if any generated file is out of date, without writing anything")
+	flags.Var(&aliases, "import-alias", "name=alias to import a runtime package under another name, such as types=gomegatypes (repeatable)")
+	flags.StringVar(&opts.FieldsJSON, "emit-fields-json", "", "file to write the fields of every generated type to as JSON, marking which a partial update may modify")
+	flags.StringVar(&opts.OutDir, "out-dir", "", "directory relative to each package to generate into as a separate package, such as ./modelstest")
+	flags.BoolVar(&opts.VerifyImports, "verify-imports", false, "type check the package once generated, failing on any unresolved or ambiguous identifier in generated files")
+	flags.StringVar(&opts.OutPackage, "out-package", "", "name of the package generated into -out-dir, defaulting to the directory name")
+	only := flags.String("only", "", "comma separated tags to generate, such as builder, ignoring any others in annotations")
+	templatesDir := flags.String("templates", "", "directory of <tag>.tmpl files replacing the built-in templates, such as builder.tmpl")
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+
+	var err error
+	opts.Patterns = flags.Args()
+	opts.Dirs, err = packageDirsForArgs(dir, opts.Patterns)
+	if err != nil {
+		return opts, err
+	}
+
+	for _, value := range aliases {
+		name, alias, err := parseImportAlias(value)
+		if err != nil {
+			return opts, err
+		}
+
+		importAliases[name] = alias
+	}
+
+	if *only != "" {
+		for _, name := range strings.Split(*only, ",") {
+			if !codegenTagNames[name] {
+				return opts, errors.New(fmt.Sprintf("unrecognised codegen tag for -only: %s", name))
+			}
+
+			opts.Only = append(opts.Only, name)
+		}
+	}
+
+	if opts.FieldsJSON != "" && !path.IsAbs(opts.FieldsJSON) {
+		opts.FieldsJSON = path.Join(dir, opts.FieldsJSON)
+	}
+
+	if *templatesDir != "" {
+		if !path.IsAbs(*templatesDir) {
+			*templatesDir = path.Join(dir, *templatesDir)
+		}
+
+		if err := loadTemplateOverrides(*templatesDir); err != nil {
+			return opts, errors.Wrap(err, "loading templates")
+		}
+	}
+
+	if *headerFile != "" {
+		if !path.IsAbs(*headerFile) {
+			*headerFile = path.Join(dir, *headerFile)
+		}
+
+		header, err := os.ReadFile(*headerFile)
+		if err != nil {
+			return opts, errors.Wrap(err, "reading header")
+		}
+
+		opts.Header = commentLines(string(header))
+	}
+
+	for _, pragma := range pragmas {
+		if !strings.HasPrefix(pragma, "//") {
+			return opts, errors.New(fmt.Sprintf("pragma must be a // comment: %s", pragma))
+		}
+
+		opts.Pragmas = append(opts.Pragmas, pragma)
+	}
+
+	return opts, nil
+}
+
+// packageDirsForArgs expands the package patterns given on the command line into the
+// directories they match. Run from the module root, we default to the packages in
+// partial.yaml, but a go:generate comment in a single package still only applies to that
+// package.
+func packageDirsForArgs(dir string, patterns []string) ([]string, error) {
+	if len(patterns) == 0 && len(project.Packages) > 0 && dir == project.root {
+		patterns = project.Packages
+	}
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	dirs := []string{}
+	for _, pattern := range patterns {
+		patternDirs, err := packageDirsFor(dir, pattern)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("finding packages matching %s", pattern))
+		}
+
+		dirs = append(dirs, patternDirs...)
+	}
+
+	return dirs, nil
+}
+
+// commentLines turns text into a block of // comments, leaving any lines that are already
+// comments alone.
+func commentLines(text string) string {
+	lines := []string{}
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "//"):
+		case line == "":
+			line = "//"
+		default:
+			line = "// " + line
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// stringsFlag collects the values of a flag that can be given more than once.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// runGenerationFor generates code for each package directory in turn. When keeping going,
+// failures in one package don't stop us generating the others.
+func runGenerationFor(dirs []string, opts generateOptions) error {
+	failures, stale := generationFailures{}, staleGenFiles{}
+	for _, dir := range dirs {
+		err := runGeneration(dir, opts)
+		if err == nil {
+			continue
+		}
+
+		// Stale files are reported for every package at once, as with -keep-going
+		if dirStale, ok := err.(staleGenFiles); ok {
+			stale = append(stale, dirStale...)
+			continue
+		}
+		if !opts.KeepGoing {
+			return err
+		}
+
+		if dirFailures, ok := err.(generationFailures); ok {
+			failures = append(failures, dirFailures...)
+		} else {
+			failures = append(failures, errors.Wrap(err, dir))
+		}
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+
+	if opts.FieldsJSON != "" {
+		summary, err := emitFieldsJSON(opts.FieldsJSON, dirs, opts.Check)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("emitting %s", opts.FieldsJSON))
+		}
+		if summary != "" {
+			stale = append(stale, summary)
+		}
+	}
+
+	if len(stale) > 0 {
+		return stale
+	}
+
+	return nil
+}
+
+func runGeneration(dir string, opts generateOptions) error {
+	targets, err := findTargets(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.Only) > 0 {
+		targets = onlyTags(targets, opts.Only)
+	}
+	for _, target := range targets {
+		target.SkipUnsupported = opts.SkipUnsupported
+	}
+
+	outDir, outputPath := outDirFor(dir, opts), ""
+	if outDir != dir && len(targets) > 0 {
+		outPackage, err := outPackageFor(outDir, opts)
+		if err != nil {
+			return err
+		}
+
+		outputPath, err = retargetToOutDir(dir, outDir, outPackage, targets)
+		if err != nil {
+			return err
+		}
+	}
+
+	// We can still generate from the syntax alone, which is right for all but the more
+	// unusual imports, so failing to load the package isn't fatal.
+	if err := resolveFieldTypes(dir, outputPath, targets); err != nil {
+		log.Printf("could not resolve field types, using them as written: %s", err)
+	}
+
+	// Each generated file is written independently, so we only ever hold one in memory no
+	// matter how many types there are. Collect the targets for each file first, keeping
+	// them in the order we found them.
+	filenames, targetsByFilename := []string{}, map[string][]*codegenTarget{}
+	for _, target := range targets {
+		for _, tag := range target.Tags {
+			targetFilename := genFilenameFor(target, tag)
+			if _, ok := targetsByFilename[targetFilename]; !ok {
+				filenames = append(filenames, targetFilename)
+			}
+			if !containsTarget(targetsByFilename[targetFilename], target) {
+				targetsByFilename[targetFilename] = append(targetsByFilename[targetFilename], target)
+			}
+		}
+	}
+
+	generated, failures, stale := map[string]bool{}, generationFailures{}, staleGenFiles{}
+	for _, targetFilename := range filenames {
+		buf := &bytes.Buffer{}
+		for _, target := range targetsByFilename[targetFilename] {
+			// Generate each target separately, so a failure doesn't leave half its code
+			// in the file when we keep going.
+			targetBuf := &bytes.Buffer{}
+			if err := genTarget(targetBuf, target, targetFilename, opts); err != nil {
+				if !opts.KeepGoing {
+					return err
+				}
+
+				failures = append(failures, err)
+				continue
+			}
+
+			buf.Write(targetBuf.Bytes())
+		}
+
+		source := genPreamble(targetsByFilename[targetFilename], path.Base(targetFilename), opts, buf.Bytes()) + buf.String()
+
+		generated[targetFilename] = true
+		if opts.Check {
+			summary, err := checkGenFile(targetFilename, []byte(source))
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("checking %s", targetFilename))
+			}
+			if summary != "" {
+				stale = append(stale, summary)
+			}
+
+			continue
+		}
+
+		log.Printf("=> %s", targetFilename)
+		if err := writeGenFile(targetFilename, []byte(source)); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("writing %s", targetFilename))
+		}
+	}
+
+	if opts.Check {
+		staleFiles, err := staleGenFilesFor(outDir, generated)
+		if err != nil {
+			return err
+		}
+		for _, staleFile := range staleFiles {
+			stale = append(stale, fmt.Sprintf("%s: should be removed", staleFile))
+		}
+	} else {
+		log.Printf("removing stale *%[1]s.go and *%[1]s_test.go files...", project.OutputSuffix)
+		if err := removeStaleGenFiles(outDir, generated); err != nil {
+			return err
+		}
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+	if len(stale) > 0 {
+		return stale
+	}
+
+	// Checking writes nothing, so there's nothing new to verify
+	if opts.VerifyImports && !opts.Check {
+		if err := verifyGenFiles(outDir, generated); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CoveragePkgPath identifies the package we generate into for coverage, falling back to
+// the package name if we couldn't load the package to find its import path.
+func (t *codegenTarget) CoveragePkgPath() string {
+	if t.OutputImportPath != "" {
+		return t.OutputImportPath
+	}
+
+	return t.Package
+}
+
+// onlyTags restricts each target to the given tags, dropping targets left with none. This
+// lets a package adopt one kind of generated code at a time without editing every
+// annotation.
+func onlyTags(targets []*codegenTarget, names []string) []*codegenTarget {
+	filtered := []*codegenTarget{}
+	for _, target := range targets {
+		tags := []codegenTag{}
+		for _, tag := range target.Tags {
+			if containsString(names, tag.Name) {
+				tags = append(tags, tag)
+			}
+		}
+
+		if len(tags) > 0 {
+			target.Tags = tags
+			filtered = append(filtered, target)
+		}
+	}
+
+	return filtered
+}
+
+// generationFailures collects the errors for every target that failed to generate when
+// running with -keep-going, reporting them all at once.
+type generationFailures []error
+
+func (f generationFailures) Error() string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "failed to generate %d type(s):", len(f))
+	for _, err := range f {
+		fmt.Fprintf(&report, "\n  %s", err)
+	}
+
+	return report.String()
+}
+
+// genTarget generates the code for every tag of the target that belongs in the given
+// file.
+func genTarget(buf *bytes.Buffer, target *codegenTarget, targetFilename string, opts generateOptions) error {
+	for _, tag := range target.Tags {
+		if genFilenameFor(target, tag) != targetFilename {
+			continue
+		}
+
+		switch tag.Name {
+		case "builder":
+			if err := genBuilder(buf, target, opts); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating builder for %s in %s", target.Name, target.Filename))
+			}
+
+		case "matcher":
+			if err := genMatcher(buf, target, tag); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating matcher for %s in %s", target.Name, target.Filename))
+			}
+
+		case "diff":
+			if err := genDiff(buf, target); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating diff for %s in %s", target.Name, target.Filename))
+			}
+
+		case "event":
+			if err := genEvent(buf, target); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating event for %s in %s", target.Name, target.Filename))
+			}
+
+		case "constructor":
+			if err := genConstructor(buf, target, tag); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating constructor for %s in %s", target.Name, target.Filename))
+			}
+
+		case "entry":
+			if err := genEntry(buf, target, tag); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating entry for %s in %s", target.Name, target.Filename))
+			}
+
+		case "options":
+			if err := genOptions(buf, target, tag); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating options for %s in %s", target.Name, target.Filename))
+			}
+
+		case "flags":
+			if err := genFlags(buf, target); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating flags for %s in %s", target.Name, target.Filename))
+			}
+
+		case "params":
+			if err := genParams(buf, target); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("error generating params for %s in %s", target.Name, target.Filename))
+			}
+
+		default:
+			return errors.New(fmt.Sprintf("unrecognised codegen tag for %s in %s: %s", target.Name, target.Filename, tag.Name))
+		}
+	}
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsTarget(targets []*codegenTarget, target *codegenTarget) bool {
+	for _, candidate := range targets {
+		if candidate == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findTargets parses the package in the given directory, returning every type that has a
+// codegen-partial annotation.
+func findTargets(dir string) ([]*codegenTarget, error) {
+	fset := token.NewFileSet()
+	notCodegenFiles := func(info fs.FileInfo) bool {
+		return !isGenFile(info.Name())
+	}
+	pkgs, err := parser.ParseDir(fset, dir, notCodegenFiles, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := findPackageDefaults(fset, pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []*codegenTarget{}
+	localPkgName := ""
+	for pkgName, pkg := range pkgs {
+		if !strings.HasSuffix(pkgName, "_test") {
+			localPkgName = pkgName
+		}
+
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					pos := fset.Position(typeSpec.Pos())
+					typeParams, typeArgs, err := typeParamsFor(fset, typeSpec)
+					if err != nil {
+						return nil, errors.Wrap(err, fmt.Sprintf("type %s in %s", typeSpec.Name.Name, pos.Filename))
+					}
+					typeDoc := annotatedDocFor(genDecl, typeSpec)
+					if typeDoc == "" {
+						// Exported structs of packages listed in partial.yaml get its tags
+						// without needing an annotation, though not those only in tests.
+						defaultTags := project.defaultTagsFor(dir)
+						_, isStruct := typeSpec.Type.(*ast.StructType)
+						if isStruct && typeSpec.Name.IsExported() && !strings.HasSuffix(pos.Filename, "_test.go") && len(defaultTags) > 0 {
+							targets = append(targets, &codegenTarget{
+								Package:       pkgName,
+								Filename:      pos.Filename,
+								Tags:          defaultTags,
+								Name:          typeSpec.Name.Name,
+								Doc:           typeSpec.Doc.Text(),
+								StructType:    typeSpec.Type.(*ast.StructType),
+								Fset:          fset,
+								TypeParams:    typeParams,
+								TypeArgs:      typeArgs,
+								Exclude:       excludedFieldsFor(defaults.Exclude),
+								SourceImports: sourceImportsFor(file),
+							})
+						}
+
+						continue
+					}
+
+					codegenTags, options, _ := annotationFor(typeDoc)
+					typeAnnotation, err := parseAnnotation(codegenTags, options)
+					if err != nil {
+						return nil, errors.Wrap(err, fmt.Sprintf("type %s in %s", typeSpec.Name.Name, pos.Filename))
+					}
+
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						return nil, errors.New(fmt.Sprintf("could not find struct for name %s referenced by file %s", typeSpec.Name.Name, pos.Filename))
+					}
+
+					// Anything the type doesn't set comes from the package defaults
+					tags := typeAnnotation.Tags
+					if len(tags) == 0 {
+						if len(defaults.Tags) == 0 {
+							return nil, errors.New(fmt.Sprintf("type %s in %s has no codegen tags, and there are no package defaults", typeSpec.Name.Name, pos.Filename))
+						}
+
+						tags = append([]codegenTag{}, defaults.Tags...)
+					}
+
+					exclude := defaults.Exclude
+					if typeAnnotation.Exclude != nil {
+						if err := checkExcludedFields(structType, typeAnnotation.Exclude); err != nil {
+							return nil, errors.Wrap(err, fmt.Sprintf("type %s in %s", typeSpec.Name.Name, pos.Filename))
+						}
+
+						exclude = typeAnnotation.Exclude
+					}
+
+					targets = append(targets, &codegenTarget{
+						Package:       pkgName,
+						Filename:      pos.Filename,
+						Tags:          tags,
+						Name:          typeSpec.Name.Name,
+						Doc:           typeDoc,
+						StructType:    structType,
+						Fset:          fset,
+						TypeParams:    typeParams,
+						TypeArgs:      typeArgs,
+						Exclude:       excludedFieldsFor(exclude),
+						SourceImports: sourceImportsFor(file),
+					})
+				}
+			}
+		}
+	}
+
+	externalTargets, err := findExternalTargets(dir, localPkgName)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading external types")
+	}
+	targets = append(targets, externalTargets...)
+
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Filename != targets[j].Filename {
+			return targets[i].Filename < targets[j].Filename
+		}
+
+		return targets[i].Name < targets[j].Name
+	})
+
+	matcherTypes := map[string]map[string]bool{}
+	for _, target := range targets {
+		if matcherTypes[target.Package] == nil {
+			matcherTypes[target.Package] = map[string]bool{}
+		}
+		if tag, ok := target.Tag("matcher"); ok && target.ImportPath == "" {
+			matcherTypes[target.Package][target.Name] = tag.TestOnly
+		}
+	}
+	for _, target := range targets {
+		target.MatcherTypes = matcherTypes[target.Package]
+	}
+
+	return targets, nil
+}
+
+// annotatedDocFor returns the doc comment containing the codegen annotation for the type,
+// or an empty string if it has none.
+//
+// Types declared in a group can be annotated individually, or the whole group can be
+// annotated at once, in which case the annotation applies to every struct in the group
+// that doesn't have its own. Groups often declare other types alongside their structs,
+// such as enums, which don't inherit it.
+func annotatedDocFor(decl *ast.GenDecl, spec *ast.TypeSpec) string {
+	docs := []*ast.CommentGroup{spec.Doc}
+	if _, isStruct := spec.Type.(*ast.StructType); isStruct || !decl.Lparen.IsValid() {
+		docs = append(docs, decl.Doc)
+	}
+
+	for _, doc := range docs {
+		if text := doc.Text(); text != "" {
+			if _, _, ok := annotationFor(text); ok {
+				return text
+			}
+		}
+	}
+
+	return ""
+}
+
+// generatorVersionPrefix marks the version of the generator in the header of each file,
+// so check-version can find stale files without compiling them.
+const generatorVersionPrefix = "// partial generator version: "
+
+// genPreamble returns everything that comes before the generated body for the targets,
+// including the imports it needs.
+func genPreamble(targets []*codegenTarget, filename string, opts generateOptions, body []byte) string {
+	var preamble strings.Builder
+
+	// The header must be separated from the code generated marker, else it would become
+	// part of the package doc.
+	if opts.Header != "" {
+		fmt.Fprintf(&preamble, "%s\n\n", opts.Header)
+	}
+
+	fmt.Fprintf(&preamble, "// Code generated by github.com/incident-io/partial/gen, DO NOT EDIT.\n")
+	fmt.Fprintf(&preamble, "%s%d\n\n", generatorVersionPrefix, partial.GeneratorVersion)
+	for _, pragma := range opts.Pragmas {
+		fmt.Fprintf(&preamble, "%s\n", pragma)
+	}
+
+	init := fmt.Sprintf(`
+func init() {
+	%s.RequireGeneratorVersion(%q, %d)
+}
+`, importNameFor("partial"), filename, partial.GeneratorVersion)
+
+	fieldImports, importedPaths := map[string]string{}, map[string]bool{}
+	for _, target := range targets {
+		for name, path := range target.FieldImports {
+			fieldImports[name], importedPaths[path] = path, true
+		}
+	}
+
+	// Types we resolved are qualified by the names we chose for them, so imports from the
+	// source only fill in names that are still free, for the types we didn't
+	reservedNames := map[string]bool{}
+	for _, runtimeImport := range runtimeImports {
+		reservedNames[importNameFor(runtimeImport.Name)] = true
+	}
+	for _, target := range targets {
+		names := []string{}
+		for name := range target.SourceImports {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			path := target.SourceImports[name]
+			if _, ok := fieldImports[name]; ok || reservedNames[name] || importedPaths[path] {
+				continue
+			}
+
+			fieldImports[name], importedPaths[path] = path, true
+		}
+	}
+
+	fmt.Fprintf(&preamble, "package %s\n\n%s%s", targets[0].Package, importBlockFor(append([]byte(init), body...), fieldImports), init)
+
+	return preamble.String()
+}
+
+// typeNameFor turns an ast.Expr into Go code that references the expressions type.
+func typeNameFor(expr ast.Expr) (string, error) {
+	switch fieldType := expr.(type) {
+	case *ast.Ident:
+		return fieldType.Name, nil // string
+
+	case *ast.StarExpr:
+		childType, err := typeNameFor(fieldType.X)
+		if err != nil {
+			return "", errors.Wrap(err, "pointer type")
+		}
+
+		return "*" + childType, nil // *string
+
+	case *ast.SelectorExpr:
+		childType, err := typeNameFor(fieldType.X)
+		if err != nil {
+			return "", errors.Wrap(err, "selector type")
+		}
+
+		return fmt.Sprintf("%s.%s", childType, fieldType.Sel.Name), nil // null.String
+
+	case *ast.ArrayType:
+		childType, err := typeNameFor(fieldType.Elt)
+		if err != nil {
+			return "", errors.Wrap(err, "array type")
+		}
+
+		if fieldType.Len == nil {
+			return fmt.Sprintf("[]%s", childType), nil // []string
+		}
+
+		// Lengths are constant expressions, such as 16, uuidLength or sha256.Size*2
+		switch fieldType.Len.(type) {
+		case *ast.BasicLit, *ast.Ident, *ast.SelectorExpr, *ast.BinaryExpr, *ast.ParenExpr:
+		default:
+			return "", errors.New(fmt.Sprintf("unsupported array length: %s", types.ExprString(fieldType.Len)))
+		}
+
+		return fmt.Sprintf("[%s]%s", types.ExprString(fieldType.Len), childType), nil // [32]byte
+
+	case *ast.MapType:
+		keyType, err := typeNameFor(fieldType.Key)
+		if err != nil {
+			return "", errors.Wrap(err, "map key type")
+		}
+
+		valueType, err := typeNameFor(fieldType.Value)
+		if err != nil {
+			return "", errors.Wrap(err, "map value type")
+		}
+
+		return fmt.Sprintf("map[%s]%s", keyType, valueType), nil // map[string]int
+
+	case *ast.StructType:
+		return anonymousStructNameFor(fieldType) // struct{ Enabled bool }
+
+	case *ast.IndexExpr:
+		return typeInstanceNameFor(fieldType.X, []ast.Expr{fieldType.Index}) // Option[string]
+
+	case *ast.IndexListExpr:
+		return typeInstanceNameFor(fieldType.X, fieldType.Indices) // lo.Tuple2[string, int]
+	}
+
+	description, ok := exprTypeDescriptions[fmt.Sprintf("%T", expr)]
+	if !ok {
+		description = fmt.Sprintf("%T", expr)
+	}
+
+	return "", errors.New(fmt.Sprintf("unsupported expr type: %s", description))
+}
+
+// typeInstanceNameFor references the instantiation of a generic type with the given type
+// arguments.
+func typeInstanceNameFor(expr ast.Expr, typeArgs []ast.Expr) (string, error) {
+	genericType, err := typeNameFor(expr)
+	if err != nil {
+		return "", errors.Wrap(err, "generic type")
+	}
+
+	typeArgNames := []string{}
+	for _, typeArg := range typeArgs {
+		typeArgName, err := typeNameFor(typeArg)
+		if err != nil {
+			return "", errors.Wrap(err, "type argument")
+		}
+
+		typeArgNames = append(typeArgNames, typeArgName)
+	}
+
+	return fmt.Sprintf("%s[%s]", genericType, strings.Join(typeArgNames, ", ")), nil
+}
+
+// anonymousStructNameFor writes out an anonymous struct type in full, including its
+// tags, which are part of its identity.
+func anonymousStructNameFor(structType *ast.StructType) (string, error) {
+	fields := []string{}
+	for _, field := range structType.Fields.List {
+		fieldType, err := typeNameFor(field.Type)
+		if err != nil {
+			return "", errors.Wrap(err, "anonymous struct field")
+		}
+
+		names := []string{}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+
+		declaration := fieldType // embedded
+		if len(names) > 0 {
+			declaration = strings.Join(names, ", ") + " " + fieldType
+		}
+		if field.Tag != nil {
+			declaration += " " + field.Tag.Value
+		}
+
+		fields = append(fields, declaration)
+	}
+
+	if len(fields) == 0 {
+		return "struct{}", nil
+	}
+
+	return fmt.Sprintf("struct{ %s }", strings.Join(fields, "; ")), nil
+}
+
+// exprTypeDescriptions describe the types we can't generate code for in terms of Go, rather
+// than the go/ast node that represents them.
+var exprTypeDescriptions = map[string]string{
+	"*ast.FuncType":      "func",
+	"*ast.ChanType":      "chan",
+	"*ast.InterfaceType": "interface",
+	"*ast.Ellipsis":      "variadic",
+}
+
+type structField struct {
+	FieldName     string // ID
+	MethodName    string // ID, or WithSlug from partial:"name=WithSlug", naming setters and matchers
+	FieldTypeName string // string
+	Tag           reflect.StructTag
+	JSONName      string // id
+	Immutable     bool   // partial:"immutable"
+	ReadOnly      bool   // partial:"readonly", for columns only the database writes
+	Encrypted     bool   // partial:"encrypted"
+	Required      bool   // partial:"required", taken positionally by constructors
+	Group         string // partial:"group=Timestamps"
+	Default       string // "active", from a // partial:default=active comment
+	SetterAccepts string // string, from a // partial:setter-accepts=string comment
+	Parse         string // ParseSeverity, converting from SetterAccepts to the field type
+	Factory       string // MatchULID, from a // partial:matcher-factory=MatchULID comment
+	DeepCopy      bool   // from a // partial:deep-copy comment
+	GormDefault   string // generate_ulid(), from gorm:"default:generate_ulid()"
+	Doc           string // the doc comment above the field, less any partial options
+
+	// UnderlyingTypeName is set for fields of defined types, such as Severity, to the type
+	// they're defined as, when type checking resolved it.
+	UnderlyingTypeName string // string
+}
+
+// DatabaseBacked is true if the field maps onto a column, which we infer from the field
+// having a JSON name. Associations and other in-memory fields have no JSON tag.
+func (f *structField) DatabaseBacked() bool {
+	return f.JSONName != ""
+}
+
+// structTagFor returns the parsed struct tag of the field, which is empty if the field
+// has no tag.
+func structTagFor(field *ast.Field) (reflect.StructTag, error) {
+	if field.Tag == nil {
+		return "", nil
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing struct tag")
+	}
+
+	return reflect.StructTag(tag), nil
+}
+
+// tagOptionsFor parses the comma separated options from a field's `partial:"..."` struct
+// tag, where each option is either a flag (immutable) or a key-value pair.
+func tagOptionsFor(tag reflect.StructTag) map[string]string {
+	options := map[string]string{}
+	for _, option := range strings.Split(tag.Get("partial"), ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(option, "=")
+		options[key] = value
+	}
+
+	return options
+}
+
+// jsonNameFor returns the name of the field when serialised to JSON, or an empty string
+// if the field is not serialised.
+func jsonNameFor(fieldName string, tag reflect.StructTag) string {
+	jsonTag, ok := tag.Lookup("json")
+	if !ok {
+		return ""
+	}
+
+	name := strings.Split(jsonTag, ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return fieldName
+	}
+
+	return name
+}
+
+// gormDefaultFor returns the column default from the field's gorm tag, or an empty string
+// if it has none.
+func gormDefaultFor(tag reflect.StructTag) string {
+	for _, setting := range strings.Split(tag.Get("gorm"), ";") {
+		key, value, _ := strings.Cut(setting, ":")
+		if strings.EqualFold(strings.TrimSpace(key), "default") {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// fieldDocFor returns the doc comment above the field as a single line, without any
+// partial options it contains.
+func fieldDocFor(field *ast.Field) string {
+	lines := []string{}
+	for _, line := range strings.Split(field.Doc.Text(), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "partial:") {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, " ")
+}
+
+// commentOptionsFor parses the options from a // partial:key=value,key=value comment on
+// the field, which may be either above the field or trailing it.
+func commentOptionsFor(field *ast.Field) map[string]string {
+	options := map[string]string{}
+	for _, comment := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		for _, match := range regexp.MustCompile(`partial:(\S+)`).FindAllStringSubmatch(comment.Text(), -1) {
+			for _, option := range strings.Split(match[1], ",") {
+				key, value, _ := strings.Cut(option, "=")
+				options[key] = value
+			}
+		}
+	}
+
+	return options
+}
+
+// defaultValueFor turns the value of a // partial:default=value comment into a Go literal
+// for a field of the given type, returning an empty string if the field has no default.
+// The literal is chosen by the kind of the field, which for a defined type or nullable
+// is that of its underlying or value type, so a string field defaulting to 404 gets
+// "404", and a default that isn't valid for a numeric or bool field is an error.
+//
+// Where we couldn't resolve the kind of the field, numbers and booleans are used as-is,
+// and anything else is treated as a string.
+func defaultValueFor(options map[string]string, typeName, underlyingTypeName string) (string, error) {
+	value, ok := options["default"]
+	if !ok || value == "" {
+		return "", nil
+	}
+
+	// Durations are written as text, such as 30s, and parsed into their nanoseconds
+	if typeName == "time.Duration" {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return "", errors.Wrap(err, "parsing default")
+		}
+
+		return fmt.Sprintf("%d", duration), nil
+	}
+
+	kind := typeName
+	if underlyingTypeName != "" {
+		kind = underlyingTypeName
+	}
+	if nullable := nullableTypes[typeName]; nullable != nil {
+		kind = nullable.ValueTypeName
+	}
+
+	switch kind {
+	case "string":
+		return strconv.Quote(value), nil
+
+	case "bool":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", errors.New(fmt.Sprintf("default %s is not a valid bool", value))
+		}
+
+		return strconv.FormatBool(parsed), nil
+
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		if _, err := strconv.ParseInt(value, 0, 64); err != nil {
+			return "", errors.New(fmt.Sprintf("default %s is not a valid %s", value, kind))
+		}
+
+		return value, nil
+
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		if _, err := strconv.ParseUint(value, 0, 64); err != nil {
+			return "", errors.New(fmt.Sprintf("default %s is not a valid %s", value, kind))
+		}
+
+		return value, nil
+
+	case "float32", "float64":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", errors.New(fmt.Sprintf("default %s is not a valid %s", value, kind))
+		}
+
+		return value, nil
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value, nil
+	}
+	if _, err := strconv.ParseBool(value); err == nil {
+		return value, nil
+	}
+
+	return strconv.Quote(value), nil
+}
+
+func getFieldsFor(target *codegenTarget) ([]*structField, error) {
+	fields := []*structField{}
+	for _, field := range target.StructType.Fields.List {
+		structField, err := structFieldFor(target, field)
+		if err != nil {
+			return nil, err
+		}
+
+		if structField != nil {
+			fields = append(fields, structField)
+		}
+	}
+
+	return fields, nil
+}
+
+// structFieldFor parses a single field of the target, returning nil if it's a field we
+// skip, such as an embedded field or an unexported field of an external type.
+func structFieldFor(target *codegenTarget, field *ast.Field) (*structField, error) {
+	// Embedded fields, we can't help here
+	if len(field.Names) == 0 {
+		return nil, nil
+	}
+
+	fieldName := field.Names[0].Name
+	if target.Exclude[fieldName] {
+		return nil, nil
+	}
+
+	// Fields tagged partial:"-", such as computed fields or deprecated columns, stay in
+	// the struct but are left out of everything we generate
+	tag, err := structTagFor(field)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("field %s on type %s", fieldName, target.Name))
+	}
+	if tag.Get("partial") == "-" {
+		return nil, nil
+	}
+
+	// Fields we can't generate for, such as funcs and channels, can be acknowledged with
+	// a // partial:skip comment, so the rest of the type still generates
+	commentOptions := commentOptionsFor(field)
+	if _, skip := commentOptions["skip"]; skip {
+		return nil, nil
+	}
+
+	// We can't set unexported fields of types from other packages, and types declared
+	// alongside them need qualifying with the package name.
+	fieldType := field.Type
+	if target.ImportPath != "" {
+		if !ast.IsExported(fieldName) {
+			// Skipping a field we've been asked to do something with would generate code
+			// that silently ignores it
+			if _, ok := tag.Lookup("partial"); ok || len(commentOptionsFor(field)) > 0 {
+				return nil, errors.New(fmt.Sprintf(
+					"field %s on type %s.%s is unexported, so can't be set from package %s: export it, or remove its partial options",
+					fieldName, target.ImportName, target.Name, target.Package,
+				))
+			}
+
+			return nil, nil
+		}
+
+		qualified, err := qualifyTypeExpr(fieldType, target.ImportName)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("field %s on type %s", fieldName, target.Name))
+		}
+
+		fieldType = qualified
+	}
+
+	typeName, err := typeNameFor(fieldType)
+	if err != nil {
+		if target.SkipUnsupported {
+			target.warnSkipped(fieldName, err)
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, fmt.Sprintf("field %s on type %s, which a // partial:skip comment would skip", fieldName, target.Name))
+	}
+	if resolved, ok := target.FieldTypes[fieldName]; ok {
+		typeName = resolved
+	}
+
+	options := tagOptionsFor(tag)
+	_, immutable := options["immutable"]
+	_, readOnly := options["readonly"]
+	_, encrypted := options["encrypted"]
+	_, required := options["required"]
+
+	_, hasDeepCopy := commentOptions["deep-copy"]
+	defaultValue, err := defaultValueFor(commentOptions, typeName, target.FieldUnderlyingTypes[fieldName])
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("field %s on type %s", fieldName, target.Name))
+	}
+	if defaultValue != "" && immutable {
+		return nil, errors.New(fmt.Sprintf("field %s on type %s is immutable, so cannot have a default", fieldName, target.Name))
+	}
+	if readOnly && (defaultValue != "" || required) {
+		return nil, errors.New(fmt.Sprintf("field %s on type %s is readonly, so cannot have a default or be required", fieldName, target.Name))
+	}
+
+	// Setters and matchers are named after the field, unless that would be awkward or
+	// collide with another method
+	methodName := fieldName
+	if name, ok := options["name"]; ok {
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return nil, errors.New(fmt.Sprintf("field %s on type %s has name=%s, which must be an exported identifier", fieldName, target.Name, name))
+		}
+
+		methodName = name
+	}
+
+	if (commentOptions["setter-accepts"] == "") != (commentOptions["parse"] == "") {
+		return nil, errors.New(fmt.Sprintf("field %s on type %s must specify both setter-accepts and parse", fieldName, target.Name))
+	}
+
+	parsed := &structField{
+		FieldName:     fieldName, // ID
+		MethodName:    methodName,
+		FieldTypeName: typeName, // string
+		Tag:           tag,
+		JSONName:      jsonNameFor(fieldName, tag), // id
+		Immutable:     immutable,
+		ReadOnly:      readOnly,
+		Encrypted:     encrypted,
+		Required:      required,
+		Group:         options["group"],
+		Default:       defaultValue,
+		SetterAccepts: commentOptions["setter-accepts"],
+		Parse:         commentOptions["parse"],
+		Factory:       commentOptions["matcher-factory"],
+		DeepCopy:      hasDeepCopy,
+		GormDefault:   gormDefaultFor(tag),
+		Doc:           fieldDocFor(field),
+	}
+	parsed.UnderlyingTypeName = target.FieldUnderlyingTypes[fieldName]
+
+	// Conversion and matcher funcs declared alongside external types need qualifying too,
+	// which we can only do if they're exported
+	for option, qualified := range map[string]*string{"parse": &parsed.Parse, "matcher-factory": &parsed.Factory} {
+		funcName := commentOptions[option]
+		if funcName == "" || target.ImportPath == "" || strings.Contains(funcName, ".") {
+			continue
+		}
+		if !ast.IsExported(funcName) {
+			return nil, errors.New(fmt.Sprintf(
+				"field %s on type %s.%s has %s=%s, which is unexported so can't be called from package %s",
+				fieldName, target.ImportName, target.Name, option, funcName, target.Package,
+			))
+		}
+
+		*qualified = fmt.Sprintf("%s.%s", target.ImportName, funcName)
+	}
+
+	return parsed, nil
+}
+
+// Builder!
+
+func genBuilder(buf *bytes.Buffer, target *codegenTarget, opts generateOptions) error {
+	fields, err := getFieldsFor(target)
+	if err != nil {
+		return err
+	}
+
+	vars := builderTemplateVars{
+		Target:              target,
+		TypeName:            target.QualifiedName(),
+		BuilderTypeName:     builderNameFor(target.Name),
+		BuilderFuncTypeName: builderNameFor(target.Name) + "Func",
+		ForCreateFuncName:   fmt.Sprintf("%sForCreate", target.Name),
+		OptionTypeName:      fmt.Sprintf("%s.Option[%s]", importNameFor("partial"), target.QualifiedName()),
+		TypeParams:          target.TypeParams,
+		TypeArgs:            target.TypeArgs,
+	}
+
+	for _, field := range fields {
+		if field.Default == "" {
+			continue
+		}
+
+		value := field.Default
+		if types.Universe.Lookup(field.FieldTypeName) == nil {
+			value = fmt.Sprintf("%s(%s)", field.FieldTypeName, field.Default)
+		}
+		if nullable := nullableTypes[field.FieldTypeName]; nullable != nil {
+			value = fmt.Sprintf("%s(%s)", nullable.ValueTypeName, field.Default)
+			value = strings.ReplaceAll(nullable.Valid, "value", value)
+		}
+
+		vars.Defaults = append(vars.Defaults, builderDefault{
+			FieldName: field.FieldName,
+			Value:     value,
+		})
+	}
+
+	groups := map[string]bool{}
+	for _, field := range fields {
+		// Immutable fields should never be patched, and readonly fields are only ever
+		// written by the database, so we don't offer setters for them
+		if field.Immutable || field.ReadOnly {
+			continue
+		}
+
+		// Grouped fields have their setters moved into a namespace, keeping the method set
+		// of the builder manageable for very wide structs.
+		receiverTypeName, optionPrefix := vars.BuilderFuncTypeName, ""
+		if field.Group != "" {
+			receiverTypeName = fmt.Sprintf("%s%s", vars.BuilderTypeName, field.Group)
+			optionPrefix = fmt.Sprintf("%s().", field.Group)
+			if !groups[field.Group] {
+				groups[field.Group] = true
+				vars.Groups = append(vars.Groups, builderGroup{
+					GroupName:     field.Group,
+					GroupTypeName: receiverTypeName,
+				})
+			}
+		}
+
+		builderField := &builderField{
+			structField:      field,
+			ReceiverTypeName: receiverTypeName,
+			OptionPrefix:     optionPrefix,
+		}
+
+		// Setters that parse their input take the friendly type instead, so the nullable
+		// helpers that call them with the raw type no longer apply.
+		if field.Parse == "" {
+			builderField.Nullable = nullableTypes[field.FieldTypeName]
+		}
+
+		// Writing null to a column should read differently from not touching it at all, so
+		// pointer and nullable fields get setters that clear them.
+		if strings.HasPrefix(field.FieldTypeName, "*") {
+			builderField.ClearValue = "nil"
+		} else if nullable := nullableTypes[field.FieldTypeName]; nullable != nil {
+			builderField.ClearValue = nullable.Null
+		}
+
+		// Optional request fields are pointers that are nil when absent, which IfSet setters
+		// take directly. Fields that are already pointers have no need of one.
+		if setterTypeName := field.FieldTypeName; !strings.HasPrefix(setterTypeName, "*") {
+			if field.Parse != "" {
+				setterTypeName = field.SetterAccepts
+			}
+			builderField.IfSetTypeName = "*" + setterTypeName
+		}
+
+		// Setters that deep copy their value share no memory with the caller, or with the
+		// records the partial is applied to.
+		if (field.DeepCopy || opts.DeepCopy) && field.Parse == "" {
+			body, err := deepCopyFuncFor(field.FieldTypeName, field.UnderlyingTypeName)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("field %s on type %s", field.FieldName, target.Name))
+			}
+			if body == "" && field.DeepCopy {
+				return errors.New(fmt.Sprintf("field %s on type %s has a deep-copy comment, but %s holds no references to copy",
+					field.FieldName, target.Name, field.FieldTypeName))
+			}
+
+			if body != "" {
+				builderField.CopyFuncName = fmt.Sprintf("%sCopy%s", templateFuncs["untitle"].(func(string) string)(target.Name), field.FieldName)
+				builderField.CopyFuncBody = body
+			}
+		}
+
+		// Setting a defaulted column to its zero value on create would write over the
+		// database default, such as an empty ID over generate_ulid().
+		if field.GormDefault != "" && field.Default == "" {
+			if opts.GuardDefaults {
+				vars.ZeroDefaultFields = append(vars.ZeroDefaultFields, field.FieldName)
+			} else {
+				log.Printf("warning: %s.%s has a gorm default of %s, but its builder setter could write a zero value over it on create (run with -guard-defaults to generate %sForCreate)",
+					target.Name, field.FieldName, field.GormDefault, target.Name)
+			}
+		}
+
+		vars.Fields = append(vars.Fields, builderField)
+		if field.DatabaseBacked() {
+			vars.FromFields = append(vars.FromFields, builderField)
+		}
+		vars.CoverageOptions = append(vars.CoverageOptions, optionPrefix+field.MethodName)
+		if builderField.Nullable != nil {
+			vars.CoverageOptions = append(vars.CoverageOptions,
+				optionPrefix+field.MethodName+"Value", optionPrefix+field.MethodName+"Null")
+		}
+		if builderField.ClearValue != "" {
+			vars.CoverageOptions = append(vars.CoverageOptions, optionPrefix+"Clear"+field.MethodName)
+		}
+	}
+
+	for _, field := range fields {
+		if groups[field.MethodName] {
+			return errors.New(fmt.Sprintf("group %s has the same name as a field", field.MethodName))
+		}
+	}
+
+	// From is a method alongside the setters, so can't be generated for a type that already
+	// has a setter of that name
+	for _, field := range vars.Fields {
+		if field.MethodName == "From" && field.Group == "" {
+			log.Printf("warning: not generating %s.From, as %s has a field named From", vars.BuilderTypeName, target.Name)
+			vars.FromFields = nil
+		}
+	}
+
+	doc := typeDoc{
+		SourceLines: sourceDocLinesFor(target),
+		Options:     vars.CoverageOptions,
+	}
+	if len(vars.Fields) > 0 {
+		example := vars.Fields[0]
+		doc.ExampleLines = []string{
+			fmt.Sprintf("model := %s(", genericRef(target, vars.BuilderTypeName)),
+			fmt.Sprintf("\t%s.%s%s(%s),", genericRef(target, vars.BuilderTypeName), example.OptionPrefix, example.MethodName, paramNameFor(example.FieldName)),
+			")",
+		}
+	}
+	vars.DocLines = doc.Lines("Setters")
+
+	if err := templateFor("builder", builderTemplate).Execute(buf, vars); err != nil {
+		return errors.Wrap(err, "executing template")
+	}
+
+	return nil
+}
+
+type builderTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
+
+	TypeName            string // APIKey
+	BuilderTypeName     string // APIKeyBuilder
+	BuilderFuncTypeName string // APIKeyBuilderFunc
+	OptionTypeName      string // partial.Option[APIKey]
+	Groups              []builderGroup
+	Fields              []*builderField
+	FromFields          []*builderField // the database-backed fields set by From
+	CoverageOptions     []string        // Name, Timestamps().CreatedAt
+	Defaults            []builderDefault
+	ZeroDefaultFields   []string // ID, fields with a gorm default to drop when zero on create
+	DocLines            []string // extra doc comment for the builder, listing setters
+	ForCreateFuncName   string   // APIKeyForCreate
+	TypeParams          string   // [T any], for generic types
+	TypeArgs            string   // [T]
+}
+
+type builderDefault struct {
+	FieldName string // Status
+	Value     string // string("active")
+}
+
+type builderGroup struct {
+	GroupName     string // Timestamps
+	GroupTypeName string // APIKeyBuilderTimestamps
+}
+
+type builderField struct {
+	*structField
+	ReceiverTypeName string // APIKeyBuilderFunc, or APIKeyBuilderTimestamps if grouped
+	OptionPrefix     string // empty, or Timestamps(). if grouped
+	Nullable         *nullableType
+	CopyFuncName     string // apiKeyCopyScopes, if the setter deep copies its value
+	CopyFuncBody     string // statements deep copying value
+	IfSetTypeName    string // *string, taken by the IfSet setter, empty if there isn't one
+	ClearValue       string // nil or null.String{}, set by the Clear setter of pointer and nullable fields
+}
+
+// nullableType describes a type that wraps a value that may be null, allowing us to
+// generate setters that accept the raw value.
+type nullableType struct {
+	ValueTypeName string // string
+	Valid         string // null.StringFrom(value)
+	Null          string // null.String{}
+}
+
+// nullableTypes are keyed by the name of the nullable type, as it would be referenced in
+// the source file.
+var nullableTypes = map[string]*nullableType{
+	"null.String": {"string", "null.StringFrom(value)", "null.String{}"},
+	"null.Int":    {"int64", "null.IntFrom(value)", "null.Int{}"},
+	"null.Float":  {"float64", "null.FloatFrom(value)", "null.Float{}"},
+	"null.Bool":   {"bool", "null.BoolFrom(value)", "null.Bool{}"},
+	"null.Time":   {"time.Time", "null.TimeFrom(value)", "null.Time{}"},
+
+	"sql.NullString":  {"string", "sql.NullString{String: value, Valid: true}", "sql.NullString{}"},
+	"sql.NullInt64":   {"int64", "sql.NullInt64{Int64: value, Valid: true}", "sql.NullInt64{}"},
+	"sql.NullInt32":   {"int32", "sql.NullInt32{Int32: value, Valid: true}", "sql.NullInt32{}"},
+	"sql.NullInt16":   {"int16", "sql.NullInt16{Int16: value, Valid: true}", "sql.NullInt16{}"},
+	"sql.NullByte":    {"byte", "sql.NullByte{Byte: value, Valid: true}", "sql.NullByte{}"},
+	"sql.NullFloat64": {"float64", "sql.NullFloat64{Float64: value, Valid: true}", "sql.NullFloat64{}"},
+	"sql.NullBool":    {"bool", "sql.NullBool{Bool: value, Valid: true}", "sql.NullBool{}"},
+	"sql.NullTime":    {"time.Time", "sql.NullTime{Time: value, Valid: true}", "sql.NullTime{}"},
+}
+
+var builderTemplate = template.Must(template.New("builderTemplate").Funcs(templateFuncs).Parse(`
+{{- if .Defaults }}
+// {{ .BuilderTypeName }} initialises a {{ .TypeName }} struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Default values are set and tracked before any setters are applied. Use WithoutDefaults
+// to build without them.
+{{- range .DocLines }}
+{{ . }}
+{{- end }}
+{{ if .TypeParams }}func {{ .BuilderTypeName }}{{ .TypeParams }}() {{ .BuilderFuncTypeName }}{{ .TypeArgs }} {
+	return {{ .BuilderFuncTypeName }}{{ .TypeArgs }}{{ else }}var {{ .BuilderTypeName }} = {{ .BuilderFuncTypeName }}{{ end }}(func(opts ...{{ .OptionTypeName }}) {{ pkg "partial" }}.Partial[{{ .TypeName }}] {
+	defaults := []{{ .OptionTypeName }}{
+		{{- range .Defaults }}
+		func(subject *{{ $.TypeName }}) []string {
+			subject.{{ .FieldName }} = {{ .Value }}
+			return []string{ {{- quote .FieldName -}} }
+		},
+		{{- end }}
+	}
+
+	return {{ untitle .BuilderTypeName }}WithoutDefaults{{ if .TypeParams }}{{ .TypeArgs }}(){{ end }}(append(defaults, opts...)...)
+}){{ if .TypeParams }}
+}{{ end }}
+
+// WithoutDefaults returns a builder that doesn't set any default values.
+func (b {{ .BuilderFuncTypeName }}{{ .TypeArgs }}) WithoutDefaults() {{ .BuilderFuncTypeName }}{{ .TypeArgs }} {
+	return {{ untitle .BuilderTypeName }}WithoutDefaults{{ if .TypeParams }}{{ .TypeArgs }}(){{ end }}
+}
+
+{{ if .TypeParams }}func {{ untitle .BuilderTypeName }}WithoutDefaults{{ .TypeParams }}() {{ .BuilderFuncTypeName }}{{ .TypeArgs }} {
+	return {{ .BuilderFuncTypeName }}{{ .TypeArgs }}{{ else }}var {{ untitle .BuilderTypeName }}WithoutDefaults = {{ .BuilderFuncTypeName }}{{ end }}(func(opts ...{{ .OptionTypeName }}) {{ pkg "partial" }}.Partial[{{ .TypeName }}] {
+{{- else }}
+// {{ .BuilderTypeName }} initialises a {{ .TypeName }} struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+{{- range .DocLines }}
+{{ . }}
+{{- end }}
+{{ if .TypeParams }}func {{ .BuilderTypeName }}{{ .TypeParams }}() {{ .BuilderFuncTypeName }}{{ .TypeArgs }} {
+	return {{ .BuilderFuncTypeName }}{{ .TypeArgs }}{{ else }}var {{ .BuilderTypeName }} = {{ .BuilderFuncTypeName }}{{ end }}(func(opts ...{{ .OptionTypeName }}) {{ pkg "partial" }}.Partial[{{ .TypeName }}] {
+{{- end }}
+	apply := func(base {{ .TypeName }}) {{ pkg "partial" }}.Partial[{{ .TypeName }}] {
+		model := {{ pkg "partial" }}.Partial[{{ .TypeName }}]{
+			Subject: base,
+		}
+
+		fieldNames, err := {{ pkg "partial" }}.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply({{ .TypeName }}{})
+	model.SetApply(func(base {{ .TypeName }}) *{{ .TypeName }} {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	{{ pkg "partial" }}.RunBuildHooks(&model)
+
+	return model
+}){{ if .TypeParams }}
+}{{ end }}
+
+type {{ .BuilderFuncTypeName }}{{ .TypeParams }} func(opts ...{{ .OptionTypeName }}) {{ pkg "partial" }}.Partial[{{ .TypeName }}]
+{{ if .ZeroDefaultFields }}
+// {{ .ForCreateFuncName }} stops model from tracking any of the fields with a gorm default that
+// are set to their zero value, so creating a record from it uses the database default instead.
+func {{ .ForCreateFuncName }}{{ .TypeParams }}(model {{ pkg "partial" }}.Partial[{{ .TypeName }}]) {{ pkg "partial" }}.Partial[{{ .TypeName }}] {
+	return {{ pkg "partial" }}.WithoutZeroFields(model,
+		{{- range .ZeroDefaultFields }}
+		{{ quote . }},
+		{{- end }}
+	)
+}
+{{ end }}
+func init() {
+	{{ pkg "partial" }}.RegisterCoverage({{ quote .Target.CoveragePkgPath }}, {{ quote .TypeName }}, "builder",
+		{{- range .CoverageOptions }}
+		{{ quote . }},
+		{{- end }}
+	)
+}
+
+{{ if .FromFields }}
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b {{ .BuilderFuncTypeName }}{{ .TypeArgs }}) From(existing {{ .TypeName }}) {{ .OptionTypeName }} {
+	return func(subject *{{ .TypeName }}) []string {
+		{{- range .FromFields }}
+		subject.{{ .FieldName }} = {{ if .CopyFuncName }}{{ .CopyFuncName }}{{ $.TypeArgs }}(existing.{{ .FieldName }}){{ else }}existing.{{ .FieldName }}{{ end }}
+		{{- end }}
+
+		return []string{
+			{{- range .FromFields }}
+			{{ quote .FieldName }},
+			{{- end }}
+		}
+	}
+}
+{{ end }}
+{{ range .Groups }}
+// {{ .GroupName }} returns the setters for the {{ .GroupName }} fields of {{ $.TypeName }}.
+func (b {{ $.BuilderFuncTypeName }}{{ $.TypeArgs }}) {{ .GroupName }}() {{ .GroupTypeName }}{{ $.TypeArgs }} {
+	return {{ .GroupTypeName }}{{ $.TypeArgs }}{}
+}
+
+type {{ .GroupTypeName }}{{ $.TypeParams }} struct{}
+{{ end }}
+{{ range .Fields }}
+{{- if .Parse }}
+// {{ .MethodName }} converts value with {{ .Parse }}. If that fails, the option is skipped and the
+// error is returned by Err on the built partial.
+func (b {{ .ReceiverTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}(value {{ .SetterAccepts }}) {{ $.OptionTypeName }} {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "builder", {{ quote (print .OptionPrefix .MethodName) }})
+
+	parsed, err := {{ .Parse }}(value)
+	if err != nil {
+		return {{ pkg "partial" }}.Fail[{{ $.TypeName }}]({{ pkg "fmt" }}.Errorf("parsing {{ .FieldName }}: %w", err))
+	}
+
+	return func(subject *{{ $.TypeName }}) []string {
+		subject.{{ .FieldName }} = parsed
+{{- else }}
+{{- if .CopyFuncName }}
+// {{ .MethodName }} deep copies value, so changing it afterwards won't change the partial.
+func (b {{ .ReceiverTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}(value {{ .FieldTypeName }}) {{ $.OptionTypeName }} {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "builder", {{ quote (print .OptionPrefix .MethodName) }})
+	value = {{ .CopyFuncName }}{{ $.TypeArgs }}(value)
+
+	return func(subject *{{ $.TypeName }}) []string {
+		subject.{{ .FieldName }} = {{ .CopyFuncName }}{{ $.TypeArgs }}(value)
+{{- else }}
+func (b {{ .ReceiverTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}(value {{ .FieldTypeName }}) {{ $.OptionTypeName }} {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "builder", {{ quote (print .OptionPrefix .MethodName) }})
+
+	return func(subject *{{ $.TypeName }}) []string {
+		subject.{{ .FieldName }} = value
+{{- end }}
+{{- end }}
+
+		return []string{
+			{{ quote .FieldName }},
+		}
+	}
+}
+{{ if .Nullable }}
+// {{ .MethodName }}Value sets {{ .FieldName }} to a valid {{ .FieldTypeName }} holding value.
+func (b {{ .ReceiverTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}Value(value {{ .Nullable.ValueTypeName }}) {{ $.OptionTypeName }} {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "builder", {{ quote (print .OptionPrefix .MethodName "Value") }})
+
+	return func(subject *{{ $.TypeName }}) []string {
+		subject.{{ .FieldName }} = {{ .Nullable.Valid }}
+
+		return []string{
+			{{ quote .FieldName }},
+		}
+	}
+}
+
+// {{ .MethodName }}Null sets {{ .FieldName }} to null.
+func (b {{ .ReceiverTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}Null() {{ $.OptionTypeName }} {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "builder", {{ quote (print .OptionPrefix .MethodName "Null") }})
+
+	return func(subject *{{ $.TypeName }}) []string {
+		subject.{{ .FieldName }} = {{ .Nullable.Null }}
+
+		return []string{
+			{{ quote .FieldName }},
+		}
+	}
+}
+{{ end }}
+{{- if .ClearValue }}
+// Clear{{ .MethodName }} sets {{ .FieldName }} to {{ if eq .ClearValue "nil" }}nil{{ else }}null{{ end }} and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b {{ .ReceiverTypeName }}{{ $.TypeArgs }}) Clear{{ .MethodName }}() {{ $.OptionTypeName }} {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "builder", {{ quote (print .OptionPrefix "Clear" .MethodName) }})
+
+	return func(subject *{{ $.TypeName }}) []string {
+		subject.{{ .FieldName }} = {{ .ClearValue }}
+
+		return []string{
+			{{ quote .FieldName }},
+		}
+	}
+}
+{{ end }}
+{{- if .IfSetTypeName }}
+// {{ .MethodName }}IfSet sets {{ .FieldName }} to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b {{ .ReceiverTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}IfSet(value {{ .IfSetTypeName }}) {{ $.OptionTypeName }} {
+	if value == nil {
+		return func(*{{ $.TypeName }}) []string {
+			return nil
+		}
+	}
+
+	return b.{{ .MethodName }}(*value)
+}
+{{ end }}
+{{- if .CopyFuncName }}
+
+// {{ .CopyFuncName }} deep copies a {{ .FieldTypeName }} for the {{ .FieldName }} setter.
+func {{ .CopyFuncName }}{{ $.TypeParams }}(value {{ .FieldTypeName }}) {{ .FieldTypeName }} {
+	{{ .CopyFuncBody }}
+}
+{{ end }}
+{{- end }}
+`))
+
+// Matcher!
+
+func genMatcher(buf *bytes.Buffer, target *codegenTarget, tag codegenTag) error {
+	fields, err := getFieldsFor(target)
+	if err != nil {
+		return err
+	}
+
+	matcherFields := []*matcherField{}
+	for _, field := range fields {
+		matcherField := &matcherField{structField: field}
+
+		// Pointers to types that have their own matcher can be matched field-by-field. This
+		// only ever references the other matcher by name, so self-referential and mutually
+		// referential types don't cause us to recurse. Production code can't reference test
+		// only matchers, so we skip those unless we're test only too.
+		if elemTypeName := strings.TrimPrefix(field.FieldTypeName, "*"); elemTypeName != field.FieldTypeName {
+			if testOnly, ok := target.MatcherTypes[elemTypeName]; ok && (tag.TestOnly || !testOnly) {
+				matcherField.NestedTypeName = elemTypeName
+			}
+		}
+
+		// Likewise, slices of those types can be matched element-by-element, regardless of
+		// the order of the elements.
+		if elemTypeName := strings.TrimPrefix(field.FieldTypeName, "[]"); elemTypeName != field.FieldTypeName {
+			pointerElemTypeName := strings.TrimPrefix(elemTypeName, "*")
+			if testOnly, ok := target.MatcherTypes[pointerElemTypeName]; ok && (tag.TestOnly || !testOnly) {
+				matcherField.SliceElemTypeName = pointerElemTypeName
+				matcherField.SliceOfPointers = pointerElemTypeName != elemTypeName
+			}
+		}
+
+		// Binary fields are matched with failure messages showing hex and base64, rather
+		// than a dump of every byte.
+		matcherField.Bytes = bytesTypePattern.MatchString(field.FieldTypeName) ||
+			bytesTypePattern.MatchString(field.UnderlyingTypeName)
+
+		matcherFields = append(matcherFields, matcherField)
+	}
+
+	vars := matcherTemplateVars{
+		Target:              target,
+		TypeName:            target.QualifiedName(),
+		External:            target.ImportPath != "",
+		MatcherTypeName:     matcherNameFor(target.Name),
+		MatcherFuncTypeName: matcherNameFor(target.Name) + "Func",
+		Fields:              matcherFields,
+		TypeParams:          target.TypeParams,
+		TypeArgs:            target.TypeArgs,
+	}
+
+	// Production matchers can't reference a test only diff
+	for _, other := range target.Tags {
+		if other.Name == "diff" && (tag.TestOnly || !other.TestOnly) {
+			vars.DiffFuncName = fmt.Sprintf("Diff%s", target.Name)
+		}
+	}
+
+	doc := typeDoc{SourceLines: sourceDocLinesFor(target)}
+	for _, field := range matcherFields {
+		doc.Options = append(doc.Options, field.MethodName)
+	}
+	if len(matcherFields) > 0 {
+		example := matcherFields[0]
+		doc.ExampleLines = []string{
+			fmt.Sprintf("Expect(%s).To(%s(", paramNameFor(target.Name), genericRef(target, vars.MatcherTypeName)),
+			fmt.Sprintf("\t%s.%s(%s),", genericRef(target, vars.MatcherTypeName), example.MethodName, paramNameFor(example.FieldName)),
+		}
+		if len(matcherFields) > 1 {
+			doc.ExampleLines = append(doc.ExampleLines,
+				fmt.Sprintf("\t%s.Match%s(Not(BeZero())),", genericRef(target, vars.MatcherTypeName), matcherFields[1].MethodName))
+		}
+		doc.ExampleLines = append(doc.ExampleLines, "))")
+	}
+	vars.DocLines = doc.Lines("Fields, each with a Match variant accepting a GomegaMatcher")
+
+	if err := templateFor("matcher", matcherTemplate).Execute(buf, vars); err != nil {
+		return errors.Wrap(err, "executing template")
+	}
+
+	return nil
+}
+
+type matcherTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
+
+	TypeName            string // APIKey
+	MatcherTypeName     string // APIKeyMatcher
+	MatcherFuncTypeName string // APIKeyMatcherFunc
+	External            bool   // true if we can't add methods to the type
+	DiffFuncName        string // DiffAPIKey, if generated alongside, to diff on failure
+	Fields              []*matcherField
+	DocLines            []string // extra doc comment for the matcher, listing fields
+	TypeParams          string   // [T any], for generic types
+	TypeArgs            string   // [T]
+}
+
+// bytesTypePattern matches the types of binary fields, []byte or [N]byte, which we match
+// by their hex and base64 encodings.
+var bytesTypePattern = regexp.MustCompile(`^\[[^\[\]]*\](byte|uint8)$`)
+
+type matcherField struct {
+	*structField
+	NestedTypeName    string // Organisation, if this field is an *Organisation with a matcher
+	SliceElemTypeName string // Action, if this field is a []Action or []*Action with a matcher
+	SliceOfPointers   bool   // true if this field is a []*Action
+	Bytes             bool   // true if this field is a []byte or [N]byte
+}
+
+var matcherTemplate = template.Must(template.New("matcherTemplate").Funcs(templateFuncs).Parse(`
+// {{ .MatcherTypeName }} creates a Gomega matcher for {{ .TypeName }} against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+{{- range .DocLines }}
+{{ . }}
+{{- end }}
+{{ if .TypeParams }}func {{ .MatcherTypeName }}{{ .TypeParams }}() {{ .MatcherFuncTypeName }}{{ .TypeArgs }} {
+	return {{ .MatcherFuncTypeName }}{{ .TypeArgs }}{{ else }}var {{ .MatcherTypeName }} = {{ .MatcherFuncTypeName }}{{ end }}(func(opts ...func(*{{ .TypeName }}, *{{ pkg "gstruct" }}.Fields)) {{ pkg "types" }}.GomegaMatcher {
+	fields := {{ pkg "gstruct" }}.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+{{- if .DiffFuncName }}
+
+	matcher := {{ pkg "gstruct" }}.PointTo(
+		{{ pkg "gstruct" }}.MatchFields({{ pkg "gstruct" }}.IgnoreExtras, fields),
+	)
+
+	// On failure, diff the actual value against a copy with every field we expected an
+	// exact value for set to that value, so only those fields can differ.
+	return {{ pkg "partial" }}.WithDiff(matcher, func(actual any) string {
+		subject, ok := actual.(*{{ .TypeName }})
+		if !ok || subject == nil {
+			return ""
+		}
+
+		expected := *subject
+		for _, opt := range opts {
+			opt(&expected, &{{ pkg "gstruct" }}.Fields{})
+		}
+
+		return {{ .DiffFuncName }}{{ .TypeArgs }}(expected, *subject)
+	})
+{{- else }}
+
+	return {{ pkg "gstruct" }}.PointTo(
+		{{ pkg "gstruct" }}.MatchFields({{ pkg "gstruct" }}.IgnoreExtras, fields),
+	)
+{{- end }}
+}){{ if .TypeParams }}
+}{{ end }}
+
+{{- if not .External }}
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b {{ .TypeName }}) Matcher(opts ...func(*{{ .TypeName }}, *{{ pkg "gstruct" }}.Fields)) {{ pkg "types" }}.GomegaMatcher {
+	return {{ .MatcherTypeName }}{{ if .TypeParams }}{{ .TypeArgs }}(){{ end }}(opts...)
+}
+{{- end }}
+
+type {{ .MatcherFuncTypeName }}{{ .TypeParams }} func(opts ...func(*{{ .TypeName }}, *{{ pkg "gstruct" }}.Fields)) {{ pkg "types" }}.GomegaMatcher
+
+type {{ .MatcherTypeName }}Matchers{{ .TypeParams }} struct {}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b {{ .MatcherFuncTypeName }}{{ .TypeArgs }}) Match() {{ .MatcherTypeName }}Matchers{{ .TypeArgs }} {
+	return {{ .MatcherTypeName }}Matchers{{ .TypeArgs }}{}
+}
+
+func init() {
+	{{ pkg "partial" }}.RegisterCoverage({{ quote .Target.CoveragePkgPath }}, {{ quote .TypeName }}, "matcher",
+		{{- range .Fields }}
+		{{ quote .MethodName }},
+		{{ quote (print "Match" .MethodName) }},
+		{{ quote (print "Match()." .MethodName) }},
+		{{- if .NestedTypeName }}
+		{{ quote (print "Match" .MethodName "With") }},
+		{{- end }}
+		{{- if .SliceElemTypeName }}
+		{{ quote (print "Match" .MethodName "ConsistOf") }},
+		{{- end }}
+		{{- if .Bytes }}
+		{{ quote (print .MethodName "Hex") }},
+		{{ quote (print .MethodName "Base64") }},
+		{{- end }}
+		{{- end }}
+	)
+}
+
+{{ range .Fields }}
+func (b {{ $.MatcherFuncTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}(value {{ .FieldTypeName }}) func(*{{ $.TypeName }}, *{{ pkg "gstruct" }}.Fields) {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "matcher", {{ quote .MethodName }})
+	{{- if .Factory }}
+	matcher := {{ pkg "partial" }}.WithProvenance({{ .Factory }}(value), {{ quote (print $.MatcherTypeName "." .MethodName) }})
+	{{- else if .Bytes }}
+	matcher := {{ pkg "partial" }}.WithProvenance({{ pkg "partial" }}.EqualBytes(value[:]), {{ quote (print $.MatcherTypeName "." .MethodName) }})
+	{{- else }}
+	matcher := {{ pkg "partial" }}.WithProvenance({{ pkg "partial" }}.Equal(value), {{ quote (print $.MatcherTypeName "." .MethodName) }})
+	{{- end }}
+{{- if $.DiffFuncName }}
+
+	return func(expected *{{ $.TypeName }}, fields *{{ pkg "gstruct" }}.Fields) {
+		if expected != nil {
+			expected.{{ .FieldName }} = value
+		}
+		(*fields)[{{ .FieldName | quote }}] = matcher
+	}
+{{- else }}
+
+	return func(_ *{{ $.TypeName }}, fields *{{ pkg "gstruct" }}.Fields) {
+		(*fields)[{{ .FieldName | quote }}] = matcher
+	}
+{{- end }}
+}
+{{ if .Bytes }}
+// {{ .MethodName }}Hex matches {{ .FieldName }} against the bytes written as hex in value.
+func (b {{ $.MatcherFuncTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}Hex(value string) func(*{{ $.TypeName }}, *{{ pkg "gstruct" }}.Fields) {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "matcher", {{ quote (print .MethodName "Hex") }})
+	matcher := {{ pkg "partial" }}.WithProvenance({{ pkg "partial" }}.EqualHex(value), {{ quote (print $.MatcherTypeName "." .MethodName "Hex") }})
+
+	return func(_ *{{ $.TypeName }}, fields *{{ pkg "gstruct" }}.Fields) {
+		(*fields)[{{ .FieldName | quote }}] = matcher
+	}
+}
+
+// {{ .MethodName }}Base64 matches {{ .FieldName }} against the bytes written as base64 in value.
+func (b {{ $.MatcherFuncTypeName }}{{ $.TypeArgs }}) {{ .MethodName }}Base64(value string) func(*{{ $.TypeName }}, *{{ pkg "gstruct" }}.Fields) {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "matcher", {{ quote (print .MethodName "Base64") }})
+	matcher := {{ pkg "partial" }}.WithProvenance({{ pkg "partial" }}.EqualBase64(value), {{ quote (print $.MatcherTypeName "." .MethodName "Base64") }})
+
+	return func(_ *{{ $.TypeName }}, fields *{{ pkg "gstruct" }}.Fields) {
+		(*fields)[{{ .FieldName | quote }}] = matcher
+	}
+}
+{{ end }}
+
+func (b {{ $.MatcherFuncTypeName }}{{ $.TypeArgs }}) Match{{ .MethodName }}(value {{ pkg "types" }}.GomegaMatcher) func(*{{ $.TypeName }}, *{{ pkg "gstruct" }}.Fields) {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "matcher", {{ quote (print "Match" .MethodName) }})
+	matcher := {{ pkg "partial" }}.WithProvenance(value, {{ quote (print $.MatcherTypeName ".Match" .MethodName) }})
+
+	return func(_ *{{ $.TypeName }}, fields *{{ pkg "gstruct" }}.Fields) {
+		(*fields)[{{ .FieldName | quote }}] = matcher
+	}
 }
 
-func runGeneration(dir string) error {
-	log.Print("removing existing *.genpartial.go files...")
-	err := removeExistingGenFiles(dir)
-	if err != nil {
-		return err
-	}
+func (b {{ $.MatcherTypeName }}Matchers{{ $.TypeArgs }}) {{ .MethodName }}(value {{ pkg "types" }}.GomegaMatcher) func(*{{ $.TypeName }}, *{{ pkg "gstruct" }}.Fields) {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "matcher", {{ quote (print "Match()." .MethodName) }})
+	matcher := {{ pkg "partial" }}.WithProvenance(value, {{ quote (print $.MatcherTypeName ".Match()." .MethodName) }})
 
-	fset := token.NewFileSet()
-	notCodegenFiles := func(info fs.FileInfo) bool {
-		return !strings.HasSuffix(info.Name(), ".genpartial.go")
+	return func(_ *{{ $.TypeName }}, fields *{{ pkg "gstruct" }}.Fields) {
+		(*fields)[{{ .FieldName | quote }}] = matcher
 	}
-	pkgs, err := parser.ParseDir(fset, dir, notCodegenFiles, parser.ParseComments)
-	if err != nil {
-		return err
+}
+{{ if .NestedTypeName }}
+// Match{{ .MethodName }}With matches {{ .FieldName }} against the given {{ .NestedTypeName }} matchers,
+// failing rather than panicking if it is nil.
+func (b {{ $.MatcherFuncTypeName }}{{ $.TypeArgs }}) Match{{ .MethodName }}With(opts ...func(*{{ .NestedTypeName }}, *{{ pkg "gstruct" }}.Fields)) func(*{{ $.TypeName }}, *{{ pkg "gstruct" }}.Fields) {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "matcher", {{ quote (print "Match" .MethodName "With") }})
+	matcher := {{ pkg "partial" }}.WithProvenance({{ matcherName .NestedTypeName }}(opts...), {{ quote (print $.MatcherTypeName ".Match" .MethodName "With") }})
+
+	return func(_ *{{ $.TypeName }}, fields *{{ pkg "gstruct" }}.Fields) {
+		(*fields)[{{ .FieldName | quote }}] = matcher
 	}
+}
+{{ end }}
+{{- if .SliceElemTypeName }}
+// Match{{ .MethodName }}ConsistOf matches when {{ .FieldName }} has exactly one element matching each of
+// the given matchers, in any order. Build each with {{ matcherName .SliceElemTypeName }}, passing it
+// every option the element should match.
+func (b {{ $.MatcherFuncTypeName }}{{ $.TypeArgs }}) Match{{ .MethodName }}ConsistOf(elements ...{{ pkg "types" }}.GomegaMatcher) func(*{{ $.TypeName }}, *{{ pkg "gstruct" }}.Fields) {
+	{{ pkg "partial" }}.RecordCoverage({{ quote $.Target.CoveragePkgPath }}, {{ quote $.TypeName }}, "matcher", {{ quote (print "Match" .MethodName "ConsistOf") }})
+	{{- if .SliceOfPointers }}
+
+	matcher := {{ pkg "partial" }}.WithProvenance({{ pkg "gomega" }}.ConsistOf(elements), {{ quote (print $.MatcherTypeName ".Match" .MethodName "ConsistOf") }})
+	{{- else }}
+
+	// Element matchers expect pointers, so point at each element before matching.
+	matcher := {{ pkg "partial" }}.WithProvenance({{ pkg "gomega" }}.WithTransform(func(items []{{ .SliceElemTypeName }}) []*{{ .SliceElemTypeName }} {
+		pointers := []*{{ .SliceElemTypeName }}{}
+		for idx := range items {
+			pointers = append(pointers, &items[idx])
+		}
 
-	findStruct := func(pkg *ast.Package, name string) *ast.StructType {
-		var result *ast.StructType
-		ast.Inspect(pkg, func(n ast.Node) bool {
-			switch node := n.(type) {
-			case *ast.TypeSpec:
-				if node.Name.String() == name {
-					result, _ = node.Type.(*ast.StructType)
-					return false
-				}
-			}
-
-			return true
-		})
+		return pointers
+	}, {{ pkg "gomega" }}.ConsistOf(elements)), {{ quote (print $.MatcherTypeName ".Match" .MethodName "ConsistOf") }})
+	{{- end }}
 
-		return result
+	return func(_ *{{ $.TypeName }}, fields *{{ pkg "gstruct" }}.Fields) {
+		(*fields)[{{ .FieldName | quote }}] = matcher
 	}
+}
+{{ end }}
+{{- end }}
+`))
 
-	targets := []*codegenTarget{}
-	for pkgName, pkg := range pkgs {
-		docPkg := doc.New(pkg, "", doc.AllDecls)
-		for _, pkgType := range docPkg.Types {
-			if strings.Contains(pkgType.Doc, "codegen-partial:") {
-				codegenTags := regexp.MustCompile(`codegen-partial:(\S+)`).FindStringSubmatch(pkgType.Doc)[1]
-				pos := fset.Position(pkgType.Decl.TokPos)
-				structType := findStruct(pkg, pkgType.Name)
-
-				if structType == nil {
-					return errors.New(fmt.Sprintf("could not find struct for name %s referenced by file %s", pkgType.Name, pos.Filename))
-				}
+// Diff!
 
-				targets = append(targets, &codegenTarget{
-					Package:    pkgName,
-					Filename:   pos.Filename,
-					Tags:       strings.Split(codegenTags, ","),
-					Type:       pkgType,
-					StructType: structType,
-				})
-			}
+func genDiff(buf *bytes.Buffer, target *codegenTarget) error {
+	fields, err := getFieldsFor(target)
+	if err != nil {
+		return err
+	}
+
+	// Only compare fields that are persisted, as associations are loaded separately and
+	// would otherwise dominate the output.
+	databaseFields := []*structField{}
+	for _, field := range fields {
+		if field.DatabaseBacked() {
+			databaseFields = append(databaseFields, field)
 		}
 	}
 
-	sort.Slice(targets, func(i, j int) bool {
-		return targets[i].Filename < targets[j].Filename
-	})
+	vars := diffTemplateVars{
+		Target:       target,
+		TypeName:     target.QualifiedName(),
+		DiffFuncName: fmt.Sprintf("Diff%s", target.Name),
+		Fields:       databaseFields,
+		TypeParams:   target.TypeParams,
+	}
 
-	// Buffer all codegen files so we don't partially write then to disk
-	buffers := map[string]*bytes.Buffer{}
+	if err := templateFor("diff", diffTemplate).Execute(buf, vars); err != nil {
+		return errors.Wrap(err, "executing template")
+	}
 
-	for _, target := range targets {
-		targetFilename := strings.TrimSuffix(target.Filename, ".go") + ".genpartial.go"
-		buf, ok := buffers[targetFilename]
-		if !ok {
-			buf = bytes.NewBufferString(genPreamble(target.Package))
-			buffers[targetFilename] = buf
-		}
+	return nil
+}
 
-		for _, tag := range target.Tags {
-			switch tag {
-			case "builder":
-				if err := genBuilder(buf, target); err != nil {
-					return errors.Wrap(err, fmt.Sprintf("error generating builder for %s in %s", target.Type.Name, target.Filename))
-				}
+type diffTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
 
-			case "matcher":
-				if err := genMatcher(buf, target); err != nil {
-					return errors.Wrap(err, fmt.Sprintf("error generating matcher for %s in %s", target.Type.Name, target.Filename))
-				}
+	TypeName     string // APIKey
+	DiffFuncName string // DiffAPIKey
+	Fields       []*structField
+	TypeParams   string // [T any], for generic types
+}
 
-			default:
-				return errors.New(fmt.Sprintf("unrecognised codegen tag for %s in %s: %s", target.Type.Name, target.Filename, tag))
-			}
-		}
-	}
+var diffTemplate = template.Must(template.New("diffTemplate").Funcs(templateFuncs).Parse(`
+// {{ .DiffFuncName }} describes how each database-backed field differs between a and b,
+// returning an empty string if they match. Useful when a {{ .TypeName }} matcher fails, as
+// the output is much smaller than printing each struct in full.
+func {{ .DiffFuncName }}{{ .TypeParams }}(a, b {{ .TypeName }}) string {
+	return {{ pkg "partial" }}.Diff({{ quote .TypeName }}, []{{ pkg "partial" }}.FieldDiff{
+		{{- range .Fields }}
+		{FieldName: {{ quote .FieldName }}, A: a.{{ .FieldName }}, B: b.{{ .FieldName }}},
+		{{- end }}
+	})
+}
+`))
 
-	log.Print("writing buffers")
-	for fileName, buf := range buffers {
-		log.Printf("=> %s", fileName)
-		if err := ioutil.WriteFile(fileName, buf.Bytes(), 0644); err != nil {
-			return err
-		}
-	}
+// Event!
 
-	{
-		log.Print("go add missing imports")
-		cmd := exec.Command("goimports", "-w", dir)
-		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
-		if err := cmd.Run(); err != nil {
-			log.Fatal(err)
-		}
+func genEvent(buf *bytes.Buffer, target *codegenTarget) error {
+	vars := eventTemplateVars{
+		Target:        target,
+		TypeName:      target.QualifiedName(),
+		EventTypeName: fmt.Sprintf("%sChangedEvent", target.Name),
+		TypeParams:    target.TypeParams,
 	}
 
-	{
-		log.Print("go fmt")
-		cmd := exec.Command("gofmt", "-w", dir)
-		cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
-		if err := cmd.Run(); err != nil {
-			log.Fatal(err)
-		}
+	if err := templateFor("event", eventTemplate).Execute(buf, vars); err != nil {
+		return errors.Wrap(err, "executing template")
 	}
 
 	return nil
 }
 
-func genPreamble(pkg string) string {
-	return fmt.Sprintf(`// Code generated by github.com/incident-io/partial/gen, DO NOT EDIT.
+type eventTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
+
+	TypeName      string // APIKey
+	EventTypeName string // APIKeyChangedEvent
+	TypeParams    string // [T any], for generic types
+}
+
+var eventTemplate = template.Must(template.New("eventTemplate").Funcs(templateFuncs).Parse(`
+// {{ .EventTypeName }} is the payload emitted when a {{ .TypeName }} changes, carrying the new
+// values of only the fields that changed.
+type {{ .EventTypeName }} struct {
+	EntityID      string         ` + "`" + `json:"entity_id"` + "`" + `
+	ChangedFields []string       ` + "`" + `json:"changed_fields"` + "`" + ` // JSON names of the changed fields
+	Values        map[string]any ` + "`" + `json:"values"` + "`" + `         // new values, keyed by JSON name
+	Actor         string         ` + "`" + `json:"actor"` + "`" + `
+}
 
-package %s
+// New{{ .EventTypeName }} builds the change event for applying the partial to the {{ .TypeName }}
+// with the given ID.
+func New{{ .EventTypeName }}{{ .TypeParams }}(entityID string, model {{ pkg "partial" }}.Partial[{{ .TypeName }}], actor string) {{ .EventTypeName }} {
+	changedFields, values := {{ pkg "partial" }}.ChangedValues(model)
 
-`, pkg)
+	return {{ .EventTypeName }}{
+		EntityID:      entityID,
+		ChangedFields: changedFields,
+		Values:        values,
+		Actor:         actor,
+	}
 }
+`))
+
+// Constructor!
 
-// removeExistingGenFiles removes all .genpartial.go files in the given directory, and should be
-// run before we attempt to rebuild things.
-func removeExistingGenFiles(dir string) error {
-	entries, err := os.ReadDir(dir)
+func genConstructor(buf *bytes.Buffer, target *codegenTarget, tag codegenTag) error {
+	// Constructors wrap the builder, so must be able to reference it.
+	builderTag, ok := target.Tag("builder")
+	if !ok || (builderTag.TestOnly && !tag.TestOnly) {
+		return errors.New("constructors need a builder that isn't test only, unless they're test only too")
+	}
+
+	fields, err := getFieldsFor(target)
 	if err != nil {
 		return err
 	}
-	for _, entry := range entries {
-		sourceFile := path.Join(dir, entry.Name())
-		if strings.HasSuffix(sourceFile, ".genpartial.go") {
-			if err := os.Remove(sourceFile); err != nil {
-				return err
-			}
+
+	vars := constructorTemplateVars{
+		Target:          target,
+		TypeName:        target.QualifiedName(),
+		ConstructorName: fmt.Sprintf("New%s", target.Name),
+		BuilderTypeName: builderNameFor(target.Name),
+		TypeParams:      target.TypeParams,
+		TypeArgs:        target.TypeArgs,
+	}
+	for _, field := range fields {
+		if field.Required {
+			vars.Params = append(vars.Params, constructorParam{
+				structField: field,
+				ParamName:   paramNameFor(field.FieldName),
+			})
 		}
 	}
 
+	if err := templateFor("constructor", constructorTemplate).Execute(buf, vars); err != nil {
+		return errors.Wrap(err, "executing template")
+	}
+
 	return nil
 }
 
-// typeNameFor turns an ast.Expr into Go code that references the expressions type.
-func typeNameFor(expr ast.Expr) (string, error) {
-	switch fieldType := expr.(type) {
-	case *ast.Ident:
-		return fieldType.Name, nil // string
+// paramNameFor turns a field name into an unexported parameter name, lowercasing any
+// leading initialism: ID becomes id, and APIKey becomes apiKey.
+func paramNameFor(fieldName string) string {
+	runes := []rune(fieldName)
 
-	case *ast.StarExpr:
-		childType, err := typeNameFor(fieldType.X)
-		if err != nil {
-			return "", errors.Wrap(err, "pointer type")
-		}
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
 
-		return "*" + childType, nil // *string
+	// Keep the last capital of an initialism followed by another word, which starts that
+	// word.
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	for idx := 0; idx < upper; idx++ {
+		runes[idx] = unicode.ToLower(runes[idx])
+	}
 
-	case *ast.SelectorExpr:
-		childType, err := typeNameFor(fieldType.X)
-		if err != nil {
-			return "", errors.Wrap(err, "selector type")
-		}
+	name := string(runes)
+	if token.IsKeyword(name) {
+		return name + "Value"
+	}
 
-		return fmt.Sprintf("%s.%s", childType, fieldType.Sel.Name), nil // null.String
+	return name
+}
 
-	case *ast.ArrayType:
-		childType, err := typeNameFor(fieldType.Elt)
-		if err != nil {
-			return "", errors.Wrap(err, "array type")
-		}
+type constructorTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
 
-		return fmt.Sprintf("[]%s", childType), nil // []string
-	}
+	TypeName        string // APIKey
+	ConstructorName string // NewAPIKey
+	BuilderTypeName string // APIKeyBuilder
+	Params          []constructorParam
+	TypeParams      string // [T any], for generic types
+	TypeArgs        string // [T]
+}
 
-	return "", errors.New(fmt.Sprintf("unsupported expr type: %v", expr))
+type constructorParam struct {
+	*structField
+	ParamName string // organisationID
 }
 
-type structField struct {
-	FieldName     string // ID
-	FieldTypeName string // string
+var constructorTemplate = template.Must(template.New("constructorTemplate").Funcs(templateFuncs).Parse(`
+// {{ .ConstructorName }} builds a {{ .TypeName }} from its required fields, followed by builder setters
+// for any others.
+func {{ .ConstructorName }}{{ .TypeParams }}(
+	{{- range .Params }}{{ .ParamName }} {{ .FieldTypeName }}, {{ end -}}
+	opts ...{{ pkg "partial" }}.Option[{{ .TypeName }}]) {{ pkg "partial" }}.Partial[{{ .TypeName }}] {
+	required := []{{ pkg "partial" }}.Option[{{ .TypeName }}]{
+		{{- range .Params }}
+		func(subject *{{ $.TypeName }}) []string {
+			subject.{{ .FieldName }} = {{ .ParamName }}
+			return []string{ {{- quote .FieldName -}} }
+		},
+		{{- end }}
+	}
+
+	return {{ .BuilderTypeName }}{{ if .TypeParams }}{{ .TypeArgs }}(){{ end }}(append(required, opts...)...)
 }
+`))
 
-func getFieldsFor(target *codegenTarget) ([]*structField, error) {
-	fields := []*structField{}
-	for _, field := range target.StructType.Fields.List {
-		// Embedded fields, we can't help here
-		if len(field.Names) == 0 {
+// Options!
+
+func genOptions(buf *bytes.Buffer, target *codegenTarget, tag codegenTag) error {
+	// The option type would be a generic alias, which needs a newer Go than we support.
+	if target.TypeParams != "" {
+		return errors.New("options can't be generated for generic types")
+	}
+
+	// Option funcs wrap the builder's setters, so must be able to reference them.
+	builderTag, ok := target.Tag("builder")
+	if !ok || (builderTag.TestOnly && !tag.TestOnly) {
+		return errors.New("options need a builder that isn't test only, unless they're test only too")
+	}
+
+	fields, err := getFieldsFor(target)
+	if err != nil {
+		return err
+	}
+
+	vars := optionsTemplateVars{
+		Target:          target,
+		TypeName:        target.QualifiedName(),
+		OptionTypeName:  fmt.Sprintf("%sOption", target.Name),
+		BuilderTypeName: builderNameFor(target.Name),
+	}
+	for _, field := range fields {
+		// Only fields with a setter on the builder get an option func.
+		if field.Immutable || field.ReadOnly {
 			continue
 		}
 
-		fieldName := field.Names[0].Name
-		typeName, err := typeNameFor(field.Type)
-		if err != nil {
-			return nil, errors.Wrap(err, fmt.Sprintf("field %s on type %s", fieldName, target.Type.Name))
+		setter := field.MethodName
+		if field.Group != "" {
+			setter = fmt.Sprintf("%s().%s", field.Group, field.MethodName)
 		}
 
-		fields = append(fields, &structField{
-			FieldName:     fieldName, // ID
-			FieldTypeName: typeName,  // string
-		})
+		funcName := fmt.Sprintf("Set%s%s", target.Name, field.MethodName)
+		if field.Parse != "" {
+			vars.Options = append(vars.Options, optionFunc{funcName, setter, field.SetterAccepts})
+			continue
+		}
+
+		vars.Options = append(vars.Options, optionFunc{funcName, setter, field.FieldTypeName})
+		if nullable := nullableTypes[field.FieldTypeName]; nullable != nil {
+			vars.Options = append(vars.Options,
+				optionFunc{funcName + "Value", setter + "Value", nullable.ValueTypeName},
+				optionFunc{funcName + "Null", setter + "Null", ""},
+			)
+		}
 	}
 
-	return fields, nil
+	if err := templateFor("options", optionsTemplate).Execute(buf, vars); err != nil {
+		return errors.Wrap(err, "executing template")
+	}
+
+	return nil
 }
 
-// Builder!
+type optionsTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
 
-func genBuilder(buf *bytes.Buffer, target *codegenTarget) error {
-	fields, err := getFieldsFor(target)
-	if err != nil {
-		return err
+	TypeName        string // APIKey
+	OptionTypeName  string // APIKeyOption
+	BuilderTypeName string // APIKeyBuilder
+	Options         []optionFunc
+}
+
+type optionFunc struct {
+	FuncName      string // SetAPIKeyName
+	Setter        string // Name, or Timestamps().CreatedAt if grouped
+	ParamTypeName string // string, or empty for setters that take nothing
+}
+
+var optionsTemplate = template.Must(template.New("optionsTemplate").Funcs(templateFuncs).Parse(`
+// {{ .OptionTypeName }} is a setter for {{ .TypeName }}, as taken by {{ .BuilderTypeName }}.
+type {{ .OptionTypeName }} = {{ pkg "partial" }}.Option[{{ .TypeName }}]
+{{ range .Options }}
+// {{ .FuncName }} is {{ $.BuilderTypeName }}.{{ .Setter }}, for code that stores or passes setters around.
+func {{ .FuncName }}({{ if .ParamTypeName }}value {{ .ParamTypeName }}{{ end }}) {{ $.OptionTypeName }} {
+	return {{ $.BuilderTypeName }}.{{ .Setter }}({{ if .ParamTypeName }}value{{ end }})
+}
+{{ end }}
+`))
+
+// Entry!
+
+func genEntry(buf *bytes.Buffer, target *codegenTarget, tag codegenTag) error {
+	// Entries are built from a builder and matcher of a particular instantiation, which
+	// we can't pick for generic types.
+	if target.TypeParams != "" {
+		return errors.New("entries can't be generated for generic types")
 	}
 
-	vars := builderTemplateVars{
-		TypeName:            target.Type.Name,
-		BuilderTypeName:     fmt.Sprintf("%sBuilder", target.Type.Name),
-		BuilderFuncTypeName: fmt.Sprintf("%sBuilderFunc", target.Type.Name),
-		Fields:              fields,
+	// Entries pair the builder with the matcher, so must be able to reference both.
+	for _, name := range []string{"builder", "matcher"} {
+		other, ok := target.Tag(name)
+		if !ok || (other.TestOnly && !tag.TestOnly) {
+			return errors.New(fmt.Sprintf("entries need a %s that isn't test only, unless they're test only too", name))
+		}
 	}
 
-	if err := builderTemplate.Execute(buf, vars); err != nil {
+	vars := entryTemplateVars{
+		Target:              target,
+		TypeName:            target.QualifiedName(),
+		EntryFuncName:       fmt.Sprintf("%sEntry", target.Name),
+		BuilderTypeName:     builderNameFor(target.Name),
+		BuilderFuncTypeName: builderNameFor(target.Name) + "Func",
+		MatcherTypeName:     matcherNameFor(target.Name),
+		MatcherFuncTypeName: matcherNameFor(target.Name) + "Func",
+	}
+
+	if err := templateFor("entry", entryTemplate).Execute(buf, vars); err != nil {
 		return errors.Wrap(err, "executing template")
 	}
 
 	return nil
 }
 
-type builderTemplateVars struct {
+type entryTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
+
 	TypeName            string // APIKey
+	EntryFuncName       string // APIKeyEntry
 	BuilderTypeName     string // APIKeyBuilder
 	BuilderFuncTypeName string // APIKeyBuilderFunc
-	Fields              []*structField
+	MatcherTypeName     string // APIKeyMatcher
+	MatcherFuncTypeName string // APIKeyMatcherFunc
 }
 
-var builderTemplate = template.Must(template.New("builderTemplate").Funcs(sprig.TxtFuncMap()).Parse(`
-// {{ .BuilderTypeName }} initialises a {{ .TypeName }} struct with fields from the given setters. Setters
-// are applied first to last, with subsequent sets taking precedence.
-var {{ .BuilderTypeName }} = {{ .BuilderFuncTypeName }}(func(opts ...func(*{{ .TypeName }}) []string) partial.Partial[{{ .TypeName }}] {
-	apply := func(base {{ .TypeName }}) partial.Partial[{{ .TypeName }}] {
-		model := partial.Partial[{{ .TypeName }}]{
-			Subject: base,
-			FieldNames: []string{},
+var entryTemplate = template.Must(template.New("entryTemplate").Funcs(templateFuncs).Parse(`
+// {{ .EntryFuncName }} builds a DescribeTable entry from a {{ .TypeName }} partial and a matcher for the
+// expected result, which the table body receives as its parameters:
+//
+//	DescribeTable("updating", func(model partial.Partial[{{ .TypeName }}], expected types.GomegaMatcher) {
+//		Expect(model.Apply(existing)).To(expected)
+//	},
+//		{{ .EntryFuncName }}("sets the name",
+//			func(b {{ .BuilderFuncTypeName }}) partial.Partial[{{ .TypeName }}] { return b(b.Name("name")) },
+//			func(m {{ .MatcherFuncTypeName }}) types.GomegaMatcher { return m(m.Name("name")) },
+//		),
+//	)
+func {{ .EntryFuncName }}(description string, build func({{ .BuilderFuncTypeName }}) {{ pkg "partial" }}.Partial[{{ .TypeName }}], expect func({{ .MatcherFuncTypeName }}) {{ pkg "types" }}.GomegaMatcher) {{ pkg "table" }}.TableEntry {
+	return {{ pkg "table" }}.Entry(description, build({{ .BuilderTypeName }}), expect({{ .MatcherTypeName }}))
+}
+`))
+
+// flagFuncs are the pflag funcs binding a flag to a variable of each type we support.
+var flagFuncs = map[string]string{
+	"string":        "StringVar",
+	"bool":          "BoolVar",
+	"int":           "IntVar",
+	"int8":          "Int8Var",
+	"int16":         "Int16Var",
+	"int32":         "Int32Var",
+	"int64":         "Int64Var",
+	"uint":          "UintVar",
+	"uint8":         "Uint8Var",
+	"uint16":        "Uint16Var",
+	"uint32":        "Uint32Var",
+	"uint64":        "Uint64Var",
+	"float32":       "Float32Var",
+	"float64":       "Float64Var",
+	"time.Duration": "DurationVar",
+	"[]string":      "StringSliceVar",
+	"[]int":         "IntSliceVar",
+	"[]bool":        "BoolSliceVar",
+}
+
+func genFlags(buf *bytes.Buffer, target *codegenTarget) error {
+	if target.TypeParams != "" {
+		return errors.New("flags can't be generated for generic types")
+	}
+
+	fields, err := getFieldsFor(target)
+	if err != nil {
+		return err
+	}
+
+	vars := flagsTemplateVars{
+		Target:        target,
+		TypeName:      target.QualifiedName(),
+		FlagsTypeName: fmt.Sprintf("%sFlagSet", target.Name),
+		FlagsFuncName: fmt.Sprintf("%sFlags", target.Name),
+	}
+	for _, field := range fields {
+		// Flags are named after the JSON name, and only override fields we could patch
+		if field.JSONName == "" || field.Immutable || field.ReadOnly {
+			continue
 		}
-		for _, opt := range opts {
-			model.FieldNames = append(model.FieldNames, opt(&model.Subject)...)
+
+		// Defined types, such as Severity, are bound as the type they're defined as
+		flagTypeName, pointer := field.FieldTypeName, fmt.Sprintf("&f.values.%s", field.FieldName)
+		if _, ok := flagFuncs[flagTypeName]; !ok && field.UnderlyingTypeName != "" {
+			flagTypeName, pointer = field.UnderlyingTypeName, fmt.Sprintf("(*%s)(%s)", field.UnderlyingTypeName, pointer)
 		}
 
-		return model
-	}
+		flagFunc, ok := flagFuncs[flagTypeName]
+		if !ok {
+			return errors.New(fmt.Sprintf("field %s on type %s is a %s, which can't be bound to a flag, so needs a // partial:skip comment",
+				field.FieldName, target.Name, field.FieldTypeName))
+		}
 
-	model := apply({{ .TypeName }}{})
-	model.SetApply(func(base {{ .TypeName }}) *{{ .TypeName }} {
-		patched := apply(base).Subject
-		return &patched
-	})
+		defaultValue, err := flagDefaultFor(flagTypeName, field.Default)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("field %s on type %s", field.FieldName, target.Name))
+		}
 
-	return model
-})
+		usage := field.Doc
+		if usage == "" {
+			usage = field.FieldName
+		}
 
-type {{ .BuilderFuncTypeName }} func(opts ...func(*{{ .TypeName }}) []string) partial.Partial[{{ .TypeName }}]
+		vars.Fields = append(vars.Fields, flagField{
+			FieldName: field.FieldName,
+			Pointer:   pointer,
+			FlagName:  strings.ReplaceAll(field.JSONName, "_", "-"),
+			FlagFunc:  flagFunc,
+			Default:   defaultValue,
+			Usage:     usage,
+		})
+	}
 
-{{ range .Fields }}
-func (b {{ $.BuilderFuncTypeName }}) {{ .FieldName }}(value {{ .FieldTypeName }}) func(*{{ $.TypeName }}) []string {
-	return func(subject *{{ $.TypeName }}) []string {
-		subject.{{ .FieldName }} = value
+	if err := templateFor("flags", flagsTemplate).Execute(buf, vars); err != nil {
+		return errors.Wrap(err, "executing template")
+	}
 
-		return []string{
-			{{ quote .FieldName }},
+	return nil
+}
+
+// flagDefaultFor returns the default value of a flag of the given type, which is its
+// zero value unless the field has a // partial:default comment.
+func flagDefaultFor(typeName, defaultValue string) (string, error) {
+	switch {
+	case strings.HasPrefix(typeName, "[]"):
+		if defaultValue != "" {
+			return "", errors.New("slice flags can't have a default")
 		}
+
+		return "nil", nil
+
+	case defaultValue != "":
+		return defaultValue, nil
+
+	case typeName == "string":
+		return `""`, nil
+
+	case typeName == "bool":
+		return "false", nil
 	}
+
+	return "0", nil
+}
+
+type flagsTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
+
+	TypeName      string // Config
+	FlagsTypeName string // ConfigFlagSet
+	FlagsFuncName string // ConfigFlags
+	Fields        []flagField
+}
+
+type flagField struct {
+	FieldName string // MaxRetries
+	Pointer   string // &f.values.MaxRetries, converted for defined types
+	FlagName  string // max-retries
+	FlagFunc  string // IntVar
+	Default   string // 3
+	Usage     string // from the doc comment of the field
+}
+
+var flagsTemplate = template.Must(template.New("flagsTemplate").Funcs(templateFuncs).Parse(`
+// {{ .FlagsTypeName }} binds a command line flag to each field of {{ .TypeName }}, for CLIs
+// that should only override the fields whose flags were passed.
+type {{ .FlagsTypeName }} struct {
+	flags  *{{ pkg "pflag" }}.FlagSet
+	values {{ .TypeName }}
+}
+
+// {{ .FlagsFuncName }} registers a flag for each field of {{ .TypeName }} on flags, such as
+// those of a cobra command. Once they're parsed, Partial tracks the flags that were set:
+//
+//	flags := {{ .FlagsFuncName }}(cmd.Flags())
+//	...
+//	overrides := flags.Partial()
+func {{ .FlagsFuncName }}(flags *{{ pkg "pflag" }}.FlagSet) *{{ .FlagsTypeName }} {
+	f := &{{ .FlagsTypeName }}{flags: flags}
+	{{- range .Fields }}
+	flags.{{ .FlagFunc }}({{ .Pointer }}, {{ quote .FlagName }}, {{ .Default }}, {{ quote .Usage }})
+	{{- end }}
+
+	return f
+}
+
+// Partial returns a partial tracking the fields whose flags were set, to the values they
+// were set to.
+func (f *{{ .FlagsTypeName }}) Partial() {{ pkg "partial" }}.Partial[{{ .TypeName }}] {
+	values := f.values
+
+	return {{ pkg "partial" }}.NewWithCapacity[{{ .TypeName }}]({{ len .Fields }}).Add(func(subject *{{ .TypeName }}) []string {
+		fieldNames := []string{}
+		{{- range .Fields }}
+		if f.flags.Changed({{ quote .FlagName }}) {
+			subject.{{ .FieldName }} = values.{{ .FieldName }}
+			fieldNames = append(fieldNames, {{ quote .FieldName }})
+		}
+		{{- end }}
+
+		return fieldNames
+	})
 }
-{{ end }}
 `))
 
-// Matcher!
+// Params!
+
+func genParams(buf *bytes.Buffer, target *codegenTarget) error {
+	if target.TypeParams != "" {
+		return errors.New("params can't be generated for generic types")
+	}
 
-func genMatcher(buf *bytes.Buffer, target *codegenTarget) error {
 	fields, err := getFieldsFor(target)
 	if err != nil {
 		return err
 	}
 
-	vars := matcherTemplateVars{
-		TypeName:            target.Type.Name,
-		MatcherTypeName:     fmt.Sprintf("%sMatcher", target.Type.Name),
-		MatcherFuncTypeName: fmt.Sprintf("%sMatcherFunc", target.Type.Name),
-		Fields:              fields,
+	vars := paramsTemplateVars{
+		Target:         target,
+		TypeName:       target.QualifiedName(),
+		ParamsTypeName: fmt.Sprintf("%sUpdateParams", target.Name),
+	}
+	for _, field := range fields {
+		// Params are for updates, so only have the columns an update could write
+		if !field.DatabaseBacked() || field.Immutable || field.ReadOnly {
+			continue
+		}
+
+		// Fields that are already pointers can be left nil as they are
+		paramTypeName, value := field.FieldTypeName, fmt.Sprintf("*p.%s", field.FieldName)
+		if !strings.HasPrefix(paramTypeName, "*") {
+			paramTypeName = "*" + paramTypeName
+		} else {
+			value = fmt.Sprintf("p.%s", field.FieldName)
+		}
+
+		vars.Fields = append(vars.Fields, paramsField{
+			FieldName:     field.FieldName,
+			ParamTypeName: paramTypeName,
+			JSONName:      field.JSONName,
+			Value:         value,
+		})
 	}
 
-	if err := matcherTemplate.Execute(buf, vars); err != nil {
+	if err := templateFor("params", paramsTemplate).Execute(buf, vars); err != nil {
 		return errors.Wrap(err, "executing template")
 	}
 
 	return nil
 }
 
-type matcherTemplateVars struct {
-	TypeName            string // APIKey
-	MatcherTypeName     string // APIKeyMatcher
-	MatcherFuncTypeName string // APIKeyMatcherFunc
-	Fields              []*structField
-}
-
-var matcherTemplate = template.Must(template.New("matcherTemplate").Funcs(sprig.TxtFuncMap()).Parse(`
-// {{ .MatcherTypeName }} creates a Gomega matcher for {{ .TypeName }} against the given
-// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
-var {{ .MatcherTypeName }} = {{ .MatcherFuncTypeName }}(func(opts ...func(*{{ .TypeName }}, *gstruct.Fields)) types.GomegaMatcher {
-	fields := gstruct.Fields{}
-	for _, opt := range opts {
-		opt(nil, &fields)
-	}
-
-	return gstruct.PointTo(
-		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
-	)
-})
+type paramsTemplateVars struct {
+	Target *codegenTarget // for custom templates, which may need more than we use
 
-// Matcher is added to the base type, permitting other generic functions to build matchers
-// from each of the matcher-setter functions.
-func (b {{ .TypeName }}) Matcher(opts ...func(*{{ .TypeName }}, *gstruct.Fields)) types.GomegaMatcher {
-	return {{ .MatcherTypeName }}(opts...)
+	TypeName       string // Incident
+	ParamsTypeName string // IncidentUpdateParams
+	Fields         []paramsField
 }
 
-type {{ .MatcherFuncTypeName }} func(opts ...func(*{{ .TypeName }}, *gstruct.Fields)) types.GomegaMatcher
-
-type {{ .MatcherTypeName }}Matchers struct {}
-
-// Match returns an interface with the same methods as the base matcher, but accepting
-// GomegaMatcher parameters instead of the exact equality matches.
-func (b {{ .MatcherFuncTypeName }}) Match() {{ .MatcherTypeName }}Matchers {
-	return {{ .MatcherTypeName }}Matchers{}
+type paramsField struct {
+	FieldName     string // Name
+	ParamTypeName string // *string
+	JSONName      string // name
+	Value         string // *p.Name, what's assigned to the field when it isn't nil
 }
 
-{{ range .Fields }}
-func (b {{ $.MatcherFuncTypeName }}) {{ .FieldName }}(value {{ .FieldTypeName }}) func(*{{ $.TypeName }}, *gstruct.Fields) {
-	return func(_ *{{ $.TypeName }}, fields *gstruct.Fields) {
-		(*fields)[{{ .FieldName | quote }}] = gomega.Equal(value)
-	}
+var paramsTemplate = template.Must(template.New("paramsTemplate").Funcs(templateFuncs).Parse(`
+// {{ .ParamsTypeName }} holds the fields of {{ .TypeName }} to update, for clients of a service that
+// shouldn't build partials themselves. Fields left nil aren't updated.
+type {{ .ParamsTypeName }} struct {
+	{{- range .Fields }}
+	{{ .FieldName }} {{ .ParamTypeName }} ` + "`" + `json:"{{ .JSONName }},omitempty"` + "`" + `
+	{{- end }}
 }
 
-func (b {{ $.MatcherFuncTypeName }}) Match{{ .FieldName }}(value types.GomegaMatcher) func(*{{ $.TypeName }}, *gstruct.Fields) {
-	return func(_ *{{ $.TypeName }}, fields *gstruct.Fields) {
-		(*fields)[{{ .FieldName | quote }}] = value
-	}
-}
+// Partial returns a partial tracking the fields of p that aren't nil, to the values they
+// point to.
+func (p {{ .ParamsTypeName }}) Partial() {{ pkg "partial" }}.Partial[{{ .TypeName }}] {
+	return {{ pkg "partial" }}.NewWithCapacity[{{ .TypeName }}]({{ len .Fields }}).Add(func(subject *{{ .TypeName }}) []string {
+		fieldNames := []string{}
+		{{- range .Fields }}
+		if p.{{ .FieldName }} != nil {
+			subject.{{ .FieldName }} = {{ .Value }}
+			fieldNames = append(fieldNames, {{ quote .FieldName }})
+		}
+		{{- end }}
 
-func (b {{ $.MatcherTypeName }}Matchers) {{ .FieldName }}(value types.GomegaMatcher) func(*{{ $.TypeName }}, *gstruct.Fields) {
-	return func(_ *{{ $.TypeName }}, fields *gstruct.Fields) {
-		(*fields)[{{ .FieldName | quote }}] = value
-	}
+		return fieldNames
+	})
 }
-{{ end }}
 `))
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("defaultValueFor", func() {
+	DescribeTable("literals by the kind of the field",
+		func(value, typeName, underlyingTypeName, expected string) {
+			literal, err := defaultValueFor(map[string]string{"default": value}, typeName, underlyingTypeName)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(literal).To(Equal(expected))
+		},
+		Entry("string", "active", "string", "", `"active"`),
+		Entry("string that looks like a number", "404", "string", "", `"404"`),
+		Entry("string that looks like a bool", "true", "string", "", `"true"`),
+		Entry("defined string", "p2", "Priority", "string", `"p2"`),
+		Entry("nullable string", "404", "null.String", "", `"404"`),
+		Entry("int", "3", "int", "", "3"),
+		Entry("hex uint", "0xff", "uint8", "", "0xff"),
+		Entry("float", "0.5", "float64", "", "0.5"),
+		Entry("nullable int", "3", "null.Int", "", "3"),
+		Entry("bool", "true", "bool", "", "true"),
+		Entry("duration", "30s", "time.Duration", "int64", "30000000000"),
+		Entry("unresolved type that looks like a number", "3", "external.Count", "", "3"),
+		Entry("unresolved type", "active", "external.Status", "", `"active"`),
+	)
+
+	DescribeTable("defaults that aren't valid for the field",
+		func(value, typeName, underlyingTypeName, message string) {
+			_, err := defaultValueFor(map[string]string{"default": value}, typeName, underlyingTypeName)
+
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("int", "three", "int", "", "default three is not a valid int"),
+		Entry("negative uint", "-1", "uint", "", "default -1 is not a valid uint"),
+		Entry("defined int", "high", "Severity", "int", "default high is not a valid int"),
+		Entry("bool", "yes", "bool", "", "default yes is not a valid bool"),
+		Entry("nullable float", "many", "null.Float", "", "default many is not a valid float64"),
+		Entry("duration", "soon", "time.Duration", "int64", "parsing default"),
+	)
+
+	It("is empty without a default", func() {
+		Expect(defaultValueFor(map[string]string{}, "string", "")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("-keep-going", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{
+			"thing.go": thingSource,
+			"broken.go": `package things
+
+// codegen-partial:builder
+type Broken struct {
+	Callback func() ` + "`json:\"callback\"`" + `
+}
+`,
+		})
+	})
+
+	DescribeTable("generating a package where one type fails",
+		func(args []string, message string, writesOthers bool) {
+			Expect(runGen(dir, args)).To(MatchError(ContainSubstring(message)))
+
+			_, err := os.Stat(filepath.Join(dir, "thing.genpartial.go"))
+			Expect(err == nil).To(Equal(writesOthers))
+			if writesOthers {
+				Expect(readFixtureFile(dir, "thing.genpartial.go")).To(ContainSubstring("var ThingBuilder"))
+				Expect(readFixtureFile(dir, "broken.genpartial.go")).NotTo(ContainSubstring("BrokenBuilder"))
+			}
+		},
+		Entry("stops at the first failure", nil, "Callback", false),
+		Entry("keeps going, reporting every failure", []string{"-keep-going"}, "failed to generate 1 type(s):", true),
+	)
+})
+
+var _ = Describe("-only", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{"thing.go": thingSource})
+	})
+
+	DescribeTable("generating a subset of the annotated tags",
+		func(args []string, generated, skipped []string) {
+			Expect(runGen(dir, args)).To(Succeed())
+
+			source := readFixtureFile(dir, "thing.genpartial.go")
+			for _, name := range generated {
+				Expect(source).To(ContainSubstring(name))
+			}
+			for _, name := range skipped {
+				Expect(source).NotTo(ContainSubstring(name))
+			}
+		},
+		Entry("every tag by default", nil, []string{"var ThingBuilder", "var ThingMatcher"}, nil),
+		Entry("only builders", []string{"-only", "builder"}, []string{"var ThingBuilder"}, []string{"ThingMatcher"}),
+		Entry("only matchers", []string{"-only=matcher"}, []string{"var ThingMatcher"}, []string{"ThingBuilder"}),
+		Entry("tags that aren't annotated", []string{"-only", "matcher,diff"}, []string{"var ThingMatcher"}, []string{"ThingBuilder", "ThingDiff"}),
+	)
+
+	It("fails for tags that don't exist", func() {
+		Expect(runGen(dir, []string{"-only", "bulider"})).To(MatchError("unrecognised codegen tag for -only: bulider"))
+	})
+
+	It("removes the generated file when no tags are left", func() {
+		Expect(runGen(dir, nil)).To(Succeed())
+		Expect(runGen(dir, []string{"-only", "diff"})).To(Succeed())
+
+		_, err := os.Stat(filepath.Join(dir, "thing.genpartial.go"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("testonly", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{
+			"thing.go": strings.Replace(thingSource, "builder,matcher", "builder(testonly),matcher(testonly)", 1),
+			"thing_test.go": `package things
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestThing(t *testing.T) {
+	thing := ThingBuilder(ThingBuilder.Name("name")).Apply(Thing{})
+
+	NewWithT(t).Expect(thing).To(ThingMatcher(ThingMatcher.Name("name")))
+}
+`,
+		})
+	})
+
+	It("generates code the package's own tests can use, leaving it out of the build", func() {
+		Expect(runGen(dir, nil)).To(Succeed())
+
+		_, err := os.Stat(filepath.Join(dir, "thing.genpartial.go"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+
+		for _, args := range [][]string{{"build", "."}, {"test", "."}} {
+			command := exec.Command("go", args...)
+			command.Dir = dir
+			output, err := command.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), "go %v: %s", args, output)
+		}
+	})
+})
+
+var _ = Describe("grouped type declarations", func() {
+	It("applies the group's annotation to its structs, skipping other types", func() {
+		dir := writeFixturePackage(map[string]string{
+			"thing.go": `package things
+
+// codegen-partial:builder,matcher
+type (
+	Thing struct {
+		Name   string ` + "`json:\"name\"`" + `
+		Status Status ` + "`json:\"status\"`" + `
+	}
+
+	Status string
+
+	Widget struct {
+		ID string ` + "`json:\"id\"`" + `
+	}
+)
+`,
+		})
+
+		Expect(runGen(dir, nil)).To(Succeed())
+
+		source := readFixtureFile(dir, "thing.genpartial.go")
+		Expect(source).To(ContainSubstring("var ThingBuilder"))
+		Expect(source).To(ContainSubstring("var WidgetBuilder"))
+		Expect(source).NotTo(ContainSubstring("StatusBuilder"))
+
+		build := exec.Command("go", "build", ".")
+		build.Dir = dir
+		output, err := build.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+	})
+
+	It("still rejects annotations on types that aren't structs", func() {
+		dir := writeFixturePackage(map[string]string{
+			"thing.go": "package things\n\n// codegen-partial:builder\ntype Status string\n",
+		})
+
+		Expect(runGen(dir, nil)).To(MatchError(ContainSubstring("could not find struct for name Status")))
+	})
+})
+
+var _ = Describe("file_per_type", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{
+			"thing.go": thingSource + `
+// codegen-partial:builder,matcher(testonly)
+type APIKeyRole struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+		})
+	})
+
+	DescribeTable("naming generated files",
+		func(filePerType bool, expected ...string) {
+			project = defaultProjectConfig()
+			project.FilePerType = filePerType
+
+			Expect(runGen(dir, nil)).To(Succeed())
+
+			genFiles, err := filepath.Glob(filepath.Join(dir, "*.genpartial*.go"))
+			Expect(err).NotTo(HaveOccurred())
+
+			filenames := []string{}
+			for _, genFile := range genFiles {
+				filenames = append(filenames, filepath.Base(genFile))
+			}
+			Expect(filenames).To(ConsistOf(expected))
+
+			if filePerType {
+				Expect(readFixtureFile(dir, "thing.genpartial.go")).NotTo(ContainSubstring("APIKeyRole"))
+				Expect(readFixtureFile(dir, "api_key_role.genpartial.go")).To(ContainSubstring("var APIKeyRoleBuilder"))
+				Expect(readFixtureFile(dir, "api_key_role.genpartial_test.go")).To(ContainSubstring("var APIKeyRoleMatcher"))
+			}
+		},
+		Entry("after each source file by default", false, "thing.genpartial.go", "thing.genpartial_test.go"),
+		Entry("after each type", true, "thing.genpartial.go", "api_key_role.genpartial.go", "api_key_role.genpartial_test.go"),
+	)
+
+	It("removes the files of the other layout when switching", func() {
+		Expect(runGen(dir, nil)).To(Succeed())
+
+		project = defaultProjectConfig()
+		project.FilePerType = true
+		Expect(runGen(dir, nil)).To(Succeed())
+
+		_, err := os.Stat(filepath.Join(dir, "thing.genpartial_test.go"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
+
+var _ = DescribeTable("snakeCaseFor",
+	func(name, expected string) {
+		Expect(snakeCaseFor(name)).To(Equal(expected))
+	},
+	Entry("a single word", "Incident", "incident"),
+	Entry("several words", "IncidentRole", "incident_role"),
+	Entry("an initialism", "APIKeyRole", "api_key_role"),
+	Entry("a trailing initialism", "IncidentID", "incident_id"),
+)
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// outDirFor returns the directory to generate the package in dir into, which is the
+// package itself unless we were given an -out-dir, taken relative to each package.
+func outDirFor(dir string, opts generateOptions) string {
+	if opts.OutDir == "" {
+		return dir
+	}
+	if filepath.IsAbs(opts.OutDir) {
+		return opts.OutDir
+	}
+
+	return filepath.Join(dir, opts.OutDir)
+}
+
+// outPackageFor returns the name of the package we generate into an -out-dir, which
+// defaults to the name of the directory.
+func outPackageFor(outDir string, opts generateOptions) (string, error) {
+	name := opts.OutPackage
+	if name == "" {
+		name = filepath.Base(outDir)
+	}
+	if !token.IsIdentifier(name) || strings.HasSuffix(name, "_test") {
+		return "", errors.New(fmt.Sprintf("cannot generate into package %q, pass -out-package with a valid package name", name))
+	}
+
+	return name, nil
+}
+
+// retargetToOutDir moves the targets of the package in dir to generate into another
+// package in outDir, such as modelstest, keeping matchers and their gomega dependencies
+// out of production code. The annotated types become external to the generated code,
+// which imports the package they're declared in as for types in partial.types.yaml.
+//
+// It returns the import path of the package in outDir, which needn't exist yet.
+func retargetToOutDir(dir, outDir, outPackage string, targets []*codegenTarget) (string, error) {
+	importPath, err := importPathFor(dir)
+	if err != nil {
+		return "", err
+	}
+
+	relative, err := filepath.Rel(dir, outDir)
+	if err != nil {
+		return "", err
+	}
+	if relative == "." {
+		return "", errors.New("-out-dir must be a different directory to the package")
+	}
+	outputPath := path.Join(importPath, filepath.ToSlash(relative))
+
+	matcherTypes := map[string]bool{}
+	for _, target := range targets {
+		if target.ImportPath == "" {
+			if !token.IsExported(target.Name) {
+				return "", errors.New(fmt.Sprintf("cannot generate for %s into %s, as it is unexported", target.Name, outPackage))
+			}
+
+			target.ImportPath = importPath
+			target.ImportName = target.Package
+
+			// Nested matchers reference each other by their qualified type, as that's
+			// how fields refer to them once generated elsewhere.
+			if tag, ok := target.Tag("matcher"); ok {
+				matcherTypes[target.QualifiedName()] = tag.TestOnly
+			}
+		}
+
+		target.Package = outPackage
+		target.Filename = filepath.Join(outDir, filepath.Base(target.Filename))
+	}
+	for _, target := range targets {
+		target.MatcherTypes = matcherTypes
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return "", err
+	}
+
+	return outputPath, nil
+}
+
+// importPathFor asks the go tool for the import path of the package in dir.
+func importPathFor(dir string) (string, error) {
+	cmd := exec.Command("go", "list", "-f", "{{.ImportPath}}", ".")
+	cmd.Dir, cmd.Stderr = dir, os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("finding import path of %s", dir))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
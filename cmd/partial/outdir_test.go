@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("-out-dir", func() {
+	DescribeTable("generating into a separate package",
+		func(args []string, outDir, packageClause string) {
+			dir := writeFixturePackage(map[string]string{"thing.go": thingSource})
+
+			Expect(runGen(dir, args)).To(Succeed())
+
+			source := readFixtureFile(filepath.Join(dir, outDir), "thing.genpartial.go")
+			Expect(source).To(ContainSubstring(packageClause))
+			Expect(source).To(MatchRegexp(`\tthings "github.com/incident-io/partial/cmd/partial/testdata/fixture\d+"\n`))
+			Expect(source).To(ContainSubstring("func(opts ...partial.Option[things.Thing]) partial.Partial[things.Thing]"))
+
+			_, err := os.Stat(filepath.Join(dir, "thing.genpartial.go"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+
+			build := exec.Command("go", "vet", "./"+outDir)
+			build.Dir = dir
+			output, err := build.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(output))
+		},
+		Entry("named after the directory", []string{"-out-dir", "thingstest"}, "thingstest", "package thingstest\n"),
+		Entry("with a package name of its own", []string{"-out-dir", "gen/things-test", "-out-package", "thingstest"}, "gen/things-test", "package thingstest\n"),
+	)
+
+	DescribeTable("failing for unusable directories",
+		func(args []string, message string) {
+			dir := writeFixturePackage(map[string]string{"thing.go": thingSource})
+
+			Expect(runGen(dir, args)).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("a directory that isn't a package name", []string{"-out-dir", "things-test"}, `cannot generate into package "things-test", pass -out-package with a valid package name`),
+		Entry("a test package", []string{"-out-dir", "gen", "-out-package", "things_test"}, `cannot generate into package "things_test"`),
+	)
+})
+
+var _ = Describe("-out-dir with unexported declarations", func() {
+	DescribeTable("failing clearly for what the other package can't reference",
+		func(source, message string) {
+			dir := writeFixturePackage(map[string]string{"thing.go": source})
+
+			Expect(runGen(dir, []string{"-out-dir", "thingstest"})).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("an unexported type", `package things
+
+// codegen-partial:builder
+type thing struct {
+	Name string
+}
+`, "cannot generate for thing into thingstest, as it is unexported"),
+		Entry("an unexported field with partial options", `package things
+
+// codegen-partial:builder
+type Thing struct {
+	secret string `+"`partial:\"encrypted\"`"+`
+}
+`, "field secret on type things.Thing is unexported, so can't be set from package thingstest: export it, or remove its partial options"),
+		Entry("an unexported matcher factory", `package things
+
+import "github.com/onsi/gomega/types"
+
+// codegen-partial:matcher
+type Thing struct {
+	Email string // partial:matcher-factory=matchEmail
+}
+
+func matchEmail(email string) types.GomegaMatcher { return nil }
+`, "field Email on type things.Thing has matcher-factory=matchEmail, which is unexported so can't be called from package thingstest"),
+		Entry("a field of an unexported type", `package things
+
+// codegen-partial:builder
+type Thing struct {
+	Status status
+}
+
+type status string
+`, "field Status on type Thing: type things.status is unexported"),
+	)
+
+	It("qualifies the source type and skips unexported fields without options", func() {
+		dir := writeFixturePackage(map[string]string{"thing.go": `package things
+
+// codegen-partial:builder,matcher
+type Thing struct {
+	Name   string
+	secret string
+}
+`})
+
+		Expect(runGen(dir, []string{"-out-dir", "thingstest"})).To(Succeed())
+
+		source := readFixtureFile(filepath.Join(dir, "thingstest"), "thing.genpartial.go")
+		Expect(source).To(ContainSubstring("func (b ThingBuilderFunc) Name(value string) partial.Option[things.Thing]"))
+		Expect(source).NotTo(ContainSubstring("secret"))
+
+		build := exec.Command("go", "vet", "./thingstest")
+		build.Dir = dir
+		output, err := build.CombinedOutput()
+		Expect(err).NotTo(HaveOccurred(), string(output))
+	})
+})
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// prunedType lists the generated identifiers for an annotated type that nothing outside
+// of generated code references.
+type prunedType struct {
+	Package   string
+	Name      string   // Incident, or the generated file if we can't find the type
+	Generated int      // how many identifiers were generated for the type
+	Unused    []string // IncidentBuilderFunc.ID, IncidentMatcher
+}
+
+// Unreferenced is true if none of the generated identifiers are used, in which case the
+// annotation can probably be removed.
+func (p *prunedType) Unreferenced() bool {
+	return len(p.Unused) == p.Generated
+}
+
+// runPruneReport loads the packages matching the patterns (./... by default) and reports
+// every generated builder, matcher or field option that is never referenced, so we can
+// trim annotations for types that no longer need them.
+func runPruneReport(dir string, args []string) error {
+	flags := flag.NewFlagSet("prune-report", flag.ExitOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	report, err := pruneReport(dir, patterns)
+	if err != nil {
+		return err
+	}
+
+	for _, pruned := range report {
+		if pruned.Unreferenced() {
+			fmt.Printf("%s.%s: nothing generated is used, consider removing its annotation\n", pruned.Package, pruned.Name)
+			continue
+		}
+
+		fmt.Printf("%s.%s: %d of %d generated identifiers are unused\n", pruned.Package, pruned.Name, len(pruned.Unused), pruned.Generated)
+		for _, name := range pruned.Unused {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+
+	return nil
+}
+
+func pruneReport(dir string, patterns []string) ([]*prunedType, error) {
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading packages")
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, errors.New("packages contain errors")
+	}
+
+	// Packages loaded with their tests appear more than once, each with their own copy of
+	// the objects, so we identify objects by where they were declared.
+	var (
+		generated = map[token.Position]*generatedIdentifier{}
+		used      = map[token.Position]bool{}
+	)
+	for _, pkg := range pkgs {
+		for ident, obj := range pkg.TypesInfo.Defs {
+			position := pkg.Fset.Position(ident.Pos())
+			if obj == nil || !isGenFile(position.Filename) {
+				continue
+			}
+
+			if name, ok := generatedIdentifierNameFor(pkg.Fset, obj); ok {
+				generated[position] = &generatedIdentifier{
+					Package:  pkg.Types.Name(),
+					Filename: position.Filename,
+					Name:     name,
+				}
+			}
+		}
+
+		for ident, obj := range pkg.TypesInfo.Uses {
+			if isGenFile(pkg.Fset.Position(ident.Pos()).Filename) {
+				continue
+			}
+
+			used[pkg.Fset.Position(obj.Pos())] = true
+		}
+	}
+
+	report := map[string]*prunedType{}
+	targetsByDir := map[string][]*codegenTarget{}
+	for position, identifier := range generated {
+		targetDir := filepath.Dir(identifier.Filename)
+		targets, ok := targetsByDir[targetDir]
+		if !ok {
+			targets, err = findTargets(targetDir)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("finding targets in %s", targetDir))
+			}
+
+			targetsByDir[targetDir] = targets
+		}
+
+		name := filepath.Base(identifier.Filename)
+		if target := targetFor(identifier.Name, targets); target != nil {
+			name = target.Name
+		}
+
+		key := fmt.Sprintf("%s.%s", identifier.Package, name)
+		pruned, ok := report[key]
+		if !ok {
+			pruned = &prunedType{Package: identifier.Package, Name: name, Unused: []string{}}
+			report[key] = pruned
+		}
+
+		pruned.Generated++
+		if !used[position] {
+			pruned.Unused = append(pruned.Unused, identifier.Name)
+		}
+	}
+
+	keys := []string{}
+	for key, pruned := range report {
+		if len(pruned.Unused) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	prunedTypes := []*prunedType{}
+	for _, key := range keys {
+		sort.Strings(report[key].Unused)
+		prunedTypes = append(prunedTypes, report[key])
+	}
+
+	return prunedTypes, nil
+}
+
+type generatedIdentifier struct {
+	Package  string
+	Filename string
+	Name     string // IncidentBuilderFunc.ID
+}
+
+// generatedIdentifierNameFor returns the name of a package level func or var, or the
+// receiver and name of a method on a generated type. Types are only ever used through
+// those, and methods generated on the source type (such as Matcher) are called through
+// generic interfaces, so reporting them would just be noise. Anything else, such as
+// parameters and local variables, is not reported.
+func generatedIdentifierNameFor(fset *token.FileSet, obj types.Object) (string, bool) {
+	if _, ok := obj.(*types.TypeName); ok || obj.Name() == "_" || obj.Name() == "init" {
+		return "", false
+	}
+
+	if fn, ok := obj.(*types.Func); ok {
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+			recvType := recv.Type()
+			if pointer, ok := recvType.(*types.Pointer); ok {
+				recvType = pointer.Elem()
+			}
+			if named, ok := recvType.(*types.Named); ok && isGenFile(fset.Position(named.Obj().Pos()).Filename) {
+				return fmt.Sprintf("%s.%s", named.Obj().Name(), fn.Name()), true
+			}
+
+			return "", false
+		}
+	}
+
+	if obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope() {
+		return obj.Name(), true
+	}
+
+	return "", false
+}
+
+// targetFor finds the type that an identifier was generated for, from the identifiers
+// being prefixed with the name of the type, or its untitled name if unexported. We pick
+// the longest matching name, so IncidentRoleBuilder belongs to IncidentRole rather than
+// Incident.
+func targetFor(identifierName string, targets []*codegenTarget) *codegenTarget {
+	untitle := templateFuncs["untitle"].(func(string) string)
+
+	var match *codegenTarget
+	for _, target := range targets {
+		if !strings.HasPrefix(identifierName, target.Name) && !strings.HasPrefix(identifierName, untitle(target.Name)) {
+			continue
+		}
+
+		if match == nil || len(target.Name) > len(match.Name) {
+			match = target
+		}
+	}
+
+	return match
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("prune-report", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{
+			"thing.go": thingSource + `
+// codegen-partial:builder
+type Widget struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+			"usage.go": `package things
+
+var named = ThingBuilder(ThingBuilder.Name("name"))
+`,
+		})
+
+		Expect(runGen(dir, []string{"-only", "builder"})).To(Succeed())
+	})
+
+	DescribeTable("reporting generated identifiers nothing references",
+		func(args []string) {
+			output, err := captureStdout(func() error { return runPruneReport(dir, args) })
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output).To(ContainSubstring("things.Widget: nothing generated is used, consider removing its annotation\n"))
+			Expect(output).To(MatchRegexp(`things\.Thing: \d+ of \d+ generated identifiers are unused\n`))
+			Expect(output).To(ContainSubstring("  ThingBuilderFunc.ID\n"))
+			Expect(output).NotTo(ContainSubstring("  ThingBuilderFunc.Name\n"))
+			Expect(output).NotTo(ContainSubstring("  ThingBuilder\n"))
+		},
+		Entry("in every package by default", nil),
+		Entry("in the packages matching the patterns", []string{"."}),
+	)
+})
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// resolveFieldTypes type checks the package in dir, recording the type of every field of
+// each annotated target as it should be written in generated code. Unlike the syntax of
+// the field, this resolves dot imports, renamed imports and aliases, qualifying each type
+// with the package it actually lives in.
+//
+// Code is generated into the package in dir, unless outputPath names another package,
+// as when generating into an -out-dir.
+//
+// Fields we fail to resolve, such as those referencing code that doesn't compile, keep
+// the type as written in the source.
+func resolveFieldTypes(dir, outputPath string, targets []*codegenTarget) error {
+	// Type checking is the slowest part of generating, so is skipped where there's
+	// nothing to generate, which is most packages when run over ./...
+	if len(targets) == 0 {
+		return nil
+	}
+
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: true,
+		// Generated files may be stale or missing what the rest of the package expects,
+		// and we're about to replace them anyway, so we check the package without them.
+		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+			if isGenFile(filename) {
+				return parser.ParseFile(fset, filename, src, parser.PackageClauseOnly)
+			}
+
+			return parser.ParseFile(fset, filename, src, parser.ParseComments)
+		},
+	}
+	// Types configured in partial.types.yaml are generated here, but declared elsewhere,
+	// so we load their packages too.
+	patterns := []string{"."}
+	for _, target := range targets {
+		if target.ImportPath != "" && !containsString(patterns, target.ImportPath) {
+			patterns = append(patterns, target.ImportPath)
+		}
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return errors.Wrap(err, "loading package")
+	}
+
+	outputTypes := types.NewPackage(outputPath, "")
+	if outputPath == "" {
+		localPkg := localPackageFor(pkgs, dir)
+		if localPkg == nil {
+			return errors.New(fmt.Sprintf("could not find package in %s", dir))
+		}
+
+		outputTypes = localPkg.Types
+	}
+
+	// Every target in the package shares the same import names, so types from different
+	// packages with the same name can't collide when generated into the same file.
+	imports := &fieldImports{pathsByName: map[string]string{}, namesByPath: map[string]string{}}
+	for _, runtimeImport := range runtimeImports {
+		imports.reserve(importNameFor(runtimeImport.Name), runtimeImport.Path)
+	}
+
+	for _, target := range targets {
+		target.OutputImportPath = outputTypes.Path()
+
+		// Code for external types is generated into the local package, so anything
+		// declared alongside them needs qualifying, even if it's in a sibling file.
+		outputPkg, structType := outputTypes, (*types.Struct)(nil)
+		if target.ImportPath != "" {
+			var externalPkg *types.Package
+			externalPkg, structType = externalStructFor(pkgs, target)
+			if structType != nil {
+				target.ImportName = imports.nameFor(externalPkg)
+			}
+		} else {
+			var pkg *packages.Package
+			pkg, structType = resolvedStructFor(pkgs, target)
+			if pkg != nil {
+				outputPkg = pkg.Types
+			}
+		}
+		if structType == nil {
+			continue
+		}
+
+		qualifier := func(other *types.Package) string {
+			if other.Path() == outputPkg.Path() {
+				return ""
+			}
+
+			return imports.nameFor(other)
+		}
+
+		target.FieldTypes, target.FieldUnderlyingTypes = map[string]string{}, map[string]string{}
+		target.FieldImports = imports.pathsByName
+		for idx := 0; idx < structType.NumFields(); idx++ {
+			field := structType.Field(idx)
+			if field.Embedded() || !isValidType(field.Type()) || referencesUnexported(field.Type(), outputPkg) {
+				continue
+			}
+
+			target.FieldTypes[field.Name()] = types.TypeString(field.Type(), qualifier)
+
+			// Aliases are already resolved to the type they stand for, but defined types
+			// such as type Severity string are named in their own right
+			if underlying := field.Type().Underlying(); underlying != field.Type() && !referencesUnexported(underlying, outputPkg) {
+				switch underlying.(type) {
+				case *types.Struct, *types.Interface:
+				default:
+					target.FieldUnderlyingTypes[field.Name()] = types.TypeString(underlying, qualifier)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// localPackageFor finds the package declared in dir, ignoring its tests.
+func localPackageFor(pkgs []*packages.Package, dir string) *packages.Package {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || pkg.ID != pkg.PkgPath || len(pkg.GoFiles) == 0 {
+			continue
+		}
+
+		if filepath.Dir(pkg.GoFiles[0]) == dir {
+			return pkg
+		}
+	}
+
+	return nil
+}
+
+// externalStructFor finds the type checked struct for a target configured in
+// partial.types.yaml, from the package it's declared in.
+func externalStructFor(pkgs []*packages.Package, target *codegenTarget) (*types.Package, *types.Struct) {
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || pkg.PkgPath != target.ImportPath || pkg.ID != pkg.PkgPath {
+			continue
+		}
+
+		typeName, ok := pkg.Types.Scope().Lookup(target.Name).(*types.TypeName)
+		if !ok {
+			return nil, nil
+		}
+
+		if structType, ok := typeName.Type().Underlying().(*types.Struct); ok {
+			return pkg.Types, structType
+		}
+	}
+
+	return nil, nil
+}
+
+// referencesUnexported is true if the type is built from any unexported type declared
+// outside of pkg, which code generated into pkg can't name.
+func referencesUnexported(typ types.Type, pkg *types.Package) bool {
+	switch typ := typ.(type) {
+	case *types.Named:
+		obj := typ.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() != pkg.Path() && !obj.Exported() {
+			return true
+		}
+
+		if args := typ.TypeArgs(); args != nil {
+			for idx := 0; idx < args.Len(); idx++ {
+				if referencesUnexported(args.At(idx), pkg) {
+					return true
+				}
+			}
+		}
+	case *types.Pointer:
+		return referencesUnexported(typ.Elem(), pkg)
+	case *types.Slice:
+		return referencesUnexported(typ.Elem(), pkg)
+	case *types.Array:
+		return referencesUnexported(typ.Elem(), pkg)
+	case *types.Map:
+		return referencesUnexported(typ.Key(), pkg) || referencesUnexported(typ.Elem(), pkg)
+	}
+
+	return false
+}
+
+// resolvedStructFor finds the type checked struct for the target, from whichever of the
+// loaded packages contains the file it was declared in. Targets declared in tests are
+// only found in the test variants of the package.
+func resolvedStructFor(pkgs []*packages.Package, target *codegenTarget) (*packages.Package, *types.Struct) {
+	filename, err := filepath.Abs(target.Filename)
+	if err != nil {
+		return nil, nil
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || !containsString(pkg.GoFiles, filename) {
+			continue
+		}
+
+		typeName, ok := pkg.Types.Scope().Lookup(target.Name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+
+		if structType, ok := typeName.Type().Underlying().(*types.Struct); ok {
+			return pkg, structType
+		}
+	}
+
+	return nil, nil
+}
+
+// isValidType is false if the type, or any type it is built from, failed to type check.
+func isValidType(typ types.Type) bool {
+	switch typ := typ.(type) {
+	case *types.Basic:
+		return typ.Kind() != types.Invalid
+	case *types.Pointer:
+		return isValidType(typ.Elem())
+	case *types.Slice:
+		return isValidType(typ.Elem())
+	case *types.Array:
+		return isValidType(typ.Elem())
+	case *types.Map:
+		return isValidType(typ.Key()) && isValidType(typ.Elem())
+	}
+
+	return true
+}
+
+// fieldImports names the packages referenced by field types, renaming any whose name is
+// already taken by another package.
+type fieldImports struct {
+	pathsByName map[string]string // null: gopkg.in/guregu/null.v3
+	namesByPath map[string]string
+}
+
+func (i *fieldImports) reserve(name, path string) {
+	i.pathsByName[name] = path
+	i.namesByPath[path] = name
+}
+
+func (i *fieldImports) nameFor(pkg *types.Package) string {
+	if name, ok := i.namesByPath[pkg.Path()]; ok {
+		return name
+	}
+
+	name := pkg.Name()
+	for suffix := 2; i.pathsByName[name] != ""; suffix++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), suffix)
+	}
+
+	i.reserve(name, pkg.Path())
+
+	return name
+}
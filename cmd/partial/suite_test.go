@@ -0,0 +1,19 @@
+package main
+
+import (
+	"log"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestGenerator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Generator Suite")
+}
+
+var _ = BeforeSuite(func() {
+	// The generator logs each file it writes, which would drown out the specs
+	log.SetOutput(GinkgoWriter)
+})
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// templateOverrides replace the built-in template for a tag, loaded from the directory
+// given by -templates.
+var templateOverrides = map[string]*template.Template{}
+
+// loadTemplateOverrides loads a <tag>.tmpl file from dir for each tag whose template
+// should be replaced, such as builder.tmpl. Each is parsed alongside the built-in
+// template, so it can include the original with {{ template "builderTemplate" . }} and
+// add to it rather than replacing it wholesale.
+func loadTemplateOverrides(dir string) error {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+	if len(filenames) == 0 {
+		return errors.New(fmt.Sprintf("no *.tmpl files in %s", dir))
+	}
+
+	builtins := map[string]*template.Template{
+		"builder":     builderTemplate,
+		"matcher":     matcherTemplate,
+		"diff":        diffTemplate,
+		"event":       eventTemplate,
+		"constructor": constructorTemplate,
+		"entry":       entryTemplate,
+		"options":     optionsTemplate,
+		"flags":       flagsTemplate,
+		"params":      paramsTemplate,
+	}
+
+	for _, filename := range filenames {
+		tag := strings.TrimSuffix(filepath.Base(filename), ".tmpl")
+		builtin, ok := builtins[tag]
+		if !ok {
+			tags := []string{}
+			for name := range builtins {
+				tags = append(tags, name)
+			}
+			sort.Strings(tags)
+
+			return errors.New(fmt.Sprintf("template %s is not for a codegen tag, expected one of: %s", filename, strings.Join(tags, ", ")))
+		}
+
+		text, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+
+		override, err := builtin.Clone()
+		if err != nil {
+			return err
+		}
+
+		override, err = override.New(tag).Parse(string(text))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("parsing template %s", filename))
+		}
+
+		templateOverrides[tag] = override
+	}
+
+	return nil
+}
+
+// templateFor returns the template to generate the tag with, which is the built-in
+// unless it was overridden.
+func templateFor(tag string, builtin *template.Template) *template.Template {
+	if override, ok := templateOverrides[tag]; ok {
+		return override
+	}
+
+	return builtin
+}
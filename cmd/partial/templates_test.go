@@ -0,0 +1,57 @@
+package main
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("-templates", func() {
+	DescribeTable("overriding the built-in templates",
+		func(templates map[string]string, contains, excludes []string) {
+			files := map[string]string{"thing.go": thingSource}
+			for filename, text := range templates {
+				files["templates/"+filename] = text
+			}
+			dir := writeFixturePackage(files)
+
+			Expect(runGen(dir, []string{"-templates", "templates"})).To(Succeed())
+
+			source := readFixtureFile(dir, "thing.genpartial.go")
+			for _, text := range contains {
+				Expect(source).To(ContainSubstring(text))
+			}
+			for _, text := range excludes {
+				Expect(source).NotTo(ContainSubstring(text))
+			}
+		},
+		Entry("extending the original",
+			map[string]string{"builder.tmpl": `{{ template "builderTemplate" . }}
+// {{ .TypeName }}BuilderVersion is added by our own template.
+const {{ .TypeName }}BuilderVersion = 2
+`},
+			[]string{"var ThingBuilder", "const ThingBuilderVersion = 2", "var ThingMatcher"},
+			nil,
+		),
+		Entry("replacing it wholesale",
+			map[string]string{"matcher.tmpl": "func {{ .TypeName }}Matches() bool { return true }\n"},
+			[]string{"var ThingBuilder", "func ThingMatches() bool"},
+			[]string{"ThingMatcher"},
+		),
+	)
+
+	DescribeTable("failing for unusable templates",
+		func(templates map[string]string, message string) {
+			files := map[string]string{"thing.go": thingSource, "templates/README": "templates"}
+			for filename, text := range templates {
+				files["templates/"+filename] = text
+			}
+			dir := writeFixturePackage(files)
+
+			Expect(runGen(dir, []string{"-templates", "templates"})).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("no templates", nil, "loading templates: no *.tmpl files in "),
+		Entry("a template for a tag that doesn't exist", map[string]string{"widget.tmpl": ""}, "is not for a codegen tag, expected one of: builder, constructor, diff"),
+		Entry("a template that doesn't parse", map[string]string{"builder.tmpl": "{{ .TypeName "}, "parsing template "),
+	)
+})
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// unresolvedGenFiles are the problems type checking found in generated files, such as an
+// identifier imported from the wrong package or not imported at all.
+type unresolvedGenFiles []string
+
+func (f unresolvedGenFiles) Error() string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "%d problem(s) type checking generated files:", len(f))
+	for _, problem := range f {
+		fmt.Fprintf(&report, "\n  %s", problem)
+	}
+
+	return report.String()
+}
+
+// verifyGenFiles type checks the package in dir now its generated files are written, so
+// an import that resolved to the wrong package, or to nothing at all, fails generation
+// rather than whoever next builds the package. Problems in files we didn't generate are
+// left for the build to report.
+func verifyGenFiles(dir string, generated map[string]bool) error {
+	generatedPaths := map[string]bool{}
+	for filename := range generated {
+		absFilename, err := filepath.Abs(filename)
+		if err != nil {
+			return err
+		}
+
+		generatedPaths[absFilename] = true
+	}
+
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return errors.Wrap(err, "loading package")
+	}
+
+	// Test variants of the package check the same files again, so report each once
+	problems, seen := unresolvedGenFiles{}, map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, pkgErr := range pkg.Errors {
+			filename, _, _ := strings.Cut(pkgErr.Pos, ":")
+			if absFilename, err := filepath.Abs(filename); err != nil || !generatedPaths[absFilename] {
+				continue
+			}
+
+			if problem := pkgErr.Error(); !seen[problem] {
+				seen[problem] = true
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+
+	return nil
+}
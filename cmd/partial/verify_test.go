@@ -0,0 +1,35 @@
+package main
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("-verify-imports", func() {
+	DescribeTable("type checking generated files",
+		func(files map[string]string, args []string, message string) {
+			files["thing.go"] = thingSource
+			dir := writeFixturePackage(files)
+
+			err := runGen(dir, append([]string{"-verify-imports"}, args...))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(BeAssignableToTypeOf(unresolvedGenFiles{}))
+				Expect(err).To(MatchError(ContainSubstring("1 problem(s) type checking generated files:")))
+				Expect(err).To(MatchError(MatchRegexp(message)))
+			}
+		},
+		Entry("passes for the built-in templates", map[string]string{}, nil, ""),
+		Entry("fails for identifiers that don't resolve",
+			map[string]string{"templates/builder.tmpl": "{{ template \"builderTemplate\" . }}\nvar {{ .TypeName }}Default = missingHelper()\n"},
+			[]string{"-templates", "templates"},
+			`thing\.genpartial\.go:\d+:\d+: undefined: missingHelper`,
+		),
+		Entry("ignores problems in files it didn't generate",
+			map[string]string{"broken.go": "package things\n\nvar broken int = \"broken\"\n"},
+			nil, "",
+		),
+	)
+})
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/incident-io/partial"
+	"github.com/pkg/errors"
+)
+
+// runCheckVersion reports every generated file in the directory that was produced by a
+// generator incompatible with the current runtime, so CI can ask for a re-run of go
+// generate before anything fails to compile.
+func runCheckVersion(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	failures := []string{}
+	for _, entry := range entries {
+		if !isGenFile(entry.Name()) {
+			continue
+		}
+
+		filename := path.Join(dir, entry.Name())
+		version, err := generatorVersionFor(filename)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("reading generator version from %s", filename))
+		}
+
+		if err := partial.CheckGeneratorVersion(entry.Name(), version); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
+
+// generatorVersionFor reads the generator version from the header of a generated file.
+// Files generated before we recorded the version are treated as version 0.
+func generatorVersionFor(filename string) (int, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+
+		if strings.HasPrefix(line, generatorVersionPrefix) {
+			return strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, generatorVersionPrefix)))
+		}
+	}
+
+	return 0, scanner.Err()
+}
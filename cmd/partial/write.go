@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/imports"
+)
+
+// writeGenFile formats the generated source and swaps it into place atomically, so an
+// interrupted run never leaves a half-written file behind. Any existing file is only
+// replaced once the new one is complete.
+func writeGenFile(filename string, source []byte) error {
+	formatted, err := formatGenSource(filename, source)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(filename, formatted)
+}
+
+// writeFileAtomically writes data to a temporary file alongside filename before renaming
+// it into place, so an interrupted write never leaves a truncated file behind.
+func writeFileAtomically(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(path.Dir(filename), "."+path.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
+
+// formatGenSource adds any imports the generated source is missing, such as those for
+// field types we couldn't resolve, and formats it. This happens in process, so we need
+// neither goimports nor gofmt installed, and never touch any file but our own. Imports
+// are resolved as if the source were already at filename.
+func formatGenSource(filename string, source []byte) ([]byte, error) {
+	formatted, err := imports.Process(filename, source, &imports.Options{
+		Comments:  true,
+		TabIndent: true,
+		TabWidth:  8,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "formatting")
+	}
+
+	return formatted, nil
+}
+
+// removeStaleGenFiles removes every generated file in the directory that we didn't just
+// write, such as those for types that are no longer annotated.
+func removeStaleGenFiles(dir string, generated map[string]bool) error {
+	staleFiles, err := staleGenFilesFor(dir, generated)
+	if err != nil {
+		return err
+	}
+
+	for _, sourceFile := range staleFiles {
+		if err := os.Remove(sourceFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// staleGenFilesFor lists every generated file in the directory that isn't one of those
+// we generated.
+func staleGenFilesFor(dir string, generated map[string]bool) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		// An -out-dir we've not generated into yet has nothing stale in it
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	staleFiles := []string{}
+	for _, entry := range entries {
+		sourceFile := path.Join(dir, entry.Name())
+		if isGenFile(sourceFile) && !generated[sourceFile] {
+			staleFiles = append(staleFiles, sourceFile)
+		}
+	}
+
+	return staleFiles, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("formatGenSource", func() {
+	DescribeTable("fixing imports and formatting in process",
+		func(source, expected string) {
+			formatted, err := formatGenSource("thing.genpartial.go", []byte(source))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(formatted)).To(Equal(expected))
+		},
+		Entry("formatting",
+			"package things\nfunc  lower(s string)string{\nreturn s}\n",
+			"package things\n\nfunc lower(s string) string {\n\treturn s\n}\n",
+		),
+		Entry("adding missing standard library imports",
+			"package things\n\nvar lower = strings.ToLower\n",
+			"package things\n\nimport \"strings\"\n\nvar lower = strings.ToLower\n",
+		),
+		Entry("removing unused imports",
+			"package things\n\nimport \"strings\"\n\nvar lower = 1\n",
+			"package things\n\nvar lower = 1\n",
+		),
+	)
+
+	It("fails for source that doesn't parse", func() {
+		_, err := formatGenSource("thing.genpartial.go", []byte("package things\n\nfunc {\n"))
+		Expect(err).To(MatchError(ContainSubstring("formatting: ")))
+	})
+})
+
+var _ = Describe("writing generated files", func() {
+	var dir string
+
+	// Formatted as nobody would, to check we only ever format our own files
+	const unformattedSource = "package things\n\n// codegen-partial:builder\ntype Thing struct {\n\tName   string `json:\"name\"`\n}\nfunc  unformatted( ) {}\n"
+
+	BeforeEach(func() {
+		dir = writeFixturePackage(map[string]string{"thing.go": unformattedSource})
+	})
+
+	It("formats without goimports or gofmt on the PATH, touching no other file", func() {
+		path := os.Getenv("PATH")
+		defer os.Setenv("PATH", path)
+		Expect(os.Setenv("PATH", "")).To(Succeed())
+
+		Expect(runGen(dir, nil)).To(Succeed())
+
+		source := readFixtureFile(dir, "thing.genpartial.go")
+		formatted, err := format.Source([]byte(source))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(source).To(Equal(string(formatted)))
+
+		Expect(readFixtureFile(dir, "thing.go")).To(Equal(unformattedSource))
+
+		tmpFiles, err := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tmpFiles).To(BeEmpty())
+	})
+})
@@ -0,0 +1,51 @@
+package partial
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ApplyConcurrent applies each partial to the base at the same index, using at most
+// workers goroutines, and returns the results in the same order. This is for scripts
+// patching many rows in memory before writing them in bulk, where applying one at a time
+// leaves most cores idle.
+//
+// A workers of zero or less uses one per CPU. The partials and bases must be the same
+// length.
+func ApplyConcurrent[T any](ps []Partial[T], bases []T, workers int) []*T {
+	if len(ps) != len(bases) {
+		panic(fmt.Sprintf("partial: ApplyConcurrent given %d partials, but %d bases", len(ps), len(bases)))
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(ps) {
+		workers = len(ps)
+	}
+
+	var (
+		results = make([]*T, len(ps))
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+	)
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Each result is written to its own index, so needs no locking
+			for idx := range indexes {
+				results[idx] = ps[idx].Apply(bases[idx])
+			}
+		}()
+	}
+
+	for idx := range ps {
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
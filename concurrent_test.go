@@ -0,0 +1,48 @@
+package partial_test
+
+import (
+	"fmt"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ApplyConcurrent", func() {
+	It("applies each partial to the base at the same index, preserving order", func() {
+		models, bases := []partial.Partial[test.Organisation]{}, []test.Organisation{}
+		for idx := 0; idx < 100; idx++ {
+			models = append(models, test.OrganisationBuilder(
+				test.OrganisationBuilder.Name(fmt.Sprintf("org-%d", idx)),
+			))
+			bases = append(bases, test.Organisation{ID: fmt.Sprintf("id-%d", idx)})
+		}
+
+		results := partial.ApplyConcurrent(models, bases, 4)
+
+		Expect(results).To(HaveLen(100))
+		for idx, result := range results {
+			Expect(result.ID).To(Equal(fmt.Sprintf("id-%d", idx)))
+			Expect(result.Name).To(Equal(fmt.Sprintf("org-%d", idx)))
+		}
+	})
+
+	It("uses a worker per CPU when given none", func() {
+		results := partial.ApplyConcurrent(
+			[]partial.Partial[test.Organisation]{test.OrganisationBuilder(test.OrganisationBuilder.Name("My Org"))},
+			[]test.Organisation{{ID: "id"}},
+			0,
+		)
+
+		Expect(results).To(ConsistOf(PointTo(Equal(test.Organisation{ID: "id", Name: "My Org"}))))
+	})
+
+	It("panics when given a different number of partials and bases", func() {
+		Expect(func() {
+			partial.ApplyConcurrent([]partial.Partial[test.Organisation]{}, []test.Organisation{{}}, 1)
+		}).To(PanicWith("partial: ApplyConcurrent given 0 partials, but 1 bases"))
+	})
+})
@@ -0,0 +1,67 @@
+package partial
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// contextKey is distinct for each T, so a request can accumulate partials for several
+// types at once.
+type contextKey[T any] struct{}
+
+// contextAccumulator holds the partial built up over the lifetime of a context. It's
+// shared by every context derived from the one it was added to, so must be locked.
+type contextAccumulator[T any] struct {
+	mu    sync.Mutex
+	model Partial[T]
+}
+
+// WithContextAccumulator returns a context carrying an empty partial of T, which layers
+// such as authorisation, normalisation and auditing can add changes to with
+// MergeIntoContext, without threading the partial through every function signature:
+//
+//	ctx = partial.WithContextAccumulator[Incident](ctx)
+//	...
+//	partial.MergeIntoContext(ctx, IncidentBuilder(IncidentBuilder.Name(name)))
+//	...
+//	model, _ := partial.FromContext[Incident](ctx)
+func WithContextAccumulator[T any](ctx context.Context) context.Context {
+	var zero T
+	return context.WithValue(ctx, contextKey[T]{}, &contextAccumulator[T]{
+		model: newTracking(zero, []string{}),
+	})
+}
+
+// FromContext returns the partial accumulated so far, or false if the context has no
+// accumulator for T.
+func FromContext[T any](ctx context.Context) (Partial[T], bool) {
+	accumulator, ok := ctx.Value(contextKey[T]{}).(*contextAccumulator[T])
+	if !ok {
+		return Partial[T]{}, false
+	}
+
+	accumulator.mu.Lock()
+	defer accumulator.mu.Unlock()
+
+	return accumulator.model, true
+}
+
+// MergeIntoContext merges other into the partial accumulated in the context, with other
+// taking precedence. It errors if the context has no accumulator for T.
+func MergeIntoContext[T any](ctx context.Context, other Partial[T]) error {
+	accumulator, ok := ctx.Value(contextKey[T]{}).(*contextAccumulator[T])
+	if !ok {
+		return errors.New(fmt.Sprintf("no partial accumulator for %s in context", reflect.TypeOf((*T)(nil)).Elem().Name()))
+	}
+
+	accumulator.mu.Lock()
+	defer accumulator.mu.Unlock()
+
+	accumulator.model = accumulator.model.Merge(other)
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package partial_test
+
+import (
+	"context"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Context accumulator", func() {
+	var (
+		ctx context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = partial.WithContextAccumulator[test.Organisation](context.Background())
+	})
+
+	It("starts empty", func() {
+		model, ok := partial.FromContext[test.Organisation](ctx)
+		Expect(ok).To(BeTrue())
+		Expect(model.Empty()).To(BeTrue())
+	})
+
+	It("accumulates merged partials, with later changes taking precedence", func() {
+		Expect(partial.MergeIntoContext(ctx, test.OrganisationBuilder(
+			test.OrganisationBuilder.ID("id"),
+			test.OrganisationBuilder.Name("first"),
+		))).To(Succeed())
+
+		// Derived contexts share the accumulator
+		childCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		Expect(partial.MergeIntoContext(childCtx, test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("second"),
+		))).To(Succeed())
+
+		model, ok := partial.FromContext[test.Organisation](ctx)
+		Expect(ok).To(BeTrue())
+		Expect(model.FieldNames).To(ConsistOf("ID", "Name"))
+		Expect(model.Apply(test.Organisation{})).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.ID("id"),
+			test.OrganisationMatcher.Name("second"),
+		))
+	})
+
+	It("keeps separate accumulators for each type", func() {
+		_, ok := partial.FromContext[test.Incident](ctx)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("errors when merging without an accumulator", func() {
+		err := partial.MergeIntoContext(context.Background(), test.OrganisationBuilder())
+		Expect(err).To(MatchError("no partial accumulator for Organisation in context"))
+	})
+})
@@ -0,0 +1,104 @@
+package partial
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CoverageOption identifies a single generated builder setter or matcher option, such as
+// the Name setter of the IncidentBuilder. Options are keyed by the import path of the
+// package they're generated into, as types in different packages can share a name.
+type CoverageOption struct {
+	PkgPath  string // github.com/incident-io/partial/test
+	TypeName string // Incident
+	Kind     string // builder
+	Option   string // Name
+}
+
+func (o CoverageOption) String() string {
+	kind := o.Kind
+	if kind != "" {
+		kind = strings.ToUpper(kind[:1]) + kind[1:]
+	}
+
+	return fmt.Sprintf("%s.%s%s.%s", o.PkgPath, o.TypeName, kind, o.Option)
+}
+
+var (
+	coverageEnabled int32
+	coverageMu      sync.Mutex
+	coverage        = map[CoverageOption]bool{} // registered options, true if used
+)
+
+// EnableCoverage starts recording which generated options are used, which is intended to
+// be called before running a test suite. Combined with UncoveredOptions, this finds fields
+// whose update path is never exercised by any test.
+//
+// Options are only recorded when built with -tags partialcoverage, so generated code
+// never pays for coverage outside of the test runs that ask for it.
+func EnableCoverage() {
+	atomic.StoreInt32(&coverageEnabled, 1)
+}
+
+// DisableCoverage stops recording which generated options are used.
+func DisableCoverage() {
+	atomic.StoreInt32(&coverageEnabled, 0)
+}
+
+// ResetCoverage forgets which options have been used so far.
+func ResetCoverage() {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	for option := range coverage {
+		coverage[option] = false
+	}
+}
+
+// UncoveredOptions returns every registered option that hasn't been used since coverage
+// was enabled, sorted by type, kind and option.
+func UncoveredOptions() []CoverageOption {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	uncovered := []CoverageOption{}
+	for option, used := range coverage {
+		if !used {
+			uncovered = append(uncovered, option)
+		}
+	}
+
+	sort.Slice(uncovered, func(i, j int) bool {
+		return uncovered[i].String() < uncovered[j].String()
+	})
+
+	return uncovered
+}
+
+// CoverageReport describes every unused option, one per line, suitable for printing
+// after a test suite has finished:
+//
+//	var _ = AfterSuite(func() {
+//		fmt.Print(partial.CoverageReport())
+//	})
+func CoverageReport() string {
+	if !coverageBuilt {
+		return "Generated options aren't recorded unless built with -tags partialcoverage\n"
+	}
+
+	uncovered := UncoveredOptions()
+	if len(uncovered) == 0 {
+		return "All generated options were used\n"
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "%d generated option(s) were never used:\n", len(uncovered))
+	for _, option := range uncovered {
+		fmt.Fprintf(&out, "  %s\n", option)
+	}
+
+	return out.String()
+}
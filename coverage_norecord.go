@@ -0,0 +1,14 @@
+//go:build !partialcoverage
+
+package partial
+
+// coverageBuilt is false, as generated options don't record their use without -tags
+// partialcoverage.
+const coverageBuilt = false
+
+// RegisterCoverage does nothing without -tags partialcoverage.
+func RegisterCoverage(pkgPath, typeName, kind string, options ...string) {}
+
+// RecordCoverage does nothing without -tags partialcoverage, so generated setters cost
+// no more than the call, which the compiler inlines away.
+func RecordCoverage(pkgPath, typeName, kind, option string) {}
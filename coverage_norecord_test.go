@@ -0,0 +1,23 @@
+//go:build !partialcoverage
+
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Coverage without -tags partialcoverage", func() {
+	It("records nothing, and says so", func() {
+		partial.EnableCoverage()
+		defer partial.DisableCoverage()
+
+		test.OrganisationBuilder.Name("name")
+
+		Expect(partial.UncoveredOptions()).To(BeEmpty())
+		Expect(partial.CoverageReport()).To(ContainSubstring("-tags partialcoverage"))
+	})
+})
@@ -0,0 +1,37 @@
+//go:build partialcoverage
+
+package partial
+
+import (
+	"sync/atomic"
+)
+
+// coverageBuilt is true when generated options record their use.
+const coverageBuilt = true
+
+// RegisterCoverage is called by generated code to register every option it provides, so
+// we can report on the options that were never used.
+func RegisterCoverage(pkgPath, typeName, kind string, options ...string) {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	for _, option := range options {
+		key := CoverageOption{PkgPath: pkgPath, TypeName: typeName, Kind: kind, Option: option}
+		if _, ok := coverage[key]; !ok {
+			coverage[key] = false
+		}
+	}
+}
+
+// RecordCoverage is called by generated options whenever they're used. This does nothing
+// unless coverage has been enabled.
+func RecordCoverage(pkgPath, typeName, kind, option string) {
+	if atomic.LoadInt32(&coverageEnabled) == 0 {
+		return
+	}
+
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	coverage[CoverageOption{PkgPath: pkgPath, TypeName: typeName, Kind: kind, Option: option}] = true
+}
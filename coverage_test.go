@@ -0,0 +1,82 @@
+//go:build partialcoverage
+
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Coverage", func() {
+	var (
+		nameSetter          = testOption("Organisation", "builder", "Name")
+		idSetter            = testOption("Organisation", "builder", "ID")
+		nameMatcher         = testOption("Organisation", "matcher", "MatchName")
+		groupedSetter       = testOption("Action", "builder", "Timestamps().DueAt")
+		prioritySetter      = testOption("Action", "builder", "Priority")
+		priorityValueSetter = testOption("Action", "builder", "PriorityValue")
+		priorityNullSetter  = testOption("Action", "builder", "PriorityNull")
+	)
+
+	BeforeEach(func() {
+		partial.ResetCoverage()
+		partial.EnableCoverage()
+	})
+
+	AfterEach(func() {
+		partial.DisableCoverage()
+		partial.ResetCoverage()
+	})
+
+	It("reports every registered option as uncovered to begin with", func() {
+		Expect(partial.UncoveredOptions()).To(ContainElements(nameSetter, idSetter, nameMatcher, groupedSetter))
+	})
+
+	It("records options that have been used", func() {
+		test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("name"),
+		)
+		test.OrganisationMatcher(
+			test.OrganisationMatcher.MatchName(Equal("name")),
+		)
+
+		Expect(partial.UncoveredOptions()).NotTo(ContainElements(nameSetter, nameMatcher))
+		Expect(partial.UncoveredOptions()).To(ContainElement(idSetter))
+	})
+
+	It("records nullable setters under their own names", func() {
+		test.ActionBuilder(
+			test.ActionBuilder.PriorityValue(1),
+		)
+
+		Expect(partial.UncoveredOptions()).NotTo(ContainElement(priorityValueSetter))
+		Expect(partial.UncoveredOptions()).To(ContainElements(prioritySetter, priorityNullSetter))
+	})
+
+	It("keeps types of the same name in different packages apart", func() {
+		partial.RegisterCoverage("example.com/other", "Organisation", "builder", "Name")
+		partial.RecordCoverage("example.com/other", "Organisation", "builder", "Name")
+
+		Expect(partial.UncoveredOptions()).NotTo(ContainElement(
+			partial.CoverageOption{PkgPath: "example.com/other", TypeName: "Organisation", Kind: "builder", Option: "Name"}))
+		Expect(partial.UncoveredOptions()).To(ContainElement(nameSetter))
+	})
+
+	It("does not record options while disabled", func() {
+		partial.DisableCoverage()
+		test.OrganisationBuilder.Name("name")
+
+		Expect(partial.UncoveredOptions()).To(ContainElement(nameSetter))
+	})
+
+	It("reports uncovered options by name", func() {
+		Expect(partial.CoverageReport()).To(ContainSubstring("  github.com/incident-io/partial/test.OrganisationBuilder.Name\n"))
+	})
+})
+
+func testOption(typeName, kind, option string) partial.CoverageOption {
+	return partial.CoverageOption{PkgPath: "github.com/incident-io/partial/test", TypeName: typeName, Kind: kind, Option: option}
+}
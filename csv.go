@@ -0,0 +1,194 @@
+package partial
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CSVRowError describes why a single row of a CSV couldn't be imported.
+type CSVRowError struct {
+	Line int // the line of the CSV, where the header is line 1
+	Err  error
+}
+
+func (e CSVRowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// ReadCSV builds a partial from each row of a CSV, for bulk imports. Each partial tracks
+// only the columns with a value in that row, so empty cells leave existing values alone.
+//
+// Headers are matched against the JSON names of T's fields, unless columns is given, in
+// which case it maps each header to a field name instead:
+//
+//	models, rowErrs, err := partial.ReadCSV[Incident](file, map[string]string{
+//		"Incident name": "Name",
+//	})
+//
+// Rows that can't be parsed are skipped and returned as row errors, so one bad row
+// doesn't fail the whole import. The error is only set if the CSV itself can't be read,
+// or a header doesn't match a field.
+func ReadCSV[T any](reader io.Reader, columns map[string]string) ([]Partial[T], []CSVRowError, error) {
+	subjectType := reflect.TypeOf((*T)(nil)).Elem()
+
+	records := csv.NewReader(reader)
+	records.FieldsPerRecord = -1 // rows may omit trailing empty cells
+
+	header, err := records.Read()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "reading CSV header")
+	}
+
+	fields := []fieldInfo{}
+	for _, name := range header {
+		name = strings.TrimSpace(name)
+
+		var (
+			field fieldInfo
+			ok    bool
+		)
+		if columns != nil {
+			field, ok = schemaFieldFor(subjectType, columns[name])
+		} else {
+			field, ok = schemaFieldForJSONName(subjectType, name)
+		}
+		if !ok {
+			return nil, nil, errors.New(fmt.Sprintf("CSV column %s is not a field on %s", name, subjectType.Name()))
+		}
+
+		fields = append(fields, field)
+	}
+
+	models, rowErrs := []Partial[T]{}, []CSVRowError{}
+	for line := 2; ; line++ {
+		row, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if _, ok := err.(*csv.ParseError); ok {
+				rowErrs = append(rowErrs, CSVRowError{Line: line, Err: err})
+				continue
+			}
+
+			return nil, nil, errors.Wrap(err, "reading CSV")
+		}
+
+		model, err := partialFromCSVRow[T](fields, row)
+		if err != nil {
+			rowErrs = append(rowErrs, CSVRowError{Line: line, Err: err})
+			continue
+		}
+
+		models = append(models, model)
+	}
+
+	return models, rowErrs, nil
+}
+
+func partialFromCSVRow[T any](fields []fieldInfo, row []string) (Partial[T], error) {
+	var subject T
+	subjectValue := reflect.ValueOf(&subject).Elem()
+
+	fieldNames := []string{}
+	for idx, cell := range row {
+		if idx >= len(fields) {
+			return Partial[T]{}, errors.New(fmt.Sprintf("row has %d cells, but there are only %d columns", len(row), len(fields)))
+		}
+
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+
+		field := fields[idx]
+		if err := parseCSVCell(subjectValue.FieldByIndex(field.Index), cell); err != nil {
+			return Partial[T]{}, errors.Wrap(err, fmt.Sprintf("parsing %s", field.Name))
+		}
+
+		fieldNames = append(fieldNames, field.Name)
+	}
+
+	return newTracking(subject, fieldNames), nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// parseCSVCell sets the field from the text of a cell. Types that can unmarshal
+// themselves from text (such as null.String and time.Time) or scan from a string (such as
+// sql.NullInt64) do so, and anything else we don't recognise is parsed as JSON.
+func parseCSVCell(field reflect.Value, cell string) error {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if err := parseCSVCell(value.Elem(), cell); err != nil {
+			return err
+		}
+
+		field.Set(value)
+		return nil
+	}
+
+	if field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(cell))
+	}
+	if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(cell)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(cell)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			value, err := time.ParseDuration(cell)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(value))
+
+			return nil
+		}
+
+		value, err := strconv.ParseInt(cell, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(cell, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(cell, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(value)
+	default:
+		return json.Unmarshal([]byte(cell), field.Addr().Interface())
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package partial_test
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+	"gopkg.in/guregu/null.v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadCSV", func() {
+	It("builds a partial per row, tracking only columns with a value", func() {
+		models, rowErrs, err := partial.ReadCSV[test.Organisation](strings.NewReader(
+			"name,bool_flag,incident_count,optional_string\n"+
+				"First,true,3,\n"+
+				"Second,,,something\n",
+		), nil)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rowErrs).To(BeEmpty())
+		Expect(models).To(HaveLen(2))
+
+		Expect(models[0].FieldNames).To(Equal([]string{"Name", "BoolFlag", "IncidentCount"}))
+		Expect(&models[0].Subject).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.Name("First"),
+			test.OrganisationMatcher.BoolFlag(true),
+			test.OrganisationMatcher.IncidentCount(3),
+		))
+
+		Expect(models[1].FieldNames).To(Equal([]string{"Name", "OptionalString"}))
+		Expect(models[1].Subject.OptionalString).To(Equal(null.StringFrom("something")))
+	})
+
+	It("maps headers to fields when given columns", func() {
+		models, _, err := partial.ReadCSV[test.Action](strings.NewReader(
+			"Description,Priority,Due\n"+
+				"Fix it,2,2022-06-01T12:00:00Z\n",
+		), map[string]string{"Description": "Description", "Priority": "Priority", "Due": "DueAt"})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(&models[0].Subject).To(test.ActionMatcher(
+			test.ActionMatcher.Description("Fix it"),
+			test.ActionMatcher.Priority(sql.NullInt64{Int64: 2, Valid: true}),
+			test.ActionMatcher.DueAt(null.TimeFrom(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))),
+		))
+	})
+
+	It("collects errors for rows that can't be parsed", func() {
+		models, rowErrs, err := partial.ReadCSV[test.Organisation](strings.NewReader(
+			"name,incident_count\n"+
+				"First,many\n"+
+				"Second,2\n",
+		), nil)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(models).To(HaveLen(1))
+		Expect(models[0].Subject.Name).To(Equal("Second"))
+		Expect(rowErrs).To(HaveLen(1))
+		Expect(rowErrs[0].Line).To(Equal(2))
+		Expect(rowErrs[0].Error()).To(ContainSubstring("line 2: parsing IncidentCount"))
+	})
+
+	It("fails if a header isn't a field", func() {
+		_, _, err := partial.ReadCSV[test.Organisation](strings.NewReader("nope\n"), nil)
+
+		Expect(err).To(MatchError(ContainSubstring("CSV column nope is not a field on Organisation")))
+	})
+})
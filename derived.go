@@ -0,0 +1,117 @@
+package partial
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// derivedField is a field whose value is computed from other fields of the same struct,
+// such as a denormalised search column.
+type derivedField struct {
+	FieldName    string
+	Dependencies []string
+	recompute    func(subject any) // subject is a *T
+}
+
+var (
+	derivedFieldsMu sync.RWMutex
+	derivedFields   = map[reflect.Type][]derivedField{}
+)
+
+// Derived registers a field of T that is computed from its dependencies. Whenever a
+// Partial tracks any of the dependencies, it will also track the derived field, and
+// recompute it when applied:
+//
+//	partial.Derived[Incident]("SearchText", []string{"Name", "Summary"}, func(inc Incident) string {
+//		return strings.ToLower(inc.Name + " " + inc.Summary)
+//	})
+//
+// This is intended to be called from an init function, and panics if the fields don't
+// exist or the computed value can't be assigned to the field. Derived fields may depend
+// on other derived fields, provided they are registered after their dependencies.
+func Derived[T any, V any](fieldName string, dependencies []string, compute func(T) V) {
+	subjectType := reflect.TypeOf((*T)(nil)).Elem()
+
+	field, ok := subjectType.FieldByName(fieldName)
+	if !ok {
+		panic(fmt.Sprintf("partial: derived field %s does not exist on %s", fieldName, subjectType.Name()))
+	}
+	if !field.IsExported() {
+		panic(fmt.Sprintf("partial: derived field %s on %s is unexported, so can't be set", fieldName, subjectType.Name()))
+	}
+	if valueType := reflect.TypeOf((*V)(nil)).Elem(); !valueType.AssignableTo(field.Type) {
+		panic(fmt.Sprintf("partial: derived field %s on %s has type %s, not %s", fieldName, subjectType.Name(), field.Type, valueType))
+	}
+	for _, dependency := range dependencies {
+		if _, ok := subjectType.FieldByName(dependency); !ok {
+			panic(fmt.Sprintf("partial: dependency %s of derived field %s does not exist on %s", dependency, fieldName, subjectType.Name()))
+		}
+	}
+
+	derivedFieldsMu.Lock()
+	defer derivedFieldsMu.Unlock()
+
+	derivedFields[subjectType] = append(derivedFields[subjectType], derivedField{
+		FieldName:    fieldName,
+		Dependencies: dependencies,
+		recompute: func(subject any) {
+			subjectPtr := subject.(*T)
+			reflect.ValueOf(subjectPtr).Elem().FieldByName(fieldName).Set(
+				reflect.ValueOf(compute(*subjectPtr)),
+			)
+		},
+	})
+}
+
+func derivedFieldsFor(subjectType reflect.Type) []derivedField {
+	derivedFieldsMu.RLock()
+	defer derivedFieldsMu.RUnlock()
+
+	return derivedFields[subjectType]
+}
+
+// TrackDerived tracks every registered derived field with a tracked dependency, and
+// computes its value from the Subject.
+//
+// The Subject only holds the tracked fields, so the value computed here may not be the
+// same as the one computed by Apply, which recomputes against the complete result.
+func (m Partial[T]) TrackDerived() Partial[T] {
+	fields := derivedFieldsFor(reflect.TypeOf(m.Subject))
+	if len(fields) == 0 {
+		return m
+	}
+
+	m.FieldNames = append([]string{}, m.FieldNames...)
+	for _, field := range fields {
+		if !containsAny(m.FieldNames, field.Dependencies) {
+			continue
+		}
+
+		if !contains(m.FieldNames, field.FieldName) {
+			m.FieldNames = append(m.FieldNames, field.FieldName)
+		}
+		field.recompute(&m.Subject)
+	}
+
+	return m
+}
+
+// recomputeDerived updates the value of every tracked derived field on subject.
+func (m Partial[T]) recomputeDerived(subject *T) {
+	for _, field := range derivedFieldsFor(reflect.TypeOf(m.Subject)) {
+		if contains(m.FieldNames, field.FieldName) {
+			field.recompute(subject)
+		}
+	}
+}
+
+func containsAny(fieldNames []string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if contains(fieldNames, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
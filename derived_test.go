@@ -0,0 +1,66 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Derived", func() {
+	var (
+		model partial.Partial[test.Action]
+	)
+
+	Context("when a dependency is tracked", func() {
+		BeforeEach(func() {
+			model = test.ActionBuilder(
+				test.ActionBuilder.Description("Restart the Database"),
+			)
+		})
+
+		It("tracks the derived field", func() {
+			Expect(model.FieldNames).To(ConsistOf("Description", "SearchText"))
+		})
+
+		It("recomputes the derived field against the complete result when applied", func() {
+			Expect(model.Apply(test.Action{Assignee: "Lisa"})).To(test.ActionMatcher(
+				test.ActionMatcher.Description("Restart the Database"),
+				test.ActionMatcher.SearchText("restart the database lisa"),
+			))
+		})
+	})
+
+	Context("when no dependency is tracked", func() {
+		BeforeEach(func() {
+			model = test.ActionBuilder(
+				test.ActionBuilder.ID("action-id"),
+			)
+		})
+
+		It("does not track the derived field", func() {
+			Expect(model.FieldNames).To(ConsistOf("ID"))
+		})
+
+		It("leaves the derived field untouched when applied", func() {
+			Expect(model.Apply(test.Action{SearchText: "stale"})).To(test.ActionMatcher(
+				test.ActionMatcher.SearchText("stale"),
+			))
+		})
+	})
+
+	Context("when merging", func() {
+		BeforeEach(func() {
+			model = test.ActionBuilder(
+				test.ActionBuilder.ID("action-id"),
+			).Merge(test.ActionBuilder(
+				test.ActionBuilder.Assignee("Lisa"),
+			))
+		})
+
+		It("tracks the derived field", func() {
+			Expect(model.FieldNames).To(ConsistOf("ID", "Assignee", "SearchText"))
+		})
+	})
+})
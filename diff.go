@@ -0,0 +1,108 @@
+package partial
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+)
+
+// DiffColours controls whether Diff output includes ANSI colour codes. Disable this when
+// writing diffs somewhere other than a terminal.
+var DiffColours = true
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// FieldDiff pairs the values of a single field from two instances of the same struct.
+type FieldDiff struct {
+	FieldName string
+	A, B      any
+}
+
+// Diff produces a per-field description of the fields that differ, returning an empty
+// string if every field is equal. This backs the generated Diff<Type> functions.
+func Diff(typeName string, fields []FieldDiff) string {
+	changed := []FieldDiff{}
+	for _, field := range fields {
+		if !reflect.DeepEqual(field.A, field.B) {
+			changed = append(changed, field)
+		}
+	}
+
+	if len(changed) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s differs in %d field(s):\n", typeName, len(changed))
+	for _, field := range changed {
+		fmt.Fprintf(&out, "  %s:\n", field.FieldName)
+		fmt.Fprintf(&out, "    %s\n", colourise(ansiRed, "- "+formatValue(field.A)))
+		fmt.Fprintf(&out, "    %s\n", colourise(ansiGreen, "+ "+formatValue(field.B)))
+	}
+
+	return out.String()
+}
+
+// WithDiff is called by generated matchers for types that also have a diff, appending the
+// output of diff to failure messages so we can see which of the fields matched exactly
+// were wrong, rather than reading the whole of both structs.
+func WithDiff(matcher types.GomegaMatcher, diff func(actual any) string) types.GomegaMatcher {
+	return &diffMatcher{GomegaMatcher: matcher, diff: diff}
+}
+
+// diffMatcher wraps a matcher, adding a diff against the actual value to its failure
+// messages.
+type diffMatcher struct {
+	types.GomegaMatcher
+	diff func(actual any) string
+}
+
+func (m *diffMatcher) FailureMessage(actual any) string {
+	message := m.GomegaMatcher.FailureMessage(actual)
+	if diff := m.diff(actual); diff != "" {
+		message = fmt.Sprintf("%s\n%s", message, diff)
+	}
+
+	return message
+}
+
+func colourise(colour, text string) string {
+	if !DiffColours {
+		return text
+	}
+
+	return colour + text + ansiReset
+}
+
+// formatValue renders a value compactly, dereferencing pointers so we show what they
+// point at rather than an address.
+func formatValue(value any) string {
+	if value == nil {
+		return "nil"
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() == reflect.Pointer && reflectValue.IsNil() {
+		return "nil"
+	}
+
+	switch value := value.(type) {
+	case string:
+		return strconv.Quote(value)
+	case fmt.Stringer:
+		return value.String()
+	}
+
+	if reflectValue.Kind() == reflect.Pointer {
+		return "&" + formatValue(reflectValue.Elem().Interface())
+	}
+
+	return fmt.Sprintf("%+v", value)
+}
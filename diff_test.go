@@ -0,0 +1,93 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+	"gopkg.in/guregu/null.v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var (
+		a, b test.Organisation
+		diff string
+	)
+
+	BeforeEach(func() {
+		partial.DiffColours = false
+
+		a = test.Organisation{
+			ID:             "id",
+			Name:           "name",
+			OptionalString: null.StringFrom("something-here"),
+		}
+		b = a
+	})
+
+	AfterEach(func() {
+		partial.DiffColours = true
+	})
+
+	JustBeforeEach(func() {
+		diff = test.DiffOrganisation(a, b)
+	})
+
+	Context("when the structs are equal", func() {
+		It("returns an empty string", func() {
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	Context("when fields differ", func() {
+		BeforeEach(func() {
+			b.Name = "other-name"
+			b.BoolFlag = true
+		})
+
+		It("describes only the fields that changed", func() {
+			Expect(diff).To(Equal(`Organisation differs in 2 field(s):
+  Name:
+    - "name"
+    + "other-name"
+  BoolFlag:
+    - false
+    + true
+`))
+		})
+	})
+
+	Context("when a matcher fails", func() {
+		It("appends a diff of the fields matched exactly", func() {
+			matcher := test.OrganisationMatcher(
+				test.OrganisationMatcher.Name("name"),
+				test.OrganisationMatcher.MatchBoolFlag(BeTrue()),
+			)
+
+			actual := &test.Organisation{ID: "id", Name: "other-name"}
+			Expect(matcher.Match(actual)).To(BeFalse())
+			Expect(matcher.FailureMessage(actual)).To(HaveSuffix(`
+Organisation differs in 1 field(s):
+  Name:
+    - "name"
+    + "other-name"
+`))
+		})
+
+		It("adds nothing when the actual value isn't the type", func() {
+			matcher := test.OrganisationMatcher(test.OrganisationMatcher.Name("name"))
+
+			Expect(matcher.FailureMessage(nil)).NotTo(ContainSubstring("differs in"))
+		})
+	})
+
+	Context("with associations", func() {
+		It("ignores fields that are not database-backed", func() {
+			Expect(test.DiffIncident(
+				test.Incident{ID: "id"},
+				test.Incident{ID: "id", Organisation: &test.Organisation{}},
+			)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,260 @@
+package partial
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// Encryptor encrypts and decrypts the values of fields tagged `partial:"encrypted"`,
+// such as by wrapping a KMS data key.
+type Encryptor interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// KeyDeriver can be implemented by an Encryptor to derive further keys from its own, such
+// as with HKDF. CacheKeySuffix and Fingerprint use a derived key to HMAC the values of
+// encrypted fields, and redact them when the registered Encryptor can't derive one. It
+// is called each time, so should be cheap.
+type KeyDeriver interface {
+	DeriveKey(purpose string) ([]byte, error)
+}
+
+var (
+	encryptorMu sync.RWMutex
+	encryptor   Encryptor
+)
+
+// RegisterEncryptor sets the Encryptor used for every encrypted field. Encrypted fields
+// must be strings or byte slices, and strings are stored as base64 of the ciphertext.
+func RegisterEncryptor(registered Encryptor) {
+	encryptorMu.Lock()
+	defer encryptorMu.Unlock()
+
+	encryptor = registered
+}
+
+func registeredEncryptor() (Encryptor, error) {
+	encryptorMu.RLock()
+	defer encryptorMu.RUnlock()
+
+	if encryptor == nil {
+		return nil, errors.New("no encryptor has been registered for encrypted fields")
+	}
+
+	return encryptor, nil
+}
+
+// ToDBMap returns the tracked fields keyed by column, ready to pass to gorm's Updates.
+// Cleared fields are written as null, incremented fields as an expression adding to the
+// existing value, and encrypted fields are encrypted. Fields without a column, which we
+// infer from them having no JSON name, and readonly fields are left out.
+//
+// As the columns are for an update, it fails if an immutable field is tracked, as
+// Validate(OperationUpdate) would.
+func (m Partial[T]) ToDBMap() (map[string]any, error) {
+	ops, err := m.EncryptedOps()
+	if err != nil {
+		return nil, err
+	}
+
+	subjectType := reflect.TypeOf(m.Subject)
+
+	columns := map[string]any{}
+	for _, op := range ops {
+		info, ok := schemaFieldFor(subjectType, op.FieldName)
+		if !ok || !info.DatabaseBacked() || info.ReadOnly {
+			continue
+		}
+		if info.Immutable {
+			return nil, errors.New(fmt.Sprintf("field %s on %s is immutable and cannot be updated", op.FieldName, subjectType.Name()))
+		}
+
+		if op.Kind == FieldOpIncrement {
+			columns[info.ColumnName] = gorm.Expr(fmt.Sprintf("%s + ?", info.ColumnName), op.Value)
+		} else {
+			columns[info.ColumnName] = op.Value
+		}
+	}
+
+	return columns, nil
+}
+
+// EncryptedOps returns Ops with the value of each encrypted field encrypted, which is
+// what persistence adapters should write. Like ToDBMap, it fails if there's an encrypted
+// field to write and no encryptor has been registered.
+func (m Partial[T]) EncryptedOps() ([]FieldOp, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	subjectType := reflect.TypeOf(m.Subject)
+
+	ops := m.Ops()
+	for idx, op := range ops {
+		if op.Kind != FieldOpSet {
+			continue
+		}
+		if info, ok := schemaFieldFor(subjectType, op.FieldName); !ok || !info.Encrypted {
+			continue
+		}
+
+		encrypted, err := encryptValue(reflect.ValueOf(op.Value))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("encrypting %s", op.FieldName))
+		}
+
+		ops[idx].Value = encrypted.Interface()
+	}
+
+	return ops, nil
+}
+
+// ApplyEncrypted applies the partial like Apply, encrypting every encrypted field of the
+// result, but returns an error where Apply would panic, and fails if no encryptor has
+// been registered rather than leaving the fields as they are.
+func (m Partial[T]) ApplyEncrypted(base T) (*T, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	patched := m.applyPlaintext(base)
+	if err := transformEncrypted(patched, encryptValue); err != nil {
+		return nil, err
+	}
+
+	return patched, nil
+}
+
+// Decrypt decrypts every encrypted field of subject in place, which should be called on
+// records loaded from the database before they're used. Until an encryptor is
+// registered, it leaves them as they are, as Apply does.
+func Decrypt[T any](subject *T) error {
+	if err := checkSubject(subject); err != nil {
+		return err
+	}
+	if _, err := registeredEncryptor(); err != nil {
+		return nil
+	}
+
+	return transformEncrypted(subject, decryptValue)
+}
+
+// encryptIfRegistered encrypts every encrypted field of subject in place, unless no
+// encryptor has been registered.
+func encryptIfRegistered[T any](subject *T) error {
+	if _, err := registeredEncryptor(); err != nil {
+		return nil
+	}
+
+	return transformEncrypted(subject, encryptValue)
+}
+
+// transformEncrypted replaces the value of each encrypted field of subject.
+func transformEncrypted[T any](subject *T, transform func(reflect.Value) (reflect.Value, error)) error {
+	subjectValue := reflect.ValueOf(subject).Elem()
+	for _, info := range schemaFor(subjectValue.Type()) {
+		if !info.Encrypted {
+			continue
+		}
+
+		field := subjectValue.FieldByIndex(info.Index)
+		if !encryptable(field.Type()) {
+			return errors.New(fmt.Sprintf("field %s: %s", info.Name, unencryptableMessage(field.Type())))
+		}
+		if field.Len() == 0 {
+			continue
+		}
+
+		transformed, err := transform(field)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("field %s", info.Name))
+		}
+
+		field.Set(transformed)
+	}
+
+	return nil
+}
+
+func encryptValue(value reflect.Value) (reflect.Value, error) {
+	plaintext, err := bytesForEncryption(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	encryptor, err := registeredEncryptor()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	ciphertext, err := encryptor.Encrypt(plaintext)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if value.Kind() == reflect.String {
+		return reflect.ValueOf(base64.StdEncoding.EncodeToString(ciphertext)).Convert(value.Type()), nil
+	}
+
+	return reflect.ValueOf(ciphertext).Convert(value.Type()), nil
+}
+
+func decryptValue(value reflect.Value) (reflect.Value, error) {
+	ciphertext, err := bytesForEncryption(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if value.Kind() == reflect.String {
+		ciphertext, err = base64.StdEncoding.DecodeString(string(ciphertext))
+		if err != nil {
+			return reflect.Value{}, errors.Wrap(err, "decoding ciphertext")
+		}
+	}
+
+	encryptor, err := registeredEncryptor()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	plaintext, err := encryptor.Decrypt(ciphertext)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if value.Kind() == reflect.String {
+		return reflect.ValueOf(string(plaintext)).Convert(value.Type()), nil
+	}
+
+	return reflect.ValueOf(plaintext).Convert(value.Type()), nil
+}
+
+// bytesForEncryption returns the contents of an encrypted field, which must be a string
+// or byte slice.
+func bytesForEncryption(value reflect.Value) ([]byte, error) {
+	if !encryptable(value.Type()) {
+		return nil, errors.New(unencryptableMessage(value.Type()))
+	}
+	if value.Kind() == reflect.String {
+		return []byte(value.String()), nil
+	}
+
+	return value.Bytes(), nil
+}
+
+// encryptable returns true if fields of the type can be encrypted, which only strings
+// and byte slices can.
+func encryptable(fieldType reflect.Type) bool {
+	return fieldType.Kind() == reflect.String ||
+		fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Uint8
+}
+
+func unencryptableMessage(fieldType reflect.Type) string {
+	return fmt.Sprintf("encrypted fields must be a string or []byte, not %s", fieldType)
+}
@@ -0,0 +1,171 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+	"gorm.io/gorm"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// reverseEncryptor "encrypts" by reversing the bytes, which is enough to tell whether a
+// value has been encrypted.
+type reverseEncryptor struct{}
+
+func (reverseEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	return reverse(plaintext), nil
+}
+
+func (reverseEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	return reverse(ciphertext), nil
+}
+
+func reverse(value []byte) []byte {
+	reversed := []byte{}
+	for idx := len(value) - 1; idx >= 0; idx-- {
+		reversed = append(reversed, value[idx])
+	}
+
+	return reversed
+}
+
+// privateSecret has an encrypted field that reflect can't set, which should be skipped.
+type privateSecret struct {
+	Name   string
+	secret string `partial:"encrypted"`
+}
+
+// numericSecret has an encrypted field of a type that can't be encrypted.
+type numericSecret struct {
+	Name string `json:"name"`
+	Pin  int    `json:"pin" partial:"encrypted"`
+}
+
+var _ = Describe("Encrypted fields", func() {
+	var model partial.Partial[test.Organisation]
+
+	BeforeEach(func() {
+		partial.RegisterEncryptor(reverseEncryptor{})
+
+		model = test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+			test.OrganisationBuilder.WebhookSecret("secret"),
+			test.OrganisationBuilder.OptionalStringNull(),
+		).Increment("IncidentCount", 1)
+	})
+
+	AfterEach(func() {
+		partial.RegisterEncryptor(nil)
+	})
+
+	Describe("ToDBMap", func() {
+		It("encrypts encrypted fields", func() {
+			columns, err := model.ToDBMap()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns).To(Equal(map[string]any{
+				"name":            "My Org",
+				"webhook_secret":  "dGVyY2Vz", // base64 of "terces"
+				"optional_string": nil,
+				"incident_count":  gorm.Expr("incident_count + ?", 1),
+			}))
+		})
+
+		It("fails if no encryptor is registered", func() {
+			partial.RegisterEncryptor(nil)
+
+			_, err := model.ToDBMap()
+			Expect(err).To(MatchError(ContainSubstring("no encryptor has been registered")))
+		})
+	})
+
+	Describe("Apply", func() {
+		It("encrypts the result, which Decrypt reverses", func() {
+			org := model.Apply(test.Organisation{ID: "org-id"})
+			Expect(org.WebhookSecret).To(Equal("dGVyY2Vz"))
+
+			Expect(partial.Decrypt(org)).To(Succeed())
+			Expect(org.WebhookSecret).To(Equal("secret"))
+		})
+
+		It("leaves encrypted fields as they are until an encryptor is registered", func() {
+			partial.RegisterEncryptor(nil)
+
+			Expect(model.Apply(test.Organisation{}).WebhookSecret).To(Equal("secret"))
+		})
+
+		It("panics for encrypted fields that can't be encrypted", func() {
+			numeric, err := partial.New(&numericSecret{Name: "name", Pin: 1234})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(func() { numeric.Apply(numericSecret{}) }).To(PanicWith(ContainSubstring("must be a string or []byte, not int")))
+		})
+	})
+
+	Describe("EncryptedOps", func() {
+		It("encrypts the values of encrypted fields, unlike Ops", func() {
+			ops, err := model.EncryptedOps()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ops).To(ContainElement(partial.FieldOp{
+				FieldName: "WebhookSecret", JSONName: "webhook_secret", Kind: partial.FieldOpSet, Value: "dGVyY2Vz",
+			}))
+			Expect(model.Ops()).To(ContainElement(partial.FieldOp{
+				FieldName: "WebhookSecret", JSONName: "webhook_secret", Kind: partial.FieldOpSet, Value: "secret",
+			}))
+		})
+
+		It("fails if no encryptor is registered", func() {
+			partial.RegisterEncryptor(nil)
+
+			_, err := model.EncryptedOps()
+			Expect(err).To(MatchError(ContainSubstring("no encryptor has been registered")))
+		})
+	})
+
+	Describe("ApplyEncrypted", func() {
+		It("encrypts the result, which Decrypt reverses", func() {
+			org, err := model.ApplyEncrypted(test.Organisation{ID: "org-id"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(org.WebhookSecret).To(Equal("dGVyY2Vz"))
+
+			Expect(partial.Decrypt(org)).To(Succeed())
+			Expect(org.WebhookSecret).To(Equal("secret"))
+		})
+	})
+
+	Describe("Decrypt", func() {
+		It("skips unexported fields, which can't be set", func() {
+			subject := privateSecret{Name: "name", secret: "dGVyY2Vz"}
+
+			Expect(partial.Decrypt(&subject)).To(Succeed())
+			Expect(subject.secret).To(Equal("dGVyY2Vz"))
+		})
+	})
+
+	Context("with an encrypted field that isn't a string or []byte", func() {
+		var numeric partial.Partial[numericSecret]
+
+		BeforeEach(func() {
+			var err error
+			numeric, err = partial.New(&numericSecret{Name: "name", Pin: 1234})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails ToDBMap", func() {
+			_, err := numeric.ToDBMap()
+			Expect(err).To(MatchError("encrypting Pin: encrypted fields must be a string or []byte, not int"))
+		})
+
+		It("fails ApplyEncrypted, rather than panicking", func() {
+			_, err := numeric.ApplyEncrypted(numericSecret{})
+			Expect(err).To(MatchError("field Pin: encrypted fields must be a string or []byte, not int"))
+		})
+
+		It("fails Decrypt, even for the zero value", func() {
+			Expect(partial.Decrypt(&numericSecret{})).To(MatchError("field Pin: encrypted fields must be a string or []byte, not int"))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+package partial
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FromEnv builds a partial from environment variables, tracking only the fields whose
+// variable is set. Each JSON field is read from a variable named after its JSON name in
+// upper case, following the prefix, so MaxRetries `json:"max_retries"` is read from
+// MYAPP_MAX_RETRIES for a prefix of MYAPP.
+//
+// Values are parsed as they are from CSV cells, so anything from an int to a
+// time.Duration or null.String is supported. Merging the result over defaults loaded
+// from elsewhere gives environment variables precedence over them.
+func FromEnv[T any](prefix string) (Partial[T], error) {
+	var subject T
+	if err := checkSubject(&subject); err != nil {
+		return Partial[T]{}, err
+	}
+
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+
+	subjectValue := reflect.ValueOf(&subject).Elem()
+
+	fieldNames := []string{}
+	for _, field := range schemaFor(subjectValue.Type()) {
+		if !field.DatabaseBacked() || field.ReadOnly {
+			continue
+		}
+
+		name := prefix + strings.ToUpper(field.JSONName)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if err := parseCSVCell(subjectValue.FieldByIndex(field.Index), value); err != nil {
+			return Partial[T]{}, errors.Wrap(err, fmt.Sprintf("parsing %s", name))
+		}
+
+		fieldNames = append(fieldNames, field.Name)
+	}
+
+	return newTracking(subject, fieldNames), nil
+}
@@ -0,0 +1,69 @@
+package partial_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FromEnv", func() {
+	var names []string
+
+	setEnv := func(name, value string) {
+		Expect(os.Setenv(name, value)).To(Succeed())
+		names = append(names, name)
+	}
+
+	AfterEach(func() {
+		for _, name := range names {
+			Expect(os.Unsetenv(name)).To(Succeed())
+		}
+		names = nil
+	})
+
+	It("tracks the fields whose variables are set", func() {
+		setEnv("MYAPP_MAX_RETRIES", "5")
+		setEnv("MYAPP_TIMEOUT", "1m")
+		setEnv("MYAPP_PRIORITY", "p1")
+		setEnv("MYAPP_TAGS", `["a", "b"]`)
+
+		model, err := partial.FromEnv[test.CLIConfig]("MYAPP")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(model.FieldNames).To(Equal([]string{"MaxRetries", "Timeout", "Tags", "Priority"}))
+		Expect(model.Subject).To(Equal(test.CLIConfig{
+			MaxRetries: 5,
+			Timeout:    time.Minute,
+			Tags:       []string{"a", "b"},
+			Priority:   "p1",
+		}))
+	})
+
+	It("tracks variables set to an empty string", func() {
+		setEnv("MYAPP_ENDPOINT", "")
+
+		model, err := partial.FromEnv[test.CLIConfig]("MYAPP_")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(model.FieldNames).To(Equal([]string{"Endpoint"}))
+	})
+
+	It("returns errors for values that fail to parse", func() {
+		setEnv("MYAPP_VERBOSE", "sometimes")
+
+		_, err := partial.FromEnv[test.CLIConfig]("MYAPP")
+
+		Expect(err).To(MatchError(ContainSubstring("parsing MYAPP_VERBOSE")))
+	})
+
+	It("returns errors for types that aren't structs", func() {
+		_, err := partial.FromEnv[string]("MYAPP")
+
+		Expect(err).To(MatchError("cannot track fields of string, which is not a struct"))
+	})
+})
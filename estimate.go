@@ -0,0 +1,99 @@
+package partial
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"time"
+)
+
+// EstimateRowDelta approximates how many bytes would be written to the database for the
+// tracked fields, which is useful when packing bulk updates into batches.
+//
+// Strings and byte slices count their length, and other values count the size of their
+// type in memory. Nil pointers and null values are free. Values implementing
+// driver.Valuer are estimated from the value they write to the database.
+func (m Partial[T]) EstimateRowDelta() int {
+	subjectValue := reflect.ValueOf(m.Subject)
+
+	size := 0
+	for _, fieldName := range m.FieldNames {
+		field := subjectValue.FieldByName(fieldName)
+		if !field.IsValid() {
+			continue
+		}
+
+		size += estimateSize(field)
+	}
+
+	return size
+}
+
+var (
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// estimateSize approximates the number of bytes needed to store the value.
+func estimateSize(value reflect.Value) int {
+	if value.Type().Implements(valuerType) && value.CanInterface() {
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			return 0
+		}
+
+		dbValue, err := value.Interface().(driver.Valuer).Value()
+		if err != nil || dbValue == nil {
+			return 0
+		}
+
+		// Valuers returning themselves would otherwise recurse forever.
+		if reflect.TypeOf(dbValue) == value.Type() {
+			return int(value.Type().Size())
+		}
+
+		return estimateSize(reflect.ValueOf(dbValue))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.Len()
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return 0
+		}
+
+		return estimateSize(value.Elem())
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return value.Len()
+		}
+
+		size := 0
+		for idx := 0; idx < value.Len(); idx++ {
+			size += estimateSize(value.Index(idx))
+		}
+
+		return size
+	case reflect.Map:
+		size := 0
+		iter := value.MapRange()
+		for iter.Next() {
+			size += estimateSize(iter.Key()) + estimateSize(iter.Value())
+		}
+
+		return size
+	case reflect.Struct:
+		// Timestamps are stored as 8 bytes, not the size of time.Time in memory.
+		if value.Type() == timeType {
+			return 8
+		}
+
+		size := 0
+		for idx := 0; idx < value.NumField(); idx++ {
+			size += estimateSize(value.Field(idx))
+		}
+
+		return size
+	default:
+		return int(value.Type().Size())
+	}
+}
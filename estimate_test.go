@@ -0,0 +1,57 @@
+package partial_test
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/incident-io/partial/test"
+	"gopkg.in/guregu/null.v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EstimateRowDelta", func() {
+	It("counts the length of strings", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("Peanuts"),
+		)
+
+		Expect(model.EstimateRowDelta()).To(Equal(7))
+	})
+
+	It("counts fixed sizes for other types", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.BoolFlag(true),
+		)
+
+		Expect(model.EstimateRowDelta()).To(Equal(1))
+	})
+
+	It("estimates valuers from their database value", func() {
+		model := test.ActionBuilder(
+			test.ActionBuilder.Priority(sql.NullInt64{Int64: 3, Valid: true}),
+			test.ActionBuilder.Timestamps().DueAt(null.TimeFrom(time.Now())),
+		)
+
+		Expect(model.EstimateRowDelta()).To(Equal(16))
+	})
+
+	It("treats null values as free", func() {
+		model := test.ActionBuilder(
+			test.ActionBuilder.PriorityNull(),
+			test.ActionBuilder.Timestamps().DueAt(null.Time{}),
+		)
+
+		Expect(model.EstimateRowDelta()).To(Equal(0))
+	})
+
+	It("counts only tracked fields", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.ID("id"),
+			test.OrganisationBuilder.Name("Peanuts"),
+		).Without("Name")
+
+		Expect(model.EstimateRowDelta()).To(Equal(2))
+	})
+})
@@ -0,0 +1,22 @@
+package partial
+
+// ChangedValues returns the JSON names of the fields tracked by the partial, along with
+// their new values keyed by the same names. This backs the generated <Type>ChangedEvent
+// constructors, giving every service the same shape of change event.
+//
+// Cleared fields have a nil value, and incremented fields have the amount they were
+// incremented by, as the new value isn't known until the increment is applied. Fields
+// that aren't serialised to JSON are left out.
+func ChangedValues[T any](model Partial[T]) ([]string, map[string]any) {
+	changedFields, values := []string{}, map[string]any{}
+	for _, op := range model.Ops() {
+		if op.JSONName == "" {
+			continue
+		}
+
+		changedFields = append(changedFields, op.JSONName)
+		values[op.JSONName] = op.Value
+	}
+
+	return changedFields, values
+}
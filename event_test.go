@@ -0,0 +1,35 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ChangedEvent", func() {
+	It("includes the new values of changed fields by JSON name", func() {
+		model := test.IncidentBuilder(
+			test.IncidentBuilder.OrganisationID("org-id"),
+			test.IncidentBuilder.Parent(&test.Incident{ID: "parent-id"}),
+		)
+
+		Expect(test.NewIncidentChangedEvent("incident-id", model, "user-id")).To(Equal(test.IncidentChangedEvent{
+			EntityID:      "incident-id",
+			ChangedFields: []string{"organisation_id"},
+			Values:        map[string]any{"organisation_id": "org-id"},
+			Actor:         "user-id",
+		}))
+	})
+
+	It("includes the amount incremented by", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.OptionalStringNull(),
+		).Increment("IncidentCount", 1)
+
+		changedFields, values := partial.ChangedValues(model)
+		Expect(changedFields).To(Equal([]string{"optional_string", "incident_count"}))
+		Expect(values).To(Equal(map[string]any{"optional_string": nil, "incident_count": 1}))
+	})
+})
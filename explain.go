@@ -0,0 +1,50 @@
+package partial
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Explain describes what applying the partial would do in prose, such as `sets Name to
+// "x", clears OptionalString, increments Count by 3`, for showing an operator pending
+// changes before they confirm them. Values of encrypted fields are redacted.
+func (m Partial[T]) Explain() string {
+	subjectType := reflect.TypeOf(m.Subject)
+
+	clauses := []string{}
+	for _, op := range m.Ops() {
+		value := explainValue(op.Value)
+		if info, ok := schemaFieldFor(subjectType, op.FieldName); ok && info.Encrypted {
+			value = "[redacted]"
+		}
+
+		switch op.Kind {
+		case FieldOpClear:
+			clauses = append(clauses, fmt.Sprintf("clears %s", op.FieldName))
+		case FieldOpIncrement:
+			clauses = append(clauses, fmt.Sprintf("increments %s by %s", op.FieldName, value))
+		default:
+			clauses = append(clauses, fmt.Sprintf("sets %s to %s", op.FieldName, value))
+		}
+	}
+
+	if len(clauses) == 0 {
+		return "changes nothing"
+	}
+
+	return strings.Join(clauses, ", ")
+}
+
+// explainValue formats a value as Diff does, showing a driver.Valuer such as null.String
+// as the value it writes rather than its struct.
+func explainValue(value any) string {
+	if valuer, ok := value.(driver.Valuer); ok {
+		if dbValue, err := valuer.Value(); err == nil {
+			return formatValue(dbValue)
+		}
+	}
+
+	return formatValue(value)
+}
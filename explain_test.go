@@ -0,0 +1,40 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial/test"
+	"gopkg.in/guregu/null.v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	It("describes each tracked field in the order it was tracked", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("x"),
+			test.OrganisationBuilder.OptionalStringNull(),
+		).Increment("IncidentCount", 3)
+
+		Expect(model.Explain()).To(Equal(`sets Name to "x", clears OptionalString, increments IncidentCount by 3`))
+	})
+
+	It("shows nullable values as the value they write", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.OptionalString(null.StringFrom("y")),
+		)
+
+		Expect(model.Explain()).To(Equal(`sets OptionalString to "y"`))
+	})
+
+	It("redacts encrypted fields", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.WebhookSecret("secret"),
+		)
+
+		Expect(model.Explain()).To(Equal("sets WebhookSecret to [redacted]"))
+	})
+
+	It("describes empty partials", func() {
+		Expect(test.OrganisationBuilder().Explain()).To(Equal("changes nothing"))
+	})
+})
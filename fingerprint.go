@@ -0,0 +1,42 @@
+package partial
+
+import (
+	"hash/fnv"
+	"reflect"
+)
+
+// Fingerprint returns a hash of the tracked fields and their values, like CacheKeySuffix
+// but as a number. It depends only on what the partial would write, never on the order
+// fields were tracked in or on map iteration, so fingerprints are stable across process
+// restarts and can be persisted.
+//
+// Partials that are Equal have the same fingerprint.
+func (m Partial[T]) Fingerprint() uint64 {
+	hash := fnv.New64a()
+	m.writeCanonical(hash)
+
+	return hash.Sum64()
+}
+
+// Equal returns true if both partials track the same fields, regardless of the order
+// they were tracked in, and would write the same value to each of them.
+func (m Partial[T]) Equal(other Partial[T]) bool {
+	ops, otherOps := m.Ops(), other.Ops()
+	if len(ops) != len(otherOps) {
+		return false
+	}
+
+	otherOpsByField := map[string]FieldOp{}
+	for _, op := range otherOps {
+		otherOpsByField[op.FieldName] = op
+	}
+
+	for _, op := range ops {
+		otherOp, ok := otherOpsByField[op.FieldName]
+		if !ok || op.Kind != otherOp.Kind || !reflect.DeepEqual(op.Value, otherOp.Value) {
+			return false
+		}
+	}
+
+	return true
+}
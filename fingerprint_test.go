@@ -0,0 +1,121 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial/test"
+	"github.com/incident-io/partial/test/external"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fingerprint", func() {
+	labels := map[external.VendorLabelKey]external.VendorLabel{
+		"team":    "on-call",
+		"service": "api",
+		"tier":    "one",
+		"region":  "eu",
+	}
+
+	It("is stable across processes, so can be persisted", func() {
+		model := test.VendorBuilder(
+			test.VendorBuilder.Name("PagerDuty"),
+			test.VendorBuilder.Labels(labels),
+		)
+
+		// Changing how fingerprints are computed invalidates every persisted fingerprint,
+		// so this should only ever change deliberately
+		Expect(model.Fingerprint()).To(Equal(uint64(7821542016005167412)))
+	})
+
+	It("is the same for partials that track fields in a different order", func() {
+		first := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("x"),
+			test.OrganisationBuilder.IncidentCount(3),
+		)
+		second := test.OrganisationBuilder(
+			test.OrganisationBuilder.IncidentCount(3),
+			test.OrganisationBuilder.Name("x"),
+		)
+
+		Expect(first.Fingerprint()).To(Equal(second.Fingerprint()))
+		Expect(first.Fingerprint()).NotTo(Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("y"),
+			test.OrganisationBuilder.IncidentCount(3),
+		).Fingerprint()))
+	})
+
+	It("is the same for partials that set a field more than once", func() {
+		overridden := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("x"),
+			test.OrganisationBuilder.Name("y"),
+		)
+
+		Expect(overridden.FieldNames).To(Equal([]string{"Name"}))
+		Expect(overridden.Fingerprint()).To(Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("y"),
+		).Fingerprint()))
+	})
+
+	It("is the same for partials that set a field again with Add", func() {
+		overridden := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("x"),
+		).Add(test.OrganisationBuilder.Name("y"))
+
+		Expect(overridden.FieldNames).To(Equal([]string{"Name"}))
+		Expect(overridden.Fingerprint()).To(Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("y"),
+		).Fingerprint()))
+	})
+})
+
+var _ = Describe("Equal", func() {
+	model := test.OrganisationBuilder(
+		test.OrganisationBuilder.Name("x"),
+		test.OrganisationBuilder.OptionalStringNull(),
+	)
+
+	It("ignores the order fields were tracked in", func() {
+		Expect(model.Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.OptionalStringNull(),
+			test.OrganisationBuilder.Name("x"),
+		))).To(BeTrue())
+	})
+
+	It("compares the tracked fields and their values", func() {
+		Expect(model.Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("x"),
+		))).To(BeFalse())
+		Expect(model.Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("y"),
+			test.OrganisationBuilder.OptionalStringNull(),
+		))).To(BeFalse())
+		Expect(model.Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("x"),
+			test.OrganisationBuilder.OptionalStringNull(),
+			test.OrganisationBuilder.IncidentCount(0),
+		))).To(BeFalse())
+	})
+
+	It("distinguishes incrementing a field from setting it", func() {
+		incremented := test.OrganisationBuilder().Increment("IncidentCount", 3)
+
+		Expect(incremented.Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.IncidentCount(3),
+		))).To(BeFalse())
+		Expect(incremented.Equal(test.OrganisationBuilder().Increment("IncidentCount", 3))).To(BeTrue())
+	})
+
+	It("compares the last write to fields set more than once", func() {
+		overridden := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("x"),
+			test.OrganisationBuilder.Name("y"),
+		)
+
+		Expect(overridden.Equal(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("y"),
+		))).To(BeTrue())
+		Expect(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("y"),
+		).Equal(overridden)).To(BeTrue())
+	})
+})
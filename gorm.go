@@ -0,0 +1,61 @@
+package partial
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// NewFromRows builds a model from a row loaded by the given query, tracking only the
+// columns that the query selected.
+//
+// Rows loaded with a restricted Select are missing every other column, so unlike New
+// this won't claim to know values that were never loaded. As with New, readonly fields
+// are never tracked:
+//
+//	db = db.Select("id", "name").First(&org)
+//	model, err := partial.NewFromRows(db, &org) // tracks ID and Name only
+func NewFromRows[T any](db *gorm.DB, dest *T) (model Partial[T], err error) {
+	if db.Error != nil {
+		return model, errors.Wrap(db.Error, "loading rows")
+	}
+	if err := checkSubject(dest); err != nil {
+		return model, err
+	}
+
+	stmt := db.Statement
+	if stmt.Schema == nil {
+		if err := stmt.Parse(dest); err != nil {
+			return model, errors.Wrap(err, "parsing schema")
+		}
+	}
+
+	destType := reflect.TypeOf(dest).Elem()
+	if stmt.Schema.ModelType != destType {
+		return model, errors.New(fmt.Sprintf("query was for %s, not %s", stmt.Schema.ModelType, destType))
+	}
+
+	// Columns maps each column to whether it was selected (true) or omitted (false), and
+	// restricted is true when only the selected columns were loaded.
+	columns, restricted := stmt.SelectAndOmitColumns(false, false)
+
+	fieldNames := []string{}
+	for _, field := range stmt.Schema.Fields {
+		// Fields promoted from embedded structs such as gorm.Model are tracked, but not
+		// those gorm nests with an embedded tag, which we don't know about.
+		info, ok := schemaFieldFor(destType, field.Name)
+		if field.DBName == "" || !ok || !slices.Equal(info.Index, field.StructField.Index) || info.ReadOnly {
+			continue
+		}
+
+		selected, ok := columns[field.DBName]
+		if selected || (!ok && !restricted) {
+			fieldNames = append(fieldNames, field.Name)
+		}
+	}
+
+	return newTracking(*dest, fieldNames), nil
+}
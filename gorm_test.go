@@ -0,0 +1,92 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewFromRows", func() {
+	var (
+		db       *gorm.DB
+		incident test.Incident
+		model    partial.Partial[test.Incident]
+		err      error
+	)
+
+	BeforeEach(func() {
+		db, err = gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		incident = test.Incident{
+			ID:             "id",
+			OrganisationID: "org-id",
+		}
+	})
+
+	Context("when the query selected specific columns", func() {
+		JustBeforeEach(func() {
+			model, err = partial.NewFromRows(db.Select("id", "organisation_id").Find(&incident), &incident)
+		})
+
+		It("tracks only the selected columns", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(model.FieldNames).To(ConsistOf("ID", "OrganisationID"))
+		})
+
+		It("applies values from the loaded row", func() {
+			Expect(model.Apply(test.Incident{})).To(test.IncidentMatcher(
+				test.IncidentMatcher.ID("id"),
+				test.IncidentMatcher.OrganisationID("org-id"),
+			))
+		})
+	})
+
+	Context("when the query omitted columns", func() {
+		JustBeforeEach(func() {
+			model, err = partial.NewFromRows(db.Omit("created_at").Find(&incident), &incident)
+		})
+
+		It("tracks every column except those omitted", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(model.FieldNames).To(ConsistOf("ID", "OrganisationID"))
+		})
+	})
+
+	Context("when the query selected everything", func() {
+		JustBeforeEach(func() {
+			model, err = partial.NewFromRows(db.Find(&incident), &incident)
+		})
+
+		It("tracks every column", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(model.FieldNames).To(ConsistOf("ID", "OrganisationID", "CreatedAt"))
+		})
+
+		It("won't write the immutable columns back", func() {
+			_, err := model.ToDBMap()
+			Expect(err).To(MatchError("field ID on Incident is immutable and cannot be updated"))
+
+			columns, err := model.Without("ID", "CreatedAt").ToDBMap()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns).To(Equal(map[string]any{"organisation_id": "org-id"}))
+		})
+	})
+})
+
+var _ = Describe("NewFromRows with embedded gorm.Model", func() {
+	It("tracks the columns promoted from it", func() {
+		db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		subject := gormModel{Model: gorm.Model{ID: 1}, Name: "name"}
+		model, err := partial.NewFromRows(db.Select("id", "name").Find(&subject), &subject)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(model.FieldNames).To(ConsistOf("ID", "Name"))
+	})
+})
@@ -0,0 +1,100 @@
+package partial
+
+import (
+	"reflect"
+	"sync"
+)
+
+// buildHook is a function registered with OnBuild. It's stored behind a pointer so we can
+// find it again when removing it.
+type buildHook struct {
+	run func(model any) // model is a *Partial[T]
+}
+
+var (
+	buildHooksMu sync.RWMutex
+	buildHooks   = map[reflect.Type][]*buildHook{}
+)
+
+// OnBuild registers a hook that runs whenever a generated builder for T finishes building
+// a partial, such as to populate an OrganisationID from ambient test context, or stamp a
+// trace ID:
+//
+//	remove := partial.OnBuild[Incident](func(model *partial.Partial[Incident]) {
+//		if !model.Tracks("OrganisationID") {
+//			*model = model.Add(IncidentBuilder.OrganisationID(currentOrganisationID))
+//		}
+//	})
+//	defer remove()
+//
+// Hooks run in the order they were registered, after all setters have been applied.
+// Calling the returned function removes the hook.
+func OnBuild[T any](hook func(model *Partial[T])) (remove func()) {
+	subjectType := reflect.TypeOf((*T)(nil)).Elem()
+	registered := &buildHook{
+		run: func(model any) {
+			hook(model.(*Partial[T]))
+		},
+	}
+
+	buildHooksMu.Lock()
+	defer buildHooksMu.Unlock()
+
+	buildHooks[subjectType] = append(buildHooks[subjectType], registered)
+
+	return func() {
+		buildHooksMu.Lock()
+		defer buildHooksMu.Unlock()
+
+		hooks := []*buildHook{}
+		for _, existing := range buildHooks[subjectType] {
+			if existing != registered {
+				hooks = append(hooks, existing)
+			}
+		}
+		buildHooks[subjectType] = hooks
+	}
+}
+
+// RunBuildHooks is called by generated builders once they've built a partial, running
+// every hook registered with OnBuild for T.
+func RunBuildHooks[T any](model *Partial[T]) {
+	buildHooksMu.RLock()
+	hooks := buildHooks[reflect.TypeOf(model.Subject)]
+	buildHooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook.run(model)
+	}
+}
+
+// Tracks returns true if the partial tracks the named field.
+func (m Partial[T]) Tracks(fieldName string) bool {
+	return contains(m.FieldNames, fieldName)
+}
+
+// Before returns a Partial that calls intercept with the record being patched before
+// Apply sets any fields, such as to reset state that every update should clear.
+// Interceptors run in the order they were added, and carry over to partials derived
+// from this one, such as through Merge or Without.
+func (m Partial[T]) Before(intercept func(*T)) Partial[T] {
+	m.before = append(append([]func(*T){}, m.before...), intercept)
+
+	return m
+}
+
+// After returns a Partial that calls intercept with the patched record once Apply has
+// set every field and recomputed derived fields. Use this for invariants that hold
+// whatever was changed, such as a checksum over the whole record:
+//
+//	model = model.After(func(incident *Incident) {
+//		incident.Checksum = incident.ComputeChecksum()
+//	})
+//
+// Unlike a derived field, values set here aren't tracked, so must be persisted some
+// other way if they need saving.
+func (m Partial[T]) After(intercept func(*T)) Partial[T] {
+	m.after = append(append([]func(*T){}, m.after...), intercept)
+
+	return m
+}
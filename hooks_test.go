@@ -0,0 +1,108 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OnBuild", func() {
+	var (
+		remove func()
+	)
+
+	BeforeEach(func() {
+		remove = partial.OnBuild(func(model *partial.Partial[test.Incident]) {
+			if !model.Tracks("OrganisationID") {
+				*model = model.Add(test.IncidentBuilder.OrganisationID("ambient-org-id"))
+			}
+		})
+	})
+
+	AfterEach(func() {
+		remove()
+	})
+
+	It("runs hooks when a builder finishes", func() {
+		model := test.IncidentBuilder()
+
+		Expect(model.FieldNames).To(ConsistOf("OrganisationID"))
+		Expect(model.Apply(test.Incident{})).To(test.IncidentMatcher(
+			test.IncidentMatcher.OrganisationID("ambient-org-id"),
+		))
+	})
+
+	It("lets hooks see what the setters tracked", func() {
+		model := test.IncidentBuilder(
+			test.IncidentBuilder.OrganisationID("explicit-org-id"),
+		)
+
+		Expect(model.Subject.OrganisationID).To(Equal("explicit-org-id"))
+	})
+
+	It("only runs hooks for the registered type", func() {
+		Expect(test.OrganisationBuilder().FieldNames).To(BeEmpty())
+	})
+
+	It("stops running hooks once removed", func() {
+		remove()
+		Expect(test.IncidentBuilder().FieldNames).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Interceptors", func() {
+	var calls []string
+
+	BeforeEach(func() {
+		calls = []string{}
+	})
+
+	before := func(org *test.Organisation) {
+		calls = append(calls, "before:"+org.Name)
+	}
+	after := func(org *test.Organisation) {
+		calls = append(calls, "after:"+org.Name)
+		org.IncidentCount = len(org.Name)
+	}
+
+	It("runs Before interceptors before setting fields, and After interceptors after", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+		).Before(before).After(after)
+
+		Expect(model.Apply(test.Organisation{Name: "Old"})).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.Name("My Org"),
+			test.OrganisationMatcher.IncidentCount(6),
+		))
+		Expect(calls).To(Equal([]string{"before:Old", "after:My Org"}))
+	})
+
+	It("keeps After interceptors last when fields are added later", func() {
+		model := test.OrganisationBuilder().After(after).Add(
+			test.OrganisationBuilder.Name("Renamed"),
+		)
+
+		Expect(model.Apply(test.Organisation{}).IncidentCount).To(Equal(7))
+	})
+
+	It("carries interceptors through Merge and Without", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+		).After(after).Merge(test.OrganisationBuilder().Before(before)).Without("BoolFlag")
+
+		model.Apply(test.Organisation{Name: "Old"})
+
+		Expect(calls).To(Equal([]string{"before:Old", "after:My Org"}))
+	})
+
+	It("doesn't change the partial it was added to", func() {
+		model := test.OrganisationBuilder()
+		model.After(after).Apply(test.Organisation{})
+
+		model.Apply(test.Organisation{})
+
+		Expect(calls).To(Equal([]string{"after:"}))
+	})
+})
@@ -0,0 +1,133 @@
+package partial
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/pkg/errors"
+)
+
+var instantMatchingEnabled int32
+
+// EnableInstantMatching makes Match, Prune and generated matchers consider two time.Time values
+// equal when they represent the same instant, even in different locations. Times read
+// back from Postgres are often in a different location to those that were written, which
+// otherwise makes idempotency checks report a change where there isn't one.
+func EnableInstantMatching() {
+	atomic.StoreInt32(&instantMatchingEnabled, 1)
+}
+
+// DisableInstantMatching goes back to comparing time.Time values with reflect.DeepEqual,
+// which requires them to have the same location.
+func DisableInstantMatching() {
+	atomic.StoreInt32(&instantMatchingEnabled, 0)
+}
+
+// valuesEqual compares two values of the same type, as reflect.DeepEqual does unless
+// instant matching is enabled.
+func valuesEqual(a, b reflect.Value) bool {
+	if atomic.LoadInt32(&instantMatchingEnabled) == 0 {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+
+	return instantsEqual(a, b)
+}
+
+// instantsEqual compares two values of the same type like reflect.DeepEqual, except that
+// any time.Time within them is compared with time.Time.Equal.
+func instantsEqual(a, b reflect.Value) bool {
+	if a.Type() == timeType && a.CanInterface() {
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Kind() == reflect.Interface && a.Elem().Type() != b.Elem().Type() {
+			return false
+		}
+
+		return instantsEqual(a.Elem(), b.Elem())
+
+	case reflect.Struct:
+		for idx := 0; idx < a.NumField(); idx++ {
+			if !instantsEqual(a.Field(idx), b.Field(idx)) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+		for idx := 0; idx < a.Len(); idx++ {
+			if !instantsEqual(a.Index(idx), b.Index(idx)) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+		for _, key := range a.MapKeys() {
+			value := b.MapIndex(key)
+			if !value.IsValid() || !instantsEqual(a.MapIndex(key), value) {
+				return false
+			}
+		}
+
+		return true
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return a.IsNil() && b.IsNil() || a.Pointer() == b.Pointer()
+	}
+
+	return a.Equal(b)
+}
+
+// Equal is used by generated matchers to compare fields against an expected value. This
+// is gomega.Equal, unless instant matching is enabled when the matcher is built.
+func Equal(expected any) types.GomegaMatcher {
+	if atomic.LoadInt32(&instantMatchingEnabled) == 0 {
+		return gomega.Equal(expected)
+	}
+
+	return &instantMatcher{expected: expected}
+}
+
+// instantMatcher is gomega.Equal, comparing any time.Time values by instant.
+type instantMatcher struct {
+	expected any
+}
+
+func (m *instantMatcher) Match(actual any) (bool, error) {
+	if actual == nil && m.expected == nil {
+		return false, errors.New("refusing to compare <nil> to <nil>, use BeNil() instead")
+	}
+
+	actualValue, expectedValue := reflect.ValueOf(actual), reflect.ValueOf(m.expected)
+	if !actualValue.IsValid() || !expectedValue.IsValid() || actualValue.Type() != expectedValue.Type() {
+		return false, nil
+	}
+
+	return instantsEqual(actualValue, expectedValue), nil
+}
+
+func (m *instantMatcher) FailureMessage(actual any) string {
+	return format.Message(actual, "to equal, comparing times by instant", m.expected)
+}
+
+func (m *instantMatcher) NegatedFailureMessage(actual any) string {
+	return format.Message(actual, "not to equal, comparing times by instant", m.expected)
+}
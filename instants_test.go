@@ -0,0 +1,67 @@
+package partial_test
+
+import (
+	"time"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+	"gopkg.in/guregu/null.v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Instant matching", func() {
+	var (
+		utc   = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		local = utc.In(time.FixedZone("BST", 60*60))
+	)
+
+	AfterEach(func() {
+		partial.DisableInstantMatching()
+	})
+
+	It("compares times by location unless enabled", func() {
+		model := test.ActionBuilder(test.ActionBuilder.Timestamps().DueAt(null.TimeFrom(utc)))
+
+		Expect(model.Match(&test.Action{DueAt: null.TimeFrom(local)})).To(BeFalse())
+
+		partial.EnableInstantMatching()
+		Expect(model.Match(&test.Action{DueAt: null.TimeFrom(local)})).To(BeTrue())
+		Expect(model.Match(&test.Action{DueAt: null.TimeFrom(local.Add(time.Second))})).To(BeFalse())
+	})
+
+	It("compares times by instant in generated matchers", func() {
+		partial.EnableInstantMatching()
+
+		Expect(&test.Incident{CreatedAt: local}).To(test.IncidentMatcher(
+			test.IncidentMatcher.CreatedAt(utc),
+		))
+		Expect(&test.Incident{CreatedAt: local.Add(time.Second)}).NotTo(test.IncidentMatcher(
+			test.IncidentMatcher.CreatedAt(utc),
+		))
+	})
+
+	It("prunes times at the same instant in a different location", func() {
+		model := test.ActionBuilder(
+			test.ActionBuilder.ID("action-id"),
+			test.ActionBuilder.Timestamps().DueAt(null.TimeFrom(utc)),
+		)
+		base := test.Action{DueAt: null.TimeFrom(local)}
+
+		Expect(model.Prune(base).FieldNames).To(ConsistOf("ID", "DueAt"))
+
+		partial.EnableInstantMatching()
+		Expect(model.Prune(base).FieldNames).To(ConsistOf("ID"))
+		Expect(model.Prune(test.Action{DueAt: null.TimeFrom(local.Add(time.Second))}).FieldNames).To(ConsistOf("ID", "DueAt"))
+	})
+
+	It("compares everything else as gomega.Equal does", func() {
+		partial.EnableInstantMatching()
+
+		Expect([]string{"a"}).To(partial.Equal([]string{"a"}))
+		Expect([]string{"a"}).NotTo(partial.Equal([]string{"b"}))
+		Expect(map[string]int{"a": 1}).NotTo(partial.Equal(map[string]int64{"a": 1}))
+		Expect(&test.Action{}).To(partial.Equal(&test.Action{}))
+	})
+})
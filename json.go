@@ -0,0 +1,83 @@
+package partial
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// NewFromMergePatch builds a partial from a JSON merge patch (RFC 7396), tracking each
+// field named by a key in the patch. Keys are matched against the JSON names of T's
+// fields, and null values clear the field to its zero value.
+//
+// Nested objects replace the field entirely, rather than being merged into the existing
+// value.
+func NewFromMergePatch[T any](patch []byte) (Partial[T], error) {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &keys); err != nil {
+		return Partial[T]{}, errors.Wrap(err, "parsing merge patch")
+	}
+
+	var subject T
+	if err := checkSubject(&subject); err != nil {
+		return Partial[T]{}, err
+	}
+	subjectType := reflect.TypeOf(subject)
+
+	for key := range keys {
+		if _, ok := schemaFieldForJSONName(subjectType, key); !ok {
+			return Partial[T]{}, errors.New(fmt.Sprintf("merge patch key %s is not a field on %s", key, subjectType.Name()))
+		}
+	}
+
+	fieldNames := []string{}
+	for _, field := range schemaFor(subjectType) {
+		value, ok := keys[field.JSONName]
+		if !ok || !field.DatabaseBacked() {
+			continue
+		}
+
+		if string(value) != "null" {
+			target := reflect.ValueOf(&subject).Elem().FieldByIndex(field.Index).Addr().Interface()
+			if err := json.Unmarshal(value, target); err != nil {
+				return Partial[T]{}, errors.Wrap(err, fmt.Sprintf("parsing merge patch key %s", field.JSONName))
+			}
+		}
+
+		fieldNames = append(fieldNames, field.Name)
+	}
+
+	return newTracking(subject, fieldNames), nil
+}
+
+// schemaFieldForJSONName finds the database-backed field with the given JSON name.
+func schemaFieldForJSONName(subjectType reflect.Type, jsonName string) (fieldInfo, bool) {
+	for _, field := range schemaFor(subjectType) {
+		if field.DatabaseBacked() && field.JSONName == jsonName {
+			return field, true
+		}
+	}
+
+	return fieldInfo{}, false
+}
+
+// JSONFieldNames returns the JSON names of the tracked fields in the order they were
+// tracked, such as for an API to tell its caller which fields an update changed. Fields
+// that aren't serialised to JSON are left out.
+func (m Partial[T]) JSONFieldNames() []string {
+	subjectType := reflect.TypeOf(m.Subject)
+
+	jsonNames := []string{}
+	for _, fieldName := range m.FieldNames {
+		field, ok := schemaFieldFor(subjectType, fieldName)
+		if !ok || field.JSONName == "" || contains(jsonNames, field.JSONName) {
+			continue
+		}
+
+		jsonNames = append(jsonNames, field.JSONName)
+	}
+
+	return jsonNames
+}
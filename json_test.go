@@ -0,0 +1,66 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewFromMergePatch", func() {
+	It("tracks each field in the patch", func() {
+		model, err := partial.NewFromMergePatch[test.Organisation]([]byte(`{"name":"My Org","bool_flag":true}`))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(model.FieldNames).To(Equal([]string{"Name", "BoolFlag"}))
+		Expect(model.Apply(test.Organisation{ID: "org-id"})).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.ID("org-id"),
+			test.OrganisationMatcher.Name("My Org"),
+			test.OrganisationMatcher.BoolFlag(true),
+		))
+	})
+
+	It("clears fields set to null", func() {
+		model, err := partial.NewFromMergePatch[test.Organisation]([]byte(`{"name":null}`))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(model.Apply(test.Organisation{Name: "My Org"}).Name).To(Equal(""))
+	})
+
+	It("tracks derived fields", func() {
+		model, err := partial.NewFromMergePatch[test.Action]([]byte(`{"description":"Restart"}`))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(model.FieldNames).To(ConsistOf("Description", "SearchText"))
+	})
+
+	It("errors on unknown keys", func() {
+		_, err := partial.NewFromMergePatch[test.Organisation]([]byte(`{"latest_incident":{}}`))
+
+		Expect(err).To(MatchError(ContainSubstring("latest_incident is not a field on Organisation")))
+	})
+
+	It("errors on values of the wrong type", func() {
+		_, err := partial.NewFromMergePatch[test.Organisation]([]byte(`{"name":3}`))
+
+		Expect(err).To(MatchError(ContainSubstring("parsing merge patch key name")))
+	})
+})
+
+var _ = Describe("JSONFieldNames", func() {
+	It("returns the JSON names of tracked fields, in order", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+			test.OrganisationBuilder.LatestIncident(&test.Incident{ID: "incident-id"}),
+			test.OrganisationBuilder.IncidentCount(3),
+			test.OrganisationBuilder.Name("Renamed"),
+		)
+
+		Expect(model.JSONFieldNames()).To(Equal([]string{"name", "incident_count"}))
+	})
+
+	It("is empty for an empty partial", func() {
+		Expect(test.IncidentBuilder().JSONFieldNames()).To(BeEmpty())
+	})
+})
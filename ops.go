@@ -0,0 +1,193 @@
+package partial
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// FieldOpKind is how a tracked field should be written.
+type FieldOpKind string
+
+const (
+	FieldOpSet       FieldOpKind = "set"       // write Value to the field
+	FieldOpClear     FieldOpKind = "clear"     // write null to the field
+	FieldOpIncrement FieldOpKind = "increment" // add Value to the existing value of the field
+)
+
+// FieldOp describes a single write to a tracked field, which is the stable
+// representation of a Partial for persistence adapters that can't use gorm, such as
+// Mongo, DynamoDB or ElasticSearch.
+type FieldOp struct {
+	FieldName string      // OrganisationID
+	JSONName  string      // organisation_id, empty if the field isn't serialised
+	Kind      FieldOpKind // set
+	Value     any         // the value to set, or the amount to increment by
+}
+
+// Ops returns the write for each tracked field, in the order they were tracked.
+//
+// Fields holding a nil pointer, slice or map, or a driver.Valuer such as null.String
+// whose database value is nil, are cleared rather than set. Encrypted fields hold their
+// plaintext, so adapters writing to storage should use EncryptedOps instead.
+func (m Partial[T]) Ops() []FieldOp {
+	subjectType, subjectValue := reflect.TypeOf(m.Subject), reflect.ValueOf(m.Subject)
+
+	ops := []FieldOp{}
+	for _, fieldName := range m.FieldNames {
+		field := subjectValue.FieldByName(fieldName)
+		if !field.IsValid() {
+			continue
+		}
+
+		op := FieldOp{
+			FieldName: fieldName,
+			Kind:      FieldOpSet,
+			Value:     field.Interface(),
+		}
+		if info, ok := schemaFieldFor(subjectType, fieldName); ok {
+			op.JSONName = info.JSONName
+		}
+
+		switch {
+		case m.increments[fieldName]:
+			op.Kind = FieldOpIncrement
+		case isNull(field):
+			op.Kind, op.Value = FieldOpClear, nil
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops
+}
+
+// isNull returns true if the value would be written to the database as null.
+func isNull(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		if value.IsNil() {
+			return true
+		}
+	}
+
+	if valuer, ok := value.Interface().(driver.Valuer); ok {
+		dbValue, err := valuer.Value()
+		return err == nil && dbValue == nil
+	}
+
+	return false
+}
+
+// Increment returns a new Partial that adds delta to the named numeric field when
+// applied, rather than setting it. This is reported as an increment by Ops, so adapters
+// can perform it atomically.
+//
+// If the field is already set by this Partial, the delta is added to the value it sets.
+// Incrementing a field that isn't numeric fails the Partial, as reported by Err.
+func (m Partial[T]) Increment(fieldName string, delta any) Partial[T] {
+	subjectType := reflect.TypeOf(m.Subject)
+
+	field, ok := subjectType.FieldByName(fieldName)
+	if !ok || !isNumeric(field.Type.Kind()) {
+		if m.err == nil {
+			m.err = errors.New(fmt.Sprintf("cannot increment field %s on %s, as it is not numeric", fieldName, subjectType.Name()))
+		}
+
+		return m
+	}
+
+	deltaValue := reflect.ValueOf(delta)
+	if !deltaValue.IsValid() || !isNumeric(deltaValue.Kind()) {
+		if m.err == nil {
+			m.err = errors.New(fmt.Sprintf("cannot increment field %s on %s by %v", fieldName, subjectType.Name(), delta))
+		}
+
+		return m
+	}
+	deltaValue = deltaValue.Convert(field.Type)
+
+	subjectField := reflect.ValueOf(&m.Subject).Elem().FieldByName(fieldName)
+	if !contains(m.FieldNames, fieldName) {
+		subjectField.Set(reflect.Zero(field.Type))
+		m.FieldNames = append(append([]string{}, m.FieldNames...), fieldName)
+		m.increments = copyIncrements(m.increments, fieldName)
+	}
+	addNumeric(subjectField, deltaValue)
+
+	apply := m.apply
+	m.apply = func(base T) *T {
+		patched := apply(base)
+		addNumeric(reflect.ValueOf(patched).Elem().FieldByName(fieldName), deltaValue)
+
+		return patched
+	}
+
+	return m
+}
+
+// copyIncrements returns a copy of increments including the given fields, so we never
+// modify the map of another Partial.
+func copyIncrements(increments map[string]bool, fieldNames ...string) map[string]bool {
+	copied := map[string]bool{}
+	for fieldName, incremented := range increments {
+		if incremented {
+			copied[fieldName] = true
+		}
+	}
+	for _, fieldName := range fieldNames {
+		copied[fieldName] = true
+	}
+
+	return copied
+}
+
+// withoutIncrements returns a copy of increments excluding the given fields.
+func withoutIncrements(increments map[string]bool, fieldNames []string) map[string]bool {
+	if len(increments) == 0 {
+		return increments
+	}
+
+	copied := copyIncrements(increments)
+	for _, fieldName := range fieldNames {
+		delete(copied, fieldName)
+	}
+
+	return copied
+}
+
+// incrementFields sets each of the named fields on subject to their value in base, plus
+// their value in delta.
+func incrementFields[T any](subject *T, base T, delta T, fieldNames []string) {
+	subjectValue, baseValue, deltaValue := reflect.ValueOf(subject).Elem(), reflect.ValueOf(base), reflect.ValueOf(delta)
+	for _, fieldName := range fieldNames {
+		field := subjectValue.FieldByName(fieldName)
+		field.Set(baseValue.FieldByName(fieldName))
+		addNumeric(field, deltaValue.FieldByName(fieldName))
+	}
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// addNumeric adds delta to target, which must both be numeric values of the same type.
+func addNumeric(target, delta reflect.Value) {
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		target.SetInt(target.Int() + delta.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		target.SetUint(target.Uint() + delta.Uint())
+	case reflect.Float32, reflect.Float64:
+		target.SetFloat(target.Float() + delta.Float())
+	}
+}
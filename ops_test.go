@@ -0,0 +1,97 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+	"gopkg.in/guregu/null.v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ops", func() {
+	It("describes each tracked field in order", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("name"),
+			test.OrganisationBuilder.OptionalStringNull(),
+		).Increment("IncidentCount", 2)
+
+		Expect(model.Ops()).To(Equal([]partial.FieldOp{
+			{FieldName: "Name", JSONName: "name", Kind: partial.FieldOpSet, Value: "name"},
+			{FieldName: "OptionalString", JSONName: "optional_string", Kind: partial.FieldOpClear},
+			{FieldName: "IncidentCount", JSONName: "incident_count", Kind: partial.FieldOpIncrement, Value: 2},
+		}))
+	})
+
+	It("sets fields with valid null values", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.OptionalString(null.StringFrom("value")),
+		)
+
+		Expect(model.Ops()[0].Kind).To(Equal(partial.FieldOpSet))
+	})
+})
+
+var _ = Describe("Increment", func() {
+	var (
+		model partial.Partial[test.Organisation]
+	)
+
+	BeforeEach(func() {
+		model = test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("name"),
+		).Increment("IncidentCount", 2)
+	})
+
+	It("adds to the base when applied", func() {
+		Expect(model.Apply(test.Organisation{IncidentCount: 3})).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.Name("name"),
+			test.OrganisationMatcher.IncidentCount(5),
+		))
+	})
+
+	It("adds to the value when the field is already set", func() {
+		model = test.OrganisationBuilder(
+			test.OrganisationBuilder.IncidentCount(10),
+		).Increment("IncidentCount", 2)
+
+		Expect(model.Ops()[0].Kind).To(Equal(partial.FieldOpSet))
+		Expect(model.Apply(test.Organisation{IncidentCount: 3}).IncidentCount).To(Equal(12))
+	})
+
+	It("never matches unless the delta is zero", func() {
+		Expect(model.Match(&test.Organisation{Name: "name", IncidentCount: 2})).To(BeFalse())
+	})
+
+	It("is kept by Prune", func() {
+		pruned := model.Prune(test.Organisation{Name: "name"})
+		Expect(pruned.Ops()).To(Equal([]partial.FieldOp{
+			{FieldName: "IncidentCount", JSONName: "incident_count", Kind: partial.FieldOpIncrement, Value: 2},
+		}))
+		Expect(pruned.Apply(test.Organisation{IncidentCount: 1}).IncidentCount).To(Equal(3))
+	})
+
+	It("is kept by Merge", func() {
+		merged := test.OrganisationBuilder(
+			test.OrganisationBuilder.IncidentCount(10),
+		).Merge(model)
+
+		Expect(merged.Apply(test.Organisation{IncidentCount: 3}).IncidentCount).To(Equal(5))
+		Expect(merged.Ops()).To(ContainElement(partial.FieldOp{
+			FieldName: "IncidentCount", JSONName: "incident_count", Kind: partial.FieldOpIncrement, Value: 2,
+		}))
+	})
+
+	It("is replaced by later setters", func() {
+		model = model.Add(test.OrganisationBuilder.IncidentCount(1))
+
+		Expect(model.Ops()).To(ContainElement(partial.FieldOp{
+			FieldName: "IncidentCount", JSONName: "incident_count", Kind: partial.FieldOpSet, Value: 1,
+		}))
+		Expect(model.Apply(test.Organisation{IncidentCount: 3}).IncidentCount).To(Equal(1))
+	})
+
+	It("fails for fields that aren't numeric", func() {
+		Expect(model.Increment("Name", 1).Err()).To(MatchError("cannot increment field Name on Organisation, as it is not numeric"))
+	})
+})
@@ -0,0 +1,121 @@
+package partial
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Option sets fields of a T, returning the names of the fields it set. Generated setters
+// return one, named for the type, such as IncidentOption.
+type Option[T any] func(*T) []string
+
+// FieldNames returns the fields the option sets, found by applying it to a zero T, so
+// options can be inspected before they're used. Options built with Fail set none.
+func (o Option[T]) FieldNames() []string {
+	var subject T
+	fieldNames, err := applyOption(&subject, o)
+	if err != nil {
+		return nil
+	}
+
+	return fieldNames
+}
+
+// String describes the option by the fields it sets, for logging, such as
+// Option[Incident](Name, Summary).
+func (o Option[T]) String() string {
+	return fmt.Sprintf("Option[%s](%s)", reflect.TypeOf((*T)(nil)).Elem().Name(), strings.Join(o.FieldNames(), ", "))
+}
+
+// Compose returns a single option applying each of opts in turn, such as for helpers
+// that bundle setters commonly used together:
+//
+//	func Resolved(at time.Time) IncidentOption {
+//		return partial.Compose(
+//			IncidentBuilder.Status("resolved"),
+//			IncidentBuilder.ResolvedAt(at),
+//		)
+//	}
+func Compose[T any](opts ...Option[T]) Option[T] {
+	return func(subject *T) []string {
+		fieldNames := []string{}
+		for _, opt := range opts {
+			fieldNames = append(fieldNames, opt(subject)...)
+		}
+
+		return fieldNames
+	}
+}
+
+// failedOption is the panic value of options built with Fail, which ApplyOptions
+// recovers into an error.
+type failedOption struct {
+	err error
+}
+
+// Fail returns an option that fails with the given error when applied, for options that
+// can't produce a value, such as generated setters whose input fails to parse. Builders
+// and Add skip the option, and report the error from Err on the resulting Partial.
+func Fail[T any](err error) Option[T] {
+	return func(*T) []string {
+		panic(failedOption{err: err})
+	}
+}
+
+// ApplyOptions is called by generated builders to apply each option to the subject in
+// turn, returning the fields they set, each only once, and the first error from any
+// options built with Fail.
+func ApplyOptions[T any](subject *T, opts []Option[T]) (fieldNames []string, err error) {
+	// Most options set a single field, so this is usually all the room we need.
+	fieldNames = make([]string, 0, len(opts))
+	for _, opt := range opts {
+		optFieldNames, optErr := applyOption(subject, opt)
+		if optErr != nil {
+			if err == nil {
+				err = optErr
+			}
+
+			continue
+		}
+
+		// Options overriding a default, or each other, set the same field again, which
+		// is still only tracked once
+		for _, fieldName := range optFieldNames {
+			if !contains(fieldNames, fieldName) {
+				fieldNames = append(fieldNames, fieldName)
+			}
+		}
+	}
+
+	return fieldNames, err
+}
+
+// applyOption applies a single option, recovering the failure of options built with Fail
+// into an error. Any other panic is left alone.
+func applyOption[T any](subject *T, opt Option[T]) (fieldNames []string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			failed, ok := r.(failedOption)
+			if !ok {
+				panic(r)
+			}
+
+			err = failed.err
+		}
+	}()
+
+	return opt(subject), nil
+}
+
+// Err returns the first error from any option that failed while building this Partial,
+// such as a setter given a value it couldn't parse. Failed options are skipped, so the
+// Partial should not be used if this is non-nil.
+func (m Partial[T]) Err() error {
+	return m.err
+}
+
+// SetErr is used by generated builders to record an error from ApplyOptions.
+func (m *Partial[T]) SetErr(err error) {
+	m.err = err
+}
@@ -0,0 +1,93 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Failed options", func() {
+	Describe("setters that parse their input", func() {
+		It("converts the friendly input type", func() {
+			model := test.ActionBuilder(
+				test.ActionBuilder.Severity("high"),
+			)
+
+			Expect(model.Err()).NotTo(HaveOccurred())
+			Expect(model.FieldNames).To(ConsistOf("Severity"))
+			Expect(model.Subject.Severity).To(Equal(test.SeverityHigh))
+		})
+
+		It("reports conversion errors through Err", func() {
+			model := test.ActionBuilder(
+				test.ActionBuilder.Description("Restart"),
+				test.ActionBuilder.Severity("apocalyptic"),
+			)
+
+			Expect(model.Err()).To(MatchError("parsing Severity: unrecognised severity: apocalyptic"))
+			Expect(model.FieldNames).NotTo(ContainElement("Severity"))
+		})
+	})
+
+	It("records errors from options added later", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("name"),
+		).Add(partial.Fail[test.Organisation](errors.New("oops")))
+
+		Expect(model.Err()).To(MatchError("oops"))
+		Expect(model.FieldNames).To(ConsistOf("Name"))
+	})
+
+	It("keeps errors when merging", func() {
+		failed := test.OrganisationBuilder(
+			partial.Fail[test.Organisation](errors.New("oops")),
+		)
+
+		Expect(test.OrganisationBuilder().Merge(failed).Err()).To(MatchError("oops"))
+	})
+
+	It("leaves other panics alone", func() {
+		Expect(func() {
+			test.OrganisationBuilder(func(*test.Organisation) []string {
+				panic("unrelated")
+			})
+		}).To(PanicWith("unrelated"))
+	})
+})
+
+var _ = Describe("Option", func() {
+	It("reports the fields it sets", func() {
+		opt := test.ActionBuilder.Timestamps().DueAtNull()
+
+		Expect(opt.FieldNames()).To(Equal([]string{"DueAt"}))
+		Expect(partial.Fail[test.Action](errors.New("oops")).FieldNames()).To(BeEmpty())
+	})
+
+	It("describes itself for logging", func() {
+		opt := test.OrganisationBuilder.From(test.Organisation{})
+
+		Expect(opt.String()).To(Equal("Option[Organisation](ID, Name, OptionalString, BoolFlag, IncidentCount, SigningKey, LogoDigest, WebhookSecret)"))
+	})
+
+	It("composes options into one", func() {
+		opt := partial.Compose(
+			test.OrganisationBuilder.Name("name"),
+			test.OrganisationBuilder.IncidentCount(3),
+		)
+
+		Expect(opt.FieldNames()).To(Equal([]string{"Name", "IncidentCount"}))
+		Expect(test.OrganisationBuilder(opt).Subject).To(Equal(test.Organisation{Name: "name", IncidentCount: 3}))
+	})
+
+	It("fails composed options when any of them fail", func() {
+		model := test.OrganisationBuilder(partial.Compose(
+			test.OrganisationBuilder.Name("name"),
+			partial.Fail[test.Organisation](errors.New("oops")),
+		))
+
+		Expect(model.Err()).To(MatchError("oops"))
+	})
+})
@@ -1,44 +1,58 @@
 package partial
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
+
+	"github.com/pkg/errors"
 )
 
-// New builds a model from a domain object, tracking all the JSON fields of the model.
+// New builds a model from a domain object, tracking all the JSON fields of the model
+// other than those tagged `partial:"readonly"`, which can never be written back.
 //
 // This should be used only for objects loaded from the database, where we know all the
 // fields are populated correctly. It should not be used with user constructed domain
 // objects, as those should be built directly into Partial's using their codegen'd
 // builders.
 func New[T any](subjectPtr *T) (model Partial[T], err error) {
-	if err != nil {
+	if err := checkSubject(subjectPtr); err != nil {
 		return model, err
 	}
 
-	base := *subjectPtr
-	model = Partial[T]{
+	fieldNames := []string{}
+	for _, field := range schemaFor(reflect.TypeOf(subjectPtr).Elem()) {
+		if field.DatabaseBacked() && !field.ReadOnly {
+			fieldNames = append(fieldNames, field.Name)
+		}
+	}
+
+	return newTracking(*subjectPtr, fieldNames), nil
+}
+
+// newTracking builds a Partial that tracks the given fields, taking their values from
+// base.
+func newTracking[T any](base T, fieldNames []string) Partial[T] {
+	model := Partial[T]{
 		FieldNames: []string{},
 		apply: func(thing T) *T {
 			return &thing
 		},
 	}
 
-	model = model.Add(func(subject *T) []string {
-		fieldNames := []string{}
-		subjectType := reflect.TypeOf(subject).Elem()
-		for idx := 0; idx < subjectType.NumField(); idx++ {
-			field := subjectType.Field(idx)
-
-			fieldNames = append(fieldNames, field.Name)
-			reflect.ValueOf(subject).Elem().FieldByIndex([]int{idx}).Set(
-				reflect.ValueOf(base).FieldByIndex([]int{idx}),
-			)
-		}
+	return model.Add(func(subject *T) []string {
+		copyFields(subject, base, fieldNames)
 
 		return fieldNames
 	})
+}
 
-	return model, nil
+// copyFields sets each of the named fields on subject to their value in source.
+func copyFields[T any](subject *T, source T, fieldNames []string) {
+	subjectValue, sourceValue := reflect.ValueOf(subject).Elem(), reflect.ValueOf(source)
+	for _, fieldName := range fieldNames {
+		subjectValue.FieldByName(fieldName).Set(sourceValue.FieldByName(fieldName))
+	}
 }
 
 // Partial wraps a domain object of type T, and maintains a list of columns that have
@@ -51,6 +65,11 @@ type Partial[T any] struct {
 	Subject    T
 	FieldNames []string `json:"-"`
 	apply      func(T) *T
+	err        error
+	increments map[string]bool  // fields that are incremented, rather than set
+	before     []func(*T)       // interceptors run by Apply before setting fields
+	after      []func(*T)       // interceptors run by Apply once fields are set
+	buffer     *fieldNameBuffer // claims on the spare capacity of FieldNames, for Add
 }
 
 func (m Partial[T]) Empty() bool {
@@ -61,8 +80,36 @@ func (m *Partial[T]) SetApply(apply func(T) *T) {
 	m.apply = apply
 }
 
+// Apply sets each tracked field on base, recomputing any tracked derived fields from the
+// result. Interceptors added with Before run first, and those added with After run last.
+//
+// Once an encryptor has been registered, encrypted fields of the result are encrypted,
+// as it's on its way to being written. Apply panics if that fails, such as when the
+// encryptor errors, so use ApplyEncrypted where that should be handled.
 func (m Partial[T]) Apply(base T) *T {
-	return m.apply(base)
+	patched := m.applyPlaintext(base)
+	if err := encryptIfRegistered(patched); err != nil {
+		panic(fmt.Sprintf("partial: encrypting the result of Apply: %s", err))
+	}
+
+	return patched
+}
+
+// applyPlaintext applies the partial like Apply, leaving encrypted fields unencrypted.
+func (m Partial[T]) applyPlaintext(base T) *T {
+	for _, before := range m.before {
+		before(&base)
+	}
+
+	patched := m.apply(base)
+	m.recomputeDerived(patched)
+	for _, after := range m.after {
+		after(patched)
+	}
+	m.checkZeroValues(patched)
+	recordStats(reflect.TypeOf(base), m.FieldNames)
+
+	return patched
 }
 
 // Match checks if the given object matches against the fields that are set on the tracked
@@ -72,9 +119,10 @@ func (m Partial[T]) Apply(base T) *T {
 // change, and is useful to check when building idempotent update methods.
 func (m Partial[T]) Match(otherPtr *T) bool {
 	// If we haven't built anything, we're a null object. It's sensible to consider nil as
-	// equal to an empty built model.
-	if otherPtr == nil && len(m.FieldNames) == 0 {
-		return true
+	// equal to an empty built model, but nothing else can match nil, such as when the
+	// caller failed to find a row.
+	if otherPtr == nil {
+		return len(m.FieldNames) == 0
 	}
 
 	var (
@@ -82,9 +130,18 @@ func (m Partial[T]) Match(otherPtr *T) bool {
 		subjectValue = reflect.ValueOf(m.Subject)
 	)
 	for _, columnName := range m.FieldNames {
-		match := reflect.DeepEqual(
-			otherValue.FieldByName(columnName).Interface(),
-			subjectValue.FieldByName(columnName).Interface(),
+		// Incrementing by anything but zero will always make a change
+		if m.increments[columnName] {
+			if !subjectValue.FieldByName(columnName).IsZero() {
+				return false
+			}
+
+			continue
+		}
+
+		match := valuesEqual(
+			otherValue.FieldByName(columnName),
+			subjectValue.FieldByName(columnName),
 		)
 		if !match {
 			return false
@@ -94,24 +151,242 @@ func (m Partial[T]) Match(otherPtr *T) bool {
 	return true
 }
 
+// Prune returns a Partial that only tracks fields whose value differs from base, which
+// is the minimal patch to turn base into the result of applying this one.
+//
+// Unlike Without, the pruned fields are never applied, even against a different base.
+func (m Partial[T]) Prune(base T) Partial[T] {
+	var (
+		baseValue    = reflect.ValueOf(base)
+		subjectValue = reflect.ValueOf(m.Subject)
+	)
+
+	fieldNames, incrementedFieldNames := []string{}, []string{}
+	for _, fieldName := range m.FieldNames {
+		if m.increments[fieldName] {
+			if !subjectValue.FieldByName(fieldName).IsZero() {
+				incrementedFieldNames = append(incrementedFieldNames, fieldName)
+			}
+
+			continue
+		}
+
+		if !valuesEqual(baseValue.FieldByName(fieldName), subjectValue.FieldByName(fieldName)) {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+
+	pruned := newTracking(m.Subject, fieldNames)
+	for _, fieldName := range incrementedFieldNames {
+		pruned = pruned.Increment(fieldName, subjectValue.FieldByName(fieldName).Interface())
+	}
+	pruned.err = m.err
+	pruned.before, pruned.after = m.before, m.after
+
+	return pruned
+}
+
+// Operation describes how a Partial is going to be persisted, as some fields may only be
+// written by certain operations.
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+)
+
+// Validate checks that every tracked field may be written by the given operation.
+//
+// Fields tagged with `partial:"immutable"` can be set when creating a record, but it is
+// an error to track them in a partial used to update one. Fields tagged with
+// `partial:"readonly"`, such as generated columns, can't be written by either.
+func (m Partial[T]) Validate(op Operation) error {
+	subjectType := reflect.TypeOf(m.Subject)
+	for _, fieldName := range m.FieldNames {
+		field, ok := schemaFieldFor(subjectType, fieldName)
+		if !ok {
+			return errors.New(fmt.Sprintf("unknown field %s on %s", fieldName, subjectType.Name()))
+		}
+
+		if field.Immutable && op == OperationUpdate {
+			return errors.New(fmt.Sprintf("field %s on %s is immutable and cannot be updated", fieldName, subjectType.Name()))
+		}
+		if field.ReadOnly {
+			return errors.New(fmt.Sprintf("field %s on %s is readonly and cannot be written", fieldName, subjectType.Name()))
+		}
+	}
+
+	return nil
+}
+
 // Merge combines one Partial with another of the same type, with the other fields
 // taking precedence.
+//
+// Only the fields tracked by other are copied across, field-by-field, so untracked
+// values in other (such as defaults, or fields removed with Without) never overwrite
+// what we already have.
 func (m Partial[T]) Merge(other Partial[T]) Partial[T] {
-	return Partial[T]{
-		Subject:    *other.Apply(m.Subject),
-		FieldNames: append(m.FieldNames, other.FieldNames...),
-		apply: func(subject T) *T {
-			return other.apply(*m.apply(subject))
+	otherFieldNames := append([]string{}, other.FieldNames...)
+	otherSubject := other.Subject
+
+	// Fields the other partial increments are added to the base, rather than copied
+	otherSetFieldNames, otherIncrementedFieldNames := []string{}, []string{}
+	for _, fieldName := range otherFieldNames {
+		if other.increments[fieldName] {
+			otherIncrementedFieldNames = append(otherIncrementedFieldNames, fieldName)
+		} else {
+			otherSetFieldNames = append(otherSetFieldNames, fieldName)
+		}
+	}
+
+	fieldNames := []string{}
+	for _, fieldName := range m.FieldNames {
+		if !contains(otherFieldNames, fieldName) {
+			fieldNames = append(fieldNames, fieldName)
+		}
+	}
+
+	subject := m.Subject
+	copyFields(&subject, otherSubject, otherFieldNames)
+
+	merged := Partial[T]{
+		Subject:    subject,
+		FieldNames: append(fieldNames, otherFieldNames...),
+		apply: func(base T) *T {
+			patched := m.apply(base)
+			copyFields(patched, otherSubject, otherSetFieldNames)
+			incrementFields(patched, base, otherSubject, otherIncrementedFieldNames)
+
+			return patched
 		},
+		err:        m.err,
+		increments: withoutIncrements(m.increments, otherSetFieldNames),
+		before:     append(append([]func(*T){}, m.before...), other.before...),
+		after:      append(append([]func(*T){}, m.after...), other.after...),
+	}
+	if len(otherIncrementedFieldNames) > 0 {
+		merged.increments = copyIncrements(merged.increments, otherIncrementedFieldNames...)
+	}
+	if merged.err == nil {
+		merged.err = other.err
+	}
+
+	return merged.TrackDerived()
+}
+
+// MergeAll merges the partials in order, each taking precedence over those before it,
+// giving the same result as folding Merge over them. It does so in a single pass, so is
+// much cheaper than Merge in a loop when combining many partials, such as when batching
+// updates to the same record.
+//
+// Merging no partials returns an empty one.
+func MergeAll[T any](models ...Partial[T]) Partial[T] {
+	switch len(models) {
+	case 0:
+		return newTracking(*new(T), []string{})
+	case 1:
+		return models[0]
+	}
+
+	// Only the last partial to track a field decides its value, and whether it's set or
+	// incremented
+	subject, lastMerged := models[0].Subject, map[string]int{}
+	for idx, model := range models {
+		if idx > 0 {
+			copyFields(&subject, model.Subject, model.FieldNames)
+		}
+		for _, fieldName := range model.FieldNames {
+			lastMerged[fieldName] = idx
+		}
+	}
+
+	// Fields are ordered by when they were last tracked, as they would be if we merged one
+	// at a time. The first partial applies its own fields, so we only need to apply those
+	// that later partials took over.
+	merged := Partial[T]{
+		Subject:    subject,
+		FieldNames: make([]string, 0, len(lastMerged)),
+	}
+	setFieldNames, incrementedFieldNames := []string{}, []string{}
+	for idx, model := range models {
+		for _, fieldName := range model.FieldNames {
+			if lastMerged[fieldName] != idx {
+				continue
+			}
+			lastMerged[fieldName] = -1 // so a field tracked twice is only added once
+
+			merged.FieldNames = append(merged.FieldNames, fieldName)
+			if model.increments[fieldName] {
+				if merged.increments == nil {
+					merged.increments = map[string]bool{}
+				}
+				merged.increments[fieldName] = true
+				if idx > 0 {
+					incrementedFieldNames = append(incrementedFieldNames, fieldName)
+				}
+			} else if idx > 0 {
+				setFieldNames = append(setFieldNames, fieldName)
+			}
+		}
+
+		merged.before = append(merged.before, model.before...)
+		merged.after = append(merged.after, model.after...)
+		if merged.err == nil {
+			merged.err = model.err
+		}
+	}
+
+	apply := models[0].apply
+	merged.apply = func(base T) *T {
+		patched := apply(base)
+		copyFields(patched, subject, setFieldNames)
+		incrementFields(patched, base, subject, incrementedFieldNames)
+
+		return patched
 	}
+
+	return merged.TrackDerived()
+}
+
+func contains(fieldNames []string, fieldName string) bool {
+	for _, candidate := range fieldNames {
+		if candidate == fieldName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// untrackedFieldNames returns the field names that aren't already tracked, so a field
+// set again is only tracked once.
+func untrackedFieldNames(tracked, fieldNames []string) []string {
+	untracked := []string{}
+	for _, fieldName := range fieldNames {
+		if !contains(tracked, fieldName) && !contains(untracked, fieldName) {
+			untracked = append(untracked, fieldName)
+		}
+	}
+
+	return untracked
 }
 
 // Add returns a new Partial with additional setters, taking precendence over
 // whatever was previously set.
-func (m Partial[T]) Add(opts ...func(*T) []string) Partial[T] {
+func (m Partial[T]) Add(opts ...Option[T]) Partial[T] {
 	for _, opt := range opts {
-		m.FieldNames = append(m.FieldNames, opt(&m.Subject)...)
-		m.apply = func(apply func(T) *T, opt func(*T) []string) func(T) *T {
+		fieldNames, err := applyOption(&m.Subject, opt)
+		if err != nil {
+			if m.err == nil {
+				m.err = err
+			}
+
+			continue
+		}
+
+		m.appendFieldNames(untrackedFieldNames(m.FieldNames, fieldNames))
+		m.increments = withoutIncrements(m.increments, fieldNames)
+		m.apply = func(apply func(T) *T, opt Option[T]) func(T) *T {
 			return func(subject T) *T {
 				res := apply(subject)
 				opt(res)
@@ -121,7 +396,7 @@ func (m Partial[T]) Add(opts ...func(*T) []string) Partial[T] {
 		}(m.apply, opt)
 	}
 
-	return m
+	return m.TrackDerived()
 }
 
 // Without removes the given field names from the model, causing these fields to be
@@ -143,5 +418,57 @@ eachExistingFieldName:
 		Subject:    m.Subject,
 		FieldNames: fieldNames,
 		apply:      m.apply,
+		err:        m.err,
+		increments: withoutIncrements(m.increments, fieldNamesToRemove),
+		before:     m.before,
+		after:      m.after,
 	}
 }
+
+// WithoutFunc removes every tracked field whose name the predicate returns true for,
+// such as every field ending in At when timestamps are managed elsewhere.
+func (m Partial[T]) WithoutFunc(remove func(fieldName string) bool) Partial[T] {
+	fieldNamesToRemove := []string{}
+	for _, fieldName := range m.FieldNames {
+		if remove(fieldName) {
+			fieldNamesToRemove = append(fieldNamesToRemove, fieldName)
+		}
+	}
+
+	return m.Without(fieldNamesToRemove...)
+}
+
+// WithoutColumns removes the fields that map onto the given columns, for call sites such
+// as migration scripts that only know the column names. Columns that no field maps onto
+// are ignored.
+func (m Partial[T]) WithoutColumns(columnNames ...string) Partial[T] {
+	fieldNamesToRemove := []string{}
+	for _, field := range schemaFor(reflect.TypeOf(m.Subject)) {
+		if field.DatabaseBacked() && slices.Contains(columnNames, field.ColumnName) {
+			fieldNamesToRemove = append(fieldNamesToRemove, field.Name)
+		}
+	}
+
+	return m.Without(fieldNamesToRemove...)
+}
+
+// WithoutZeroFields removes any of the given fields that are tracked but set to their
+// zero value. This backs the generated <Type>ForCreate funcs, which use it to leave
+// columns with a database default out of inserts.
+func WithoutZeroFields[T any](model Partial[T], fieldNames ...string) Partial[T] {
+	subjectValue := reflect.ValueOf(model.Subject)
+
+	zeroFieldNames := []string{}
+	for _, fieldName := range fieldNames {
+		field := subjectValue.FieldByName(fieldName)
+		if field.IsValid() && field.IsZero() && model.Tracks(fieldName) && !model.increments[fieldName] {
+			zeroFieldNames = append(zeroFieldNames, fieldName)
+		}
+	}
+
+	if len(zeroFieldNames) == 0 {
+		return model
+	}
+
+	return model.Without(zeroFieldNames...)
+}
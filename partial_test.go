@@ -1,13 +1,24 @@
 package partial_test
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/incident-io/partial"
 	"github.com/incident-io/partial/test"
+	"github.com/incident-io/partial/test/external"
+	"github.com/onsi/gomega/types"
+	"github.com/spf13/pflag"
 	"gopkg.in/guregu/null.v3"
 
+	"gorm.io/gorm"
+
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 )
@@ -28,12 +39,13 @@ var _ = Describe("Partial", func() {
 					ID:   "id",
 					Name: "Peanuts",
 				},
-				CreatedAt: now,
+				CreatedAt:    now,
+				SearchVector: "peanuts",
 			})
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("only creates fields that are valid database columns", func() {
+		It("only creates fields that are valid database columns, and not readonly", func() {
 			Expect(model.FieldNames).To(ConsistOf(
 				"ID",
 				"OrganisationID",
@@ -50,6 +62,93 @@ var _ = Describe("Partial", func() {
 				"CreatedAt":      Equal(now),
 			}))
 		})
+
+		It("errors for a nil subject", func() {
+			_, err := partial.New[test.Incident](nil)
+			Expect(err).To(MatchError("cannot track fields of a nil *test.Incident"))
+		})
+
+		It("errors for a subject boxed in an interface", func() {
+			var boxed any = test.Incident{ID: "id"}
+			_, err := partial.New(&boxed)
+			Expect(err).To(MatchError("cannot track fields of test.Incident boxed in interface {}, pass a pointer to the struct itself"))
+		})
+
+		It("errors for a pointer to a pointer", func() {
+			inc := &test.Incident{ID: "id"}
+			_, err := partial.New(&inc)
+			Expect(err).To(MatchError("cannot track fields of *test.Incident, pass a pointer to the struct rather than a pointer to a pointer"))
+		})
+	})
+
+	Describe("Validate", func() {
+		var (
+			model partial.Partial[test.Incident]
+			op    partial.Operation
+			err   error
+		)
+
+		BeforeEach(func() {
+			model = test.IncidentBuilder(
+				test.IncidentBuilder.OrganisationID("org-id"),
+			)
+		})
+
+		JustBeforeEach(func() {
+			err = model.Validate(op)
+		})
+
+		Context("when updating", func() {
+			BeforeEach(func() {
+				op = partial.OperationUpdate
+			})
+
+			Context("with only mutable fields", func() {
+				It("succeeds", func() {
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			Context("with an immutable field", func() {
+				BeforeEach(func() {
+					var newErr error
+					model, newErr = partial.New(&test.Incident{ID: "id"})
+					Expect(newErr).NotTo(HaveOccurred())
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError(ContainSubstring("field ID on Incident is immutable")))
+				})
+			})
+		})
+
+		Context("with a readonly field", func() {
+			BeforeEach(func() {
+				op = partial.OperationCreate
+				model = model.Add(func(incident *test.Incident) []string {
+					incident.SearchVector = "peanuts"
+					return []string{"SearchVector"}
+				})
+			})
+
+			It("errors, even when creating", func() {
+				Expect(err).To(MatchError(ContainSubstring("field SearchVector on Incident is readonly")))
+			})
+		})
+
+		Context("when creating", func() {
+			BeforeEach(func() {
+				op = partial.OperationCreate
+
+				var newErr error
+				model, newErr = partial.New(&test.Incident{ID: "id"})
+				Expect(newErr).NotTo(HaveOccurred())
+			})
+
+			It("permits immutable fields", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("methods", func() {
@@ -99,6 +198,26 @@ var _ = Describe("Partial", func() {
 					Expect(match).To(BeFalse())
 				})
 			})
+
+			Context("when matching against nil", func() {
+				JustBeforeEach(func() {
+					match = model.Match(nil)
+				})
+
+				It("returns false", func() {
+					Expect(match).To(BeFalse())
+				})
+
+				Context("and the model is empty", func() {
+					BeforeEach(func() {
+						model = test.OrganisationBuilder()
+					})
+
+					It("returns true", func() {
+						Expect(match).To(BeTrue())
+					})
+				})
+			})
 		})
 
 		Describe("Apply", func() {
@@ -134,5 +253,927 @@ var _ = Describe("Partial", func() {
 				))
 			})
 		})
+
+		Describe("Prune", func() {
+			var (
+				pruned partial.Partial[test.Organisation]
+			)
+
+			JustBeforeEach(func() {
+				pruned = model.Prune(test.Organisation{
+					ID:   "id",
+					Name: "base-name",
+				})
+			})
+
+			It("drops fields that already match the base", func() {
+				Expect(pruned.FieldNames).To(ConsistOf("Name", "OptionalString"))
+			})
+
+			It("never applies the dropped fields", func() {
+				Expect(pruned.Apply(test.Organisation{ID: "other-id"})).To(test.OrganisationMatcher(
+					test.OrganisationMatcher.ID("other-id"),
+					test.OrganisationMatcher.Name("name"),
+					test.OrganisationMatcher.OptionalString(null.StringFrom("something-here")),
+				))
+			})
+		})
+
+		Describe("Merge", func() {
+			var (
+				other  partial.Partial[test.Organisation]
+				merged partial.Partial[test.Organisation]
+			)
+
+			BeforeEach(func() {
+				other = test.OrganisationBuilder(
+					test.OrganisationBuilder.Name("other-name"),
+					test.OrganisationBuilder.BoolFlag(false),
+				).Without("BoolFlag")
+			})
+
+			JustBeforeEach(func() {
+				merged = model.Merge(other)
+			})
+
+			It("tracks fields from both, without duplicates", func() {
+				Expect(merged.FieldNames).To(ConsistOf("ID", "Name", "OptionalString"))
+			})
+
+			It("takes values from the other partial", func() {
+				Expect(&merged.Subject).To(test.OrganisationMatcher(
+					test.OrganisationMatcher.ID("id"),
+					test.OrganisationMatcher.Name("other-name"),
+				))
+			})
+
+			It("never applies fields the other partial does not track", func() {
+				Expect(merged.Apply(test.Organisation{BoolFlag: true})).To(test.OrganisationMatcher(
+					test.OrganisationMatcher.ID("id"),
+					test.OrganisationMatcher.Name("other-name"),
+					test.OrganisationMatcher.BoolFlag(true),
+				))
+			})
+
+			Context("when the other partial was built with New", func() {
+				BeforeEach(func() {
+					var err error
+					other, err = partial.New(&test.Organisation{Name: "loaded-name"})
+					Expect(err).NotTo(HaveOccurred())
+
+					other = other.Without("ID", "OptionalString", "BoolFlag")
+				})
+
+				It("only copies the fields that remain tracked", func() {
+					Expect(merged.Apply(test.Organisation{BoolFlag: true})).To(test.OrganisationMatcher(
+						test.OrganisationMatcher.ID("id"),
+						test.OrganisationMatcher.Name("loaded-name"),
+						test.OrganisationMatcher.OptionalString(null.StringFrom("something-here")),
+						test.OrganisationMatcher.BoolFlag(true),
+					))
+				})
+			})
+		})
+
+		Describe("WithoutFunc", func() {
+			It("removes the fields the predicate matches", func() {
+				without := model.WithoutFunc(func(fieldName string) bool {
+					return strings.HasPrefix(fieldName, "Optional")
+				})
+
+				Expect(without.FieldNames).To(ConsistOf("ID", "Name"))
+			})
+		})
+
+		Describe("WithoutColumns", func() {
+			It("removes the fields mapped onto the columns", func() {
+				Expect(model.WithoutColumns("optional_string", "name").FieldNames).To(ConsistOf("ID"))
+			})
+
+			It("ignores columns no field maps onto", func() {
+				Expect(model.WithoutColumns("LatestIncident", "unknown").FieldNames).To(ConsistOf("ID", "Name", "OptionalString"))
+			})
+		})
+	})
+
+	Describe("MergeAll", func() {
+		var models []partial.Partial[test.Organisation]
+
+		BeforeEach(func() {
+			models = []partial.Partial[test.Organisation]{
+				test.OrganisationBuilder(
+					test.OrganisationBuilder.ID("id"),
+					test.OrganisationBuilder.Name("first-name"),
+				).Increment("IncidentCount", 1),
+				test.OrganisationBuilder(
+					test.OrganisationBuilder.Name("second-name"),
+					test.OrganisationBuilder.BoolFlag(true),
+				),
+				test.OrganisationBuilder().Increment("IncidentCount", 5),
+			}
+		})
+
+		It("gives the same result as merging one at a time", func() {
+			folded := models[0]
+			for _, model := range models[1:] {
+				folded = folded.Merge(model)
+			}
+
+			merged := partial.MergeAll(models...)
+
+			Expect(merged.FieldNames).To(Equal(folded.FieldNames))
+			Expect(merged.Subject).To(Equal(folded.Subject))
+			Expect(merged.Apply(test.Organisation{IncidentCount: 2})).To(Equal(folded.Apply(test.Organisation{IncidentCount: 2})))
+		})
+
+		It("applies values from the last partial to track each field", func() {
+			Expect(partial.MergeAll(models...).Apply(test.Organisation{IncidentCount: 2})).To(test.OrganisationMatcher(
+				test.OrganisationMatcher.ID("id"),
+				test.OrganisationMatcher.Name("second-name"),
+				test.OrganisationMatcher.BoolFlag(true),
+				test.OrganisationMatcher.IncidentCount(7),
+			))
+		})
+
+		It("returns the first error", func() {
+			failed := test.OrganisationBuilder(
+				partial.Fail[test.Organisation](errors.New("oops")),
+			)
+
+			Expect(partial.MergeAll(models[0], failed, models[1]).Err()).To(MatchError("oops"))
+		})
+
+		It("returns an empty partial when given nothing to merge", func() {
+			merged := partial.MergeAll[test.Organisation]()
+
+			Expect(merged.Empty()).To(BeTrue())
+			Expect(merged.Apply(test.Organisation{Name: "name"}).Name).To(Equal("name"))
+		})
+	})
+})
+
+var _ = Describe("Nested matchers", func() {
+	var (
+		incident test.Incident
+		matcher  types.GomegaMatcher
+	)
+
+	BeforeEach(func() {
+		incident = test.Incident{
+			ID: "child",
+			Parent: &test.Incident{
+				ID: "parent",
+				Organisation: &test.Organisation{
+					Name: "Peanuts",
+				},
+			},
+		}
+
+		matcher = test.IncidentMatcher(
+			test.IncidentMatcher.MatchParentWith(
+				test.IncidentMatcher.ID("parent"),
+				test.IncidentMatcher.MatchOrganisationWith(
+					test.OrganisationMatcher.Name("Peanuts"),
+				),
+			),
+		)
+	})
+
+	It("matches through self-referential fields", func() {
+		Expect(&incident).To(matcher)
+	})
+
+	Context("when the nested field is nil", func() {
+		BeforeEach(func() {
+			incident.Parent = nil
+		})
+
+		It("fails without panicking", func() {
+			match, err := matcher.Match(&incident)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(match).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("Slice matchers", func() {
+	It("matches slices of structs in any order", func() {
+		incident := test.Incident{
+			Actions: []test.Action{{ID: "first"}, {ID: "second"}},
+		}
+
+		Expect(&incident).To(test.IncidentMatcher(
+			test.IncidentMatcher.MatchActionsConsistOf(
+				test.ActionMatcher(test.ActionMatcher.ID("second")),
+				test.ActionMatcher(test.ActionMatcher.ID("first")),
+			),
+		))
+	})
+
+	It("matches each element on several fields", func() {
+		incident := test.Incident{
+			Actions: []test.Action{{ID: "first", Description: "call"}, {ID: "second", Description: "page"}},
+		}
+
+		Expect(&incident).To(test.IncidentMatcher(
+			test.IncidentMatcher.MatchActionsConsistOf(
+				test.ActionMatcher(test.ActionMatcher.ID("first"), test.ActionMatcher.Description("call")),
+				test.ActionMatcher(test.ActionMatcher.ID("second"), test.ActionMatcher.Description("page")),
+			),
+		))
+		Expect(&incident).NotTo(test.IncidentMatcher(
+			test.IncidentMatcher.MatchActionsConsistOf(
+				test.ActionMatcher(test.ActionMatcher.ID("first"), test.ActionMatcher.Description("page")),
+				test.ActionMatcher(test.ActionMatcher.ID("second"), test.ActionMatcher.Description("call")),
+			),
+		))
+	})
+
+	It("matches slices of pointers", func() {
+		org := test.Organisation{
+			Incidents: []*test.Incident{{ID: "incident-id"}},
+		}
+
+		Expect(&org).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.MatchIncidentsConsistOf(
+				test.IncidentMatcher(test.IncidentMatcher.ID("incident-id")),
+			),
+		))
+	})
+
+	It("fails when an element is missing", func() {
+		incident := test.Incident{
+			Actions: []test.Action{{ID: "first"}},
+		}
+
+		Expect(&incident).NotTo(test.IncidentMatcher(
+			test.IncidentMatcher.MatchActionsConsistOf(
+				test.ActionMatcher(test.ActionMatcher.ID("first")),
+				test.ActionMatcher(test.ActionMatcher.ID("second")),
+			),
+		))
+	})
+})
+
+var _ = Describe("Matcher factories", func() {
+	It("matches with the factory named on the field", func() {
+		action := &test.Action{Assignee: "Lisa@Example.com"}
+
+		Expect(action).To(test.ActionMatcher(
+			test.ActionMatcher.Assignee("lisa@example.com"),
+		))
+		Expect(action).NotTo(test.ActionMatcher(
+			test.ActionMatcher.Assignee("other@example.com"),
+		))
+	})
+})
+
+var _ = Describe("Deep copying setters", func() {
+	It("doesn't change the partial when the caller changes their value", func() {
+		actions := []test.Action{{ID: "first"}}
+		model := test.IncidentBuilder(
+			test.IncidentBuilder.Actions(actions),
+		)
+
+		actions[0].ID = "changed"
+
+		Expect(model.Subject.Actions[0].ID).To(Equal("first"))
+		Expect(model.Apply(test.Incident{}).Actions[0].ID).To(Equal("first"))
+	})
+
+	It("gives each applied record its own copy", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.SigningKey([]byte("key")),
+		)
+
+		first := model.Apply(test.Organisation{})
+		first.SigningKey[0] = 'K'
+
+		Expect(model.Apply(test.Organisation{}).SigningKey).To(Equal([]byte("key")))
+	})
+
+	It("keeps nil values nil", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.SigningKey(nil),
+		)
+
+		Expect(model.Subject.SigningKey).To(BeNil())
+	})
+})
+
+var _ = Describe("Seeding builders from existing values", func() {
+	existing := test.Organisation{
+		ID:             "org-id",
+		Name:           "Existing",
+		IncidentCount:  3,
+		SigningKey:     []byte("key"),
+		LatestIncident: &test.Incident{ID: "incident-id"},
+	}
+
+	It("sets every database-backed field, with later setters taking precedence", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.From(existing),
+			test.OrganisationBuilder.Name("Renamed"),
+		)
+
+		Expect(model.FieldNames).To(ContainElements("ID", "Name", "OptionalString", "IncidentCount", "SigningKey"))
+		Expect(model.FieldNames).NotTo(ContainElement("LatestIncident"))
+		Expect(&model.Subject).To(test.OrganisationMatcher(
+			test.OrganisationMatcher.ID("org-id"),
+			test.OrganisationMatcher.Name("Renamed"),
+			test.OrganisationMatcher.IncidentCount(3),
+			test.OrganisationMatcher.LatestIncident(nil),
+		))
+	})
+
+	It("skips fields without setters", func() {
+		model := test.IncidentBuilder(
+			test.IncidentBuilder.From(test.Incident{ID: "incident-id", OrganisationID: "org-id"}),
+		)
+
+		Expect(model.FieldNames).To(ConsistOf("OrganisationID"))
+	})
+
+	It("deep copies fields that ask for it", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.From(existing),
+		)
+
+		model.Subject.SigningKey[0] = 'K'
+
+		Expect(existing.SigningKey).To(Equal([]byte("key")))
+	})
+})
+
+var _ = Describe("Builder groups", func() {
+	var (
+		dueAt = null.TimeFrom(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+		model partial.Partial[test.Action]
+	)
+
+	BeforeEach(func() {
+		model = test.ActionBuilder(
+			test.ActionBuilder.ID("action-id"),
+			test.ActionBuilder.Timestamps().DueAt(dueAt),
+		)
+	})
+
+	It("tracks fields set through the group", func() {
+		Expect(model.FieldNames).To(ConsistOf("ID", "DueAt"))
+		Expect(&model.Subject).To(test.ActionMatcher(
+			test.ActionMatcher.DueAt(dueAt),
+		))
+	})
+})
+
+var _ = Describe("Nullable setters", func() {
+	It("wraps raw values for database/sql null types", func() {
+		model := test.ActionBuilder(
+			test.ActionBuilder.PriorityValue(3),
+		)
+
+		Expect(model.Subject.Priority).To(Equal(sql.NullInt64{Int64: 3, Valid: true}))
+	})
+
+	It("wraps raw values for guregu null types", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.OptionalStringValue("something-here"),
+		)
+
+		Expect(model.Subject.OptionalString).To(Equal(null.StringFrom("something-here")))
+	})
+
+	It("tracks fields explicitly cleared to null", func() {
+		model := test.ActionBuilder(
+			test.ActionBuilder.PriorityValue(3),
+			test.ActionBuilder.PriorityNull(),
+		)
+
+		Expect(model.FieldNames).To(ContainElement("Priority"))
+		Expect(model.Apply(test.Action{Priority: sql.NullInt64{Int64: 1, Valid: true}})).To(test.ActionMatcher(
+			test.ActionMatcher.Priority(sql.NullInt64{}),
+		))
+	})
+})
+
+var _ = Describe("Clearing setters", func() {
+	It("clears nullable fields, still tracking them", func() {
+		model := test.ActionBuilder(
+			test.ActionBuilder.ClearPriority(),
+			test.ActionBuilder.Timestamps().ClearDueAt(),
+		)
+
+		Expect(model.FieldNames).To(ConsistOf("Priority", "DueAt"))
+		Expect(model.Ops()).To(ConsistOf(
+			partial.FieldOp{FieldName: "Priority", JSONName: "priority", Kind: partial.FieldOpClear},
+			partial.FieldOp{FieldName: "DueAt", JSONName: "due_at", Kind: partial.FieldOpClear},
+		))
+	})
+
+	It("clears pointer fields", func() {
+		model := test.OrganisationBuilder(
+			test.OrganisationBuilder.ClearLatestIncident(),
+		)
+
+		Expect(model.FieldNames).To(ConsistOf("LatestIncident"))
+		Expect(model.Apply(test.Organisation{LatestIncident: &test.Incident{}}).LatestIncident).To(BeNil())
+	})
+})
+
+var _ = Describe("Optional setters", func() {
+	It("sets fields whose value is given", func() {
+		name, severity := "Renamed", "high"
+		model := test.ActionBuilder(
+			test.ActionBuilder.DescriptionIfSet(&name),
+			test.ActionBuilder.SeverityIfSet(&severity),
+		)
+
+		Expect(model.FieldNames).To(ContainElements("Description", "Severity"))
+		Expect(model.Subject.Description).To(Equal("Renamed"))
+		Expect(model.Subject.Severity).To(Equal(test.SeverityHigh))
+	})
+
+	It("does nothing for nil values", func() {
+		model := test.ActionBuilder(
+			test.ActionBuilder.DescriptionIfSet(nil),
+			test.ActionBuilder.Timestamps().DueAtIfSet(nil),
+		)
+
+		Expect(model.FieldNames).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Grouped type declarations", func() {
+	It("generates for annotated types within the group", func() {
+		model := test.CustomFieldBuilder(
+			test.CustomFieldBuilder.Name("Affected teams"),
+		)
+
+		Expect(&model.Subject).To(test.CustomFieldMatcher(
+			test.CustomFieldMatcher.Name("Affected teams"),
+		))
+	})
+})
+
+var _ = Describe("Field defaults", func() {
+	It("sets and tracks default values", func() {
+		model := test.CustomFieldBuilder(
+			test.CustomFieldBuilder.Name("Affected teams"),
+		)
+
+		Expect(model.FieldNames).To(ConsistOf("Kind", "Required", "SchemaVersion", "Name"))
+		Expect(model.Apply(test.CustomField{})).To(test.CustomFieldMatcher(
+			test.CustomFieldMatcher.Name("Affected teams"),
+			test.CustomFieldMatcher.Kind("text"),
+			test.CustomFieldMatcher.Required(true),
+		))
+	})
+
+	It("quotes defaults of string fields, even when they look like numbers", func() {
+		Expect(test.CustomFieldBuilder().Subject.SchemaVersion).To(Equal("2"))
+	})
+
+	It("lets setters override defaults", func() {
+		model := test.CustomFieldBuilder(
+			test.CustomFieldBuilder.Kind("select"),
+		)
+
+		Expect(model.Subject.Kind).To(Equal("select"))
+		Expect(model.FieldNames).To(Equal([]string{"Kind", "Required", "SchemaVersion"}))
+	})
+
+	It("parses defaults of duration fields", func() {
+		Expect(test.RetryPolicyBuilder().Subject.Backoff).To(Equal(30 * time.Second))
+	})
+
+	It("does not set defaults when built without them", func() {
+		model := test.CustomFieldBuilder.WithoutDefaults()(
+			test.CustomFieldBuilder.Name("Affected teams"),
+		)
+
+		Expect(model.FieldNames).To(ConsistOf("Name"))
+		Expect(model.Subject.Required).To(BeFalse())
+	})
+})
+
+var _ = Describe("Constructors", func() {
+	It("takes required fields positionally, and setters for the rest", func() {
+		model := test.NewIncident("incident-id", "org-id",
+			test.IncidentBuilder.Parent(&test.Incident{ID: "parent-id"}),
+		)
+
+		Expect(model.FieldNames).To(Equal([]string{"ID", "OrganisationID", "Parent"}))
+		Expect(&model.Subject).To(test.IncidentMatcher(
+			test.IncidentMatcher.ID("incident-id"),
+			test.IncidentMatcher.OrganisationID("org-id"),
+		))
+	})
+})
+
+var _ = Describe("Option funcs", func() {
+	It("can be stored and passed to the builder", func() {
+		opts := []test.ActionOption{
+			test.SetActionDescription("Restart"),
+			test.SetActionDueAtValue(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+			test.SetActionSeverity("high"),
+			test.SetActionPriorityNull(),
+		}
+
+		model := test.ActionBuilder(opts...)
+
+		Expect(model.Err()).NotTo(HaveOccurred())
+		Expect(model.FieldNames).To(ContainElements("Description", "DueAt", "Severity", "Priority"))
+		Expect(model.Subject.Description).To(Equal("Restart"))
+		Expect(model.Subject.DueAt).To(Equal(null.TimeFrom(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))))
+		Expect(model.Subject.Severity).To(Equal(test.SeverityHigh))
+	})
+})
+
+var _ = Describe("Table entries", func() {
+	existing := test.Incident{ID: "incident-id", OrganisationID: "org-id"}
+
+	DescribeTable("applying",
+		func(model partial.Partial[test.Incident], expected types.GomegaMatcher) {
+			Expect(model.Apply(existing)).To(expected)
+		},
+		test.IncidentEntry("sets the organisation",
+			func(b test.IncidentBuilderFunc) partial.Partial[test.Incident] {
+				return b(b.OrganisationID("other-org-id"))
+			},
+			func(m test.IncidentMatcherFunc) types.GomegaMatcher {
+				return m(m.ID("incident-id"), m.OrganisationID("other-org-id"))
+			},
+		),
+		test.IncidentEntry("leaves fields that aren't set",
+			func(b test.IncidentBuilderFunc) partial.Partial[test.Incident] {
+				return b()
+			},
+			func(m test.IncidentMatcherFunc) types.GomegaMatcher {
+				return m(m.OrganisationID("org-id"))
+			},
+		),
+	)
+})
+
+var _ = Describe("Guarded gorm defaults", func() {
+	It("drops zero values over columns with a gorm default", func() {
+		model := test.OrganisationForCreate(test.OrganisationBuilder(
+			test.OrganisationBuilder.ID(""),
+			test.OrganisationBuilder.Name("My Org"),
+		))
+
+		Expect(model.FieldNames).To(ConsistOf("Name"))
+	})
+
+	It("keeps non-zero values", func() {
+		model := test.OrganisationForCreate(test.OrganisationBuilder(
+			test.OrganisationBuilder.ID("org-id"),
+		))
+
+		Expect(model.FieldNames).To(ConsistOf("ID"))
+	})
+})
+
+var _ = Describe("External types", func() {
+	It("generates builders for types configured in partial.types.yaml", func() {
+		model := test.VendorBuilder(
+			test.VendorBuilder.Name("PagerDuty"),
+			test.VendorBuilder.Tier(external.VendorTierPaid),
+		)
+
+		Expect(model.FieldNames).To(ConsistOf("Name", "Tier"))
+		Expect(&model.Subject).To(test.VendorMatcher(
+			test.VendorMatcher.Name("PagerDuty"),
+			test.VendorMatcher.Tier(external.VendorTierPaid),
+		))
+	})
+
+	It("qualifies types declared in other files of the external package", func() {
+		model := test.VendorBuilder(
+			test.VendorBuilder.Labels(map[external.VendorLabelKey]external.VendorLabel{"team": "on-call"}),
+		)
+
+		Expect(&model.Subject).To(test.VendorMatcher(
+			test.VendorMatcher.Labels(map[external.VendorLabelKey]external.VendorLabel{"team": "on-call"}),
+		))
+	})
+
+	It("generates diffs", func() {
+		Expect(test.DiffVendor(external.Vendor{Name: "a"}, external.Vendor{Name: "b"})).To(ContainSubstring("Name"))
+	})
+})
+
+// gormModel embeds gorm.Model, whose fields have no JSON tags of their own.
+type gormModel struct {
+	gorm.Model
+	Name string `json:"name"`
+}
+
+var _ = Describe("Embedded structs", func() {
+	var (
+		subject gormModel
+		model   partial.Partial[gormModel]
+	)
+
+	BeforeEach(func() {
+		subject = gormModel{
+			Model: gorm.Model{ID: 1, CreatedAt: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+			Name:  "name",
+		}
+
+		var err error
+		model, err = partial.New(&subject)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("tracks the fields promoted from them", func() {
+		Expect(model.FieldNames).To(Equal([]string{"ID", "CreatedAt", "UpdatedAt", "DeletedAt", "Name"}))
+	})
+
+	It("applies the promoted fields", func() {
+		applied := model.Apply(gormModel{})
+		Expect(applied.ID).To(Equal(uint(1)))
+		Expect(applied.CreatedAt).To(Equal(subject.CreatedAt))
+		Expect(applied.Name).To(Equal("name"))
+	})
+
+	It("names the columns of promoted fields as gorm does", func() {
+		columns, err := model.Without("UpdatedAt", "DeletedAt").ToDBMap()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(columns).To(Equal(map[string]any{
+			"id":         uint(1),
+			"created_at": subject.CreatedAt,
+			"name":       "name",
+		}))
+	})
+
+	It("removes promoted fields by the columns gorm names for them", func() {
+		Expect(model.WithoutColumns("created_at", "deleted_at").FieldNames).To(Equal([]string{"ID", "UpdatedAt", "Name"}))
+	})
+
+	It("names promoted fields in JSON as encoding/json does", func() {
+		encoded, err := json.Marshal(subject)
+		Expect(err).NotTo(HaveOccurred())
+
+		var keys map[string]any
+		Expect(json.Unmarshal(encoded, &keys)).To(Succeed())
+
+		Expect(model.JSONFieldNames()).To(Equal([]string{"ID", "CreatedAt", "UpdatedAt", "DeletedAt", "name"}))
+		for _, jsonName := range model.JSONFieldNames() {
+			Expect(keys).To(HaveKey(jsonName))
+		}
+
+		values, err := model.Without("UpdatedAt", "DeletedAt").ToURLValues()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(HaveKeyWithValue("CreatedAt", []string{"2022-01-01T00:00:00Z"}))
+	})
+
+	It("reads promoted fields from merge patches by their JSON names", func() {
+		patched, err := partial.NewFromMergePatch[gormModel]([]byte(`{"CreatedAt": "2022-01-01T00:00:00Z", "name": "patched"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(patched.FieldNames).To(ConsistOf("CreatedAt", "Name"))
+		Expect(patched.Subject.CreatedAt).To(BeTemporally("==", subject.CreatedAt))
+
+		_, err = partial.NewFromMergePatch[gormModel]([]byte(`{"created_at": "2022-01-01T00:00:00Z"}`))
+		Expect(err).To(MatchError(ContainSubstring("merge patch key created_at is not a field on gormModel")))
+	})
+})
+
+var _ = Describe("Generic types", func() {
+	It("generates builders and matchers with the same type parameters", func() {
+		builder, matcher := test.PageBuilder[string](), test.PageMatcher[string]()
+		model := builder(
+			builder.Items([]string{"a", "b"}),
+			builder.Counts().Total(2),
+		)
+
+		Expect(model.FieldNames).To(Equal([]string{"Items", "Total"}))
+		Expect(&model.Subject).To(matcher(
+			matcher.Items([]string{"a", "b"}),
+			matcher.MatchTotal(BeNumerically(">", 1)),
+		))
+	})
+
+	It("deep copies in the setters of generic fields", func() {
+		items := []int{1, 2}
+		builder := test.PageBuilder[int]()
+		model := builder(builder.Items(items))
+
+		items[0] = 3
+		Expect(model.Subject.Items).To(Equal([]int{1, 2}))
+	})
+
+	It("generates constructors", func() {
+		model := test.NewPage[string]("cursor", test.PageBuilder[string]().Items([]string{"a"}))
+
+		Expect(model.FieldNames).To(Equal([]string{"NextCursor", "Items"}))
+		Expect(model.Subject.NextCursor).To(Equal("cursor"))
+	})
+
+	It("sets defaults, unless built without them", func() {
+		builder := test.PairBuilder[string, int]()
+
+		Expect(builder(builder.Key("answer")).Subject).To(Equal(test.Pair[string, int]{Key: "answer", Label: "unlabelled"}))
+		Expect(builder.WithoutDefaults()(builder.Value(42)).FieldNames).To(Equal([]string{"Value"}))
+	})
+
+	It("supports fields holding instantiated generic types", func() {
+		heading := test.Pair[string, int]{Key: "incidents", Value: 3}
+		model := test.ListingBuilder(
+			test.ListingBuilder.Heading(heading),
+			test.ListingBuilder.Page(&test.Page[test.Incident]{Total: 3}),
+		)
+
+		Expect(&model.Subject).To(test.ListingMatcher(
+			test.ListingMatcher.Heading(heading),
+			test.ListingMatcher.MatchPage(test.PageMatcher[test.Incident]()(
+				test.PageMatcher[test.Incident]().Total(3),
+			)),
+		))
+	})
+
+	It("generates diffs", func() {
+		Expect(test.DiffPage(test.Page[int]{Total: 1}, test.Page[int]{Total: 2})).To(ContainSubstring("Total"))
+	})
+})
+
+var _ = Describe("Anonymous struct fields", func() {
+	It("generates setters and matchers taking the anonymous type", func() {
+		settings := struct {
+			Enabled bool   `json:"enabled"`
+			Channel string `json:"channel"`
+		}{Enabled: true, Channel: "#incidents"}
+
+		model := test.PreferencesBuilder(
+			test.PreferencesBuilder.Settings(settings),
+			test.PreferencesBuilder.Limits(&struct{ Daily, Weekly int }{Daily: 1}),
+		)
+
+		Expect(model.FieldNames).To(Equal([]string{"Settings", "Limits"}))
+		Expect(&model.Subject).To(test.PreferencesMatcher(
+			test.PreferencesMatcher.Settings(settings),
+			test.PreferencesMatcher.MatchLimits(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Daily": Equal(1),
+			}))),
+		))
+	})
+})
+
+var _ = Describe("Fixed-size array fields", func() {
+	It("generates setters and matchers for arrays with a constant length", func() {
+		token := [16]byte{0xde, 0xad, 0xbe, 0xef}
+		model := test.PreferencesBuilder(
+			test.PreferencesBuilder.Token(token),
+			test.PreferencesBuilder.Channels([2]string{"#incidents", "#alerts"}),
+		)
+
+		Expect(&model.Subject).To(test.PreferencesMatcher(
+			test.PreferencesMatcher.TokenHex("deadbeef000000000000000000000000"),
+			test.PreferencesMatcher.Channels([2]string{"#incidents", "#alerts"}),
+		))
+	})
+
+	It("qualifies constant lengths declared by external packages", func() {
+		model := test.VendorBuilder(
+			test.VendorBuilder.APIKey([external.VendorAPIKeyLength]byte{1}),
+		)
+
+		Expect(model.Subject.APIKey[0]).To(Equal(byte(1)))
+	})
+})
+
+var _ = Describe("Skipped fields", func() {
+	It("generates nothing for fields with a partial:skip comment", func() {
+		_, hasSetter := reflect.TypeOf(test.PreferencesBuilder).MethodByName("OnChange")
+		_, hasMatcher := reflect.TypeOf(test.PreferencesMatcher).MethodByName("Updates")
+
+		Expect(hasSetter).To(BeFalse())
+		Expect(hasMatcher).To(BeFalse())
+	})
+
+	It("generates nothing for fields tagged partial:\"-\"", func() {
+		_, hasSetter := reflect.TypeOf(test.CustomFieldBuilder).MethodByName("LegacyType")
+		_, hasMatcher := reflect.TypeOf(test.CustomFieldMatcher).MethodByName("LegacyType")
+
+		Expect(hasSetter).To(BeFalse())
+		Expect(hasMatcher).To(BeFalse())
+	})
+})
+
+var _ = Describe("Flags", func() {
+	var (
+		flags  *pflag.FlagSet
+		config *test.CLIConfigFlagSet
+	)
+
+	BeforeEach(func() {
+		flags = pflag.NewFlagSet("cli", pflag.ContinueOnError)
+		config = test.CLIConfigFlags(flags)
+	})
+
+	It("registers a flag for each field, named after its JSON name", func() {
+		Expect(flags.Lookup("max-retries").DefValue).To(Equal("3"))
+		Expect(flags.Lookup("timeout").DefValue).To(Equal("30s"))
+		Expect(flags.Lookup("endpoint").Usage).To(Equal("The API to send requests to."))
+		Expect(flags.Lookup("token")).To(BeNil())
+	})
+
+	It("tracks only the flags that were set", func() {
+		Expect(flags.Parse([]string{"--max-retries=5", "--tags=a,b"})).To(Succeed())
+
+		model := config.Partial()
+		Expect(model.FieldNames).To(Equal([]string{"MaxRetries", "Tags"}))
+		Expect(model.Apply(test.CLIConfig{Endpoint: "https://example.com", MaxRetries: 1})).To(PointTo(Equal(test.CLIConfig{
+			Endpoint:   "https://example.com",
+			MaxRetries: 5,
+			Tags:       []string{"a", "b"},
+		})))
+	})
+
+	It("tracks nothing when no flags were set", func() {
+		Expect(flags.Parse([]string{})).To(Succeed())
+
+		Expect(config.Partial().Empty()).To(BeTrue())
+	})
+})
+
+var _ = Describe("Update params", func() {
+	It("tracks the fields that aren't nil", func() {
+		name, count := "Renamed", 0
+		model := test.OrganisationUpdateParams{
+			Name:          &name,
+			IncidentCount: &count,
+		}.Partial()
+
+		Expect(model.FieldNames).To(Equal([]string{"Name", "IncidentCount"}))
+		Expect(model.Apply(test.Organisation{ID: "org-id", IncidentCount: 3})).To(Equal(&test.Organisation{
+			ID:   "org-id",
+			Name: "Renamed",
+		}))
+	})
+
+	It("serialises only the fields that are set", func() {
+		name := "Renamed"
+		encoded, err := json.Marshal(test.OrganisationUpdateParams{Name: &name})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(encoded)).To(Equal(`{"name":"Renamed"}`))
+	})
+})
+
+var _ = Describe("Renamed setters", func() {
+	It("names setters and matchers after the name tag option", func() {
+		model := test.AlertBuilder(test.AlertBuilder.Sender("pagerduty"))
+
+		Expect(model.Subject.From).To(Equal("pagerduty"))
+		Expect(model.FieldNames).To(ConsistOf("From"))
+		Expect(&model.Subject).To(test.AlertMatcher(
+			test.AlertMatcher.Sender("pagerduty"),
+		))
+	})
+
+	It("still generates From when a field of that name is renamed", func() {
+		existing := test.Alert{Priority: "p1", From: "pagerduty"}
+		model := test.AlertBuilder(test.AlertBuilder.From(existing))
+
+		Expect(model.Subject.From).To(Equal("pagerduty"))
+	})
+})
+
+var _ = Describe("Defined types", func() {
+	It("generates setters and matchers taking the defined type", func() {
+		model := test.AlertBuilder(
+			test.AlertBuilder.Priority(test.Priority("p1")),
+			test.AlertBuilder.Urgency(test.Urgency("p3")),
+		)
+
+		Expect(&model.Subject).To(test.AlertMatcher(
+			test.AlertMatcher.Priority("p1"),
+			test.AlertMatcher.Urgency("p3"),
+		))
+	})
+
+	It("deep copies defined slice types", func() {
+		labels := test.Labels{"on-call"}
+		model := test.AlertBuilder(test.AlertBuilder.Labels(labels))
+
+		labels[0] = "changed"
+		Expect(model.Subject.Labels).To(Equal(test.Labels{"on-call"}))
+	})
+
+	It("matches defined byte arrays as bytes", func() {
+		model := test.AlertBuilder(test.AlertBuilder.Digest(test.Digest{0xca, 0xfe}))
+
+		Expect(&model.Subject).To(test.AlertMatcher(
+			test.AlertMatcher.DigestHex("cafe000000000000"),
+		))
+	})
+
+	It("binds flags as the type they're defined as", func() {
+		flags := pflag.NewFlagSet("cli", pflag.ContinueOnError)
+		config := test.CLIConfigFlags(flags)
+		Expect(flags.Parse([]string{"--priority=p1"})).To(Succeed())
+
+		Expect(config.Partial().Subject.Priority).To(Equal(test.Priority("p1")))
 	})
 })
@@ -0,0 +1,13 @@
+package partiales_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPartiales(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Partiales Suite")
+}
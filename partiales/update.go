@@ -0,0 +1,77 @@
+// Package partiales builds ElasticSearch partial update bodies from partials, so indexers
+// can update the fields that changed rather than reindexing whole documents.
+package partiales
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/incident-io/partial"
+)
+
+// Update returns the body of an update API request writing each tracked field of the
+// partial, named by its JSON tag. Fields without a JSON name are never written, and
+// encrypted fields are encrypted.
+//
+// Most partials become a partial document, with cleared fields set to null:
+//
+//	{"doc": {"name": "Peanuts", "deleted_at": null}}
+//
+// Partial documents can't express increments, so partials that have any are written as a
+// painless script instead, with every value passed as a parameter:
+//
+//	{"script": {
+//		"lang":   "painless",
+//		"source": "ctx._source['name'] = params['name']; ctx._source['incident_count'] += params['incident_count'];",
+//		"params": {"name": "Peanuts", "incident_count": 1},
+//	}}
+func Update[T any](model partial.Partial[T]) (map[string]any, error) {
+	encryptedOps, err := model.EncryptedOps()
+	if err != nil {
+		return nil, err
+	}
+
+	ops := []partial.FieldOp{}
+	scripted := false
+	for _, op := range encryptedOps {
+		if op.JSONName == "" {
+			continue
+		}
+		if op.Kind == partial.FieldOpIncrement {
+			scripted = true
+		}
+
+		ops = append(ops, op)
+	}
+
+	if scripted {
+		return map[string]any{"script": script(ops)}, nil
+	}
+
+	doc := map[string]any{}
+	for _, op := range ops {
+		doc[op.JSONName] = op.Value
+	}
+
+	return map[string]any{"doc": doc}, nil
+}
+
+// script returns a painless script applying each of the ops to the source document.
+func script(ops []partial.FieldOp) map[string]any {
+	statements, params := []string{}, map[string]any{}
+	for _, op := range ops {
+		operator := "="
+		if op.Kind == partial.FieldOpIncrement {
+			operator = "+="
+		}
+
+		statements = append(statements, fmt.Sprintf("ctx._source['%s'] %s params['%s'];", op.JSONName, operator, op.JSONName))
+		params[op.JSONName] = op.Value
+	}
+
+	return map[string]any{
+		"lang":   "painless",
+		"source": strings.Join(statements, " "),
+		"params": params,
+	}
+}
@@ -0,0 +1,105 @@
+package partiales_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/partiales"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Document struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Summary  *string `json:"summary"`
+	Views    int     `json:"views"`
+	Internal string  `json:"-"`
+	Secret   string  `json:"secret" partial:"encrypted"`
+}
+
+// reverseEncryptor "encrypts" by reversing the bytes, which is enough to tell whether a
+// value has been encrypted.
+type reverseEncryptor struct{}
+
+func (reverseEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	reversed := []byte{}
+	for idx := len(plaintext) - 1; idx >= 0; idx-- {
+		reversed = append(reversed, plaintext[idx])
+	}
+
+	return reversed, nil
+}
+
+func (e reverseEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	return e.Encrypt(ciphertext)
+}
+
+var _ = Describe("Update", func() {
+	build := func(opts ...partial.Option[Document]) partial.Partial[Document] {
+		model, err := partial.New(&Document{})
+		Expect(err).NotTo(HaveOccurred())
+
+		return model.Without(model.FieldNames...).Add(opts...)
+	}
+
+	It("writes tracked fields as a partial document by JSON name", func() {
+		model := build(
+			func(doc *Document) []string {
+				doc.Name = "Peanuts"
+				doc.Internal = "secret"
+				return []string{"Name", "Summary", "Internal"}
+			},
+		)
+
+		Expect(partiales.Update(model)).To(Equal(map[string]any{
+			"doc": map[string]any{"name": "Peanuts", "summary": nil},
+		}))
+	})
+
+	It("writes partials with increments as a script", func() {
+		model := build(
+			func(doc *Document) []string {
+				doc.Name = "Peanuts"
+				return []string{"Name"}
+			},
+		).Increment("Views", 2)
+
+		Expect(partiales.Update(model)).To(Equal(map[string]any{
+			"script": map[string]any{
+				"lang":   "painless",
+				"source": "ctx._source['name'] = params['name']; ctx._source['views'] += params['views'];",
+				"params": map[string]any{"name": "Peanuts", "views": 2},
+			},
+		}))
+	})
+
+	Context("with encrypted fields", func() {
+		var model partial.Partial[Document]
+
+		BeforeEach(func() {
+			model = build(
+				func(doc *Document) []string {
+					doc.Secret = "secret"
+					return []string{"Secret"}
+				},
+			)
+		})
+
+		AfterEach(func() {
+			partial.RegisterEncryptor(nil)
+		})
+
+		It("writes them encrypted", func() {
+			partial.RegisterEncryptor(reverseEncryptor{})
+
+			Expect(partiales.Update(model)).To(Equal(map[string]any{
+				"doc": map[string]any{"secret": "dGVyY2Vz"}, // base64 of "terces"
+			}))
+		})
+
+		It("fails if no encryptor is registered", func() {
+			_, err := partiales.Update(model)
+			Expect(err).To(MatchError(ContainSubstring("no encryptor has been registered")))
+		})
+	})
+})
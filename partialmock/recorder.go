@@ -0,0 +1,109 @@
+// Package partialmock provides test doubles for code that persists partials, such as
+// fake repositories.
+package partialmock
+
+import (
+	"sync"
+
+	"github.com/incident-io/partial"
+)
+
+// UpdateRecorder captures every partial passed to a fake repository, so tests can assert
+// on what would have been written:
+//
+//	type fakeIncidentStore struct {
+//		Updates partialmock.UpdateRecorder[Incident]
+//	}
+//
+//	func (s *fakeIncidentStore) Update(ctx context.Context, model partial.Partial[Incident]) error {
+//		s.Updates.Record(model)
+//		return nil
+//	}
+//
+//	Expect(store.Updates.Last().Tracked("Name")).To(BeTrue())
+//
+// The zero value is ready to use, and it is safe to record from multiple goroutines.
+type UpdateRecorder[T any] struct {
+	mu      sync.Mutex
+	updates []partial.Partial[T]
+}
+
+// Record captures a partial.
+func (r *UpdateRecorder[T]) Record(model partial.Partial[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.updates = append(r.updates, model)
+}
+
+// Updates returns every recorded partial, oldest first.
+func (r *UpdateRecorder[T]) Updates() []RecordedUpdate[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updates := []RecordedUpdate[T]{}
+	for _, model := range r.updates {
+		updates = append(updates, RecordedUpdate[T]{Partial: model})
+	}
+
+	return updates
+}
+
+// Len returns how many partials have been recorded.
+func (r *UpdateRecorder[T]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.updates)
+}
+
+// Last returns the most recently recorded partial, or an empty one if nothing has been
+// recorded.
+func (r *UpdateRecorder[T]) Last() RecordedUpdate[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.updates) == 0 {
+		return RecordedUpdate[T]{}
+	}
+
+	return RecordedUpdate[T]{Partial: r.updates[len(r.updates)-1]}
+}
+
+// TotalFieldsWritten sums the tracked fields of every recorded partial, which is useful
+// for asserting that a flow doesn't write more than it needs to.
+func (r *UpdateRecorder[T]) TotalFieldsWritten() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total := 0
+	for _, model := range r.updates {
+		total += len(model.FieldNames)
+	}
+
+	return total
+}
+
+// Reset forgets every recorded partial.
+func (r *UpdateRecorder[T]) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.updates = nil
+}
+
+// RecordedUpdate is a partial captured by an UpdateRecorder.
+type RecordedUpdate[T any] struct {
+	partial.Partial[T]
+}
+
+// Tracked returns true if the partial tracked every one of the given fields.
+func (u RecordedUpdate[T]) Tracked(fieldNames ...string) bool {
+	for _, fieldName := range fieldNames {
+		if !u.Tracks(fieldName) {
+			return false
+		}
+	}
+
+	return true
+}
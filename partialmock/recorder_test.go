@@ -0,0 +1,60 @@
+package partialmock_test
+
+import (
+	"github.com/incident-io/partial/partialmock"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpdateRecorder", func() {
+	var (
+		recorder *partialmock.UpdateRecorder[test.Organisation]
+	)
+
+	BeforeEach(func() {
+		recorder = &partialmock.UpdateRecorder[test.Organisation]{}
+	})
+
+	Context("with nothing recorded", func() {
+		It("returns an empty last update", func() {
+			Expect(recorder.Len()).To(Equal(0))
+			Expect(recorder.Last().Tracked("Name")).To(BeFalse())
+			Expect(recorder.TotalFieldsWritten()).To(Equal(0))
+		})
+	})
+
+	Context("with recorded updates", func() {
+		BeforeEach(func() {
+			recorder.Record(test.OrganisationBuilder(
+				test.OrganisationBuilder.ID("id"),
+				test.OrganisationBuilder.Name("first"),
+			))
+			recorder.Record(test.OrganisationBuilder(
+				test.OrganisationBuilder.Name("second"),
+			))
+		})
+
+		It("returns the most recent update", func() {
+			Expect(recorder.Last().Tracked("Name")).To(BeTrue())
+			Expect(recorder.Last().Tracked("Name", "ID")).To(BeFalse())
+			Expect(recorder.Last().Subject.Name).To(Equal("second"))
+		})
+
+		It("returns every update in order", func() {
+			updates := recorder.Updates()
+			Expect(updates).To(HaveLen(2))
+			Expect(updates[0].Tracked("ID", "Name")).To(BeTrue())
+		})
+
+		It("totals the fields written", func() {
+			Expect(recorder.TotalFieldsWritten()).To(Equal(3))
+		})
+
+		It("forgets updates once reset", func() {
+			recorder.Reset()
+			Expect(recorder.Len()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package partialmock_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPartialmock(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Partialmock Suite")
+}
@@ -0,0 +1,13 @@
+package partialmongo_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPartialmongo(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Partialmongo Suite")
+}
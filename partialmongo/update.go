@@ -0,0 +1,76 @@
+// Package partialmongo builds MongoDB update documents from partials, so documents get
+// the same patch discipline as rows.
+package partialmongo
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/incident-io/partial"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Update returns an update document writing each tracked field of the partial:
+//
+//	bson.M{
+//		"$set":   bson.M{"name": "Peanuts"},
+//		"$unset": bson.M{"deleted_at": ""},
+//		"$inc":   bson.M{"incident_count": 1},
+//	}
+//
+// Only operators with fields are included. Fields are named by their bson tag, or their
+// lowercased name if they have none, matching the driver. Fields tagged `bson:"-"` are
+// never written, and encrypted fields are encrypted.
+func Update[T any](model partial.Partial[T]) (bson.M, error) {
+	ops, err := model.EncryptedOps()
+	if err != nil {
+		return nil, err
+	}
+
+	subjectType := reflect.TypeOf(model.Subject)
+
+	set, unset, inc := bson.M{}, bson.M{}, bson.M{}
+	for _, op := range ops {
+		field, ok := subjectType.FieldByName(op.FieldName)
+		if !ok {
+			continue
+		}
+
+		key := keyFor(field)
+		if key == "" {
+			continue
+		}
+
+		switch op.Kind {
+		case partial.FieldOpSet:
+			set[key] = op.Value
+		case partial.FieldOpClear:
+			unset[key] = ""
+		case partial.FieldOpIncrement:
+			inc[key] = op.Value
+		}
+	}
+
+	update := bson.M{}
+	for operator, fields := range map[string]bson.M{"$set": set, "$unset": unset, "$inc": inc} {
+		if len(fields) > 0 {
+			update[operator] = fields
+		}
+	}
+
+	return update, nil
+}
+
+// keyFor returns the name of the field in the document, or an empty string if the field
+// is never written.
+func keyFor(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("bson"), ",")[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(field.Name)
+	}
+
+	return name
+}
@@ -0,0 +1,103 @@
+package partialmongo_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/partialmongo"
+	"go.mongodb.org/mongo-driver/bson"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Document struct {
+	ID       string  `bson:"_id"`
+	Name     string  `bson:"name"`
+	Summary  *string `bson:"summary,omitempty"`
+	Views    int     `bson:"views"`
+	Internal string  `bson:"-"`
+	Untagged string
+	Secret   string `bson:"secret" partial:"encrypted"`
+}
+
+// reverseEncryptor "encrypts" by reversing the bytes, which is enough to tell whether a
+// value has been encrypted.
+type reverseEncryptor struct{}
+
+func (reverseEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	reversed := []byte{}
+	for idx := len(plaintext) - 1; idx >= 0; idx-- {
+		reversed = append(reversed, plaintext[idx])
+	}
+
+	return reversed, nil
+}
+
+func (e reverseEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	return e.Encrypt(ciphertext)
+}
+
+var _ = Describe("Update", func() {
+	build := func(opts ...partial.Option[Document]) partial.Partial[Document] {
+		model, err := partial.New(&Document{})
+		Expect(err).NotTo(HaveOccurred())
+
+		return model.Add(opts...)
+	}
+
+	It("sets, unsets and increments tracked fields by bson name", func() {
+		model := build(
+			func(doc *Document) []string {
+				doc.Name = "Peanuts"
+				doc.Untagged = "value"
+				return []string{"Name", "Untagged", "Summary"}
+			},
+		).Increment("Views", 1)
+
+		Expect(partialmongo.Update(model)).To(Equal(bson.M{
+			"$set":   bson.M{"name": "Peanuts", "untagged": "value"},
+			"$unset": bson.M{"summary": ""},
+			"$inc":   bson.M{"views": 1},
+		}))
+	})
+
+	It("never writes fields excluded from the document", func() {
+		model := build(
+			func(doc *Document) []string {
+				doc.Internal = "secret"
+				return []string{"Internal"}
+			},
+		)
+
+		Expect(partialmongo.Update(model)).To(BeEmpty())
+	})
+
+	Context("with encrypted fields", func() {
+		var model partial.Partial[Document]
+
+		BeforeEach(func() {
+			model = build(
+				func(doc *Document) []string {
+					doc.Secret = "secret"
+					return []string{"Secret"}
+				},
+			)
+		})
+
+		AfterEach(func() {
+			partial.RegisterEncryptor(nil)
+		})
+
+		It("writes them encrypted", func() {
+			partial.RegisterEncryptor(reverseEncryptor{})
+
+			Expect(partialmongo.Update(model)).To(Equal(bson.M{
+				"$set": bson.M{"secret": "dGVyY2Vz"}, // base64 of "terces"
+			}))
+		})
+
+		It("fails if no encryptor is registered", func() {
+			_, err := partialmongo.Update(model)
+			Expect(err).To(MatchError(ContainSubstring("no encryptor has been registered")))
+		})
+	})
+})
@@ -0,0 +1,113 @@
+// Package partialredis converts partials into Redis hash commands, so cache layers that
+// mirror a subset of a row in a hash can write only the fields that changed.
+package partialredis
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/incident-io/partial"
+	"github.com/pkg/errors"
+)
+
+// Mapper configures how fields of a partial are written to a hash. The zero value names
+// fields by their JSON tag and encodes values with DefaultEncode.
+type Mapper struct {
+	// FieldName returns the hash field for the op, or an empty string to skip it.
+	FieldName func(op partial.FieldOp) string
+	// Encode converts a value to a form the Redis client can write.
+	Encode func(value any) (any, error)
+}
+
+// Fields are the hash commands needed to write a partial.
+type Fields struct {
+	Set       []any          // field/value pairs for HSET
+	Delete    []string       // fields to HDEL, as they were cleared
+	Increment map[string]any // fields to HINCRBY or HINCRBYFLOAT, by the amount to increment
+}
+
+// Empty returns true if there is nothing to write.
+func (f Fields) Empty() bool {
+	return len(f.Set) == 0 && len(f.Delete) == 0 && len(f.Increment) == 0
+}
+
+// HSet returns the hash commands writing each tracked field of the partial:
+//
+//	fields, err := partialredis.HSet(model, partialredis.Mapper{})
+//	if len(fields.Set) > 0 {
+//		pipe.HSet(ctx, key, fields.Set...)
+//	}
+func HSet[T any](model partial.Partial[T], mapper Mapper) (Fields, error) {
+	if mapper.FieldName == nil {
+		mapper.FieldName = func(op partial.FieldOp) string {
+			return op.JSONName
+		}
+	}
+	if mapper.Encode == nil {
+		mapper.Encode = DefaultEncode
+	}
+
+	ops, err := model.EncryptedOps()
+	if err != nil {
+		return Fields{}, err
+	}
+
+	fields := Fields{
+		Set:       []any{},
+		Delete:    []string{},
+		Increment: map[string]any{},
+	}
+	for _, op := range ops {
+		name := mapper.FieldName(op)
+		if name == "" {
+			continue
+		}
+
+		switch op.Kind {
+		case partial.FieldOpSet:
+			value, err := mapper.Encode(op.Value)
+			if err != nil {
+				return Fields{}, errors.Wrap(err, fmt.Sprintf("encoding %s", op.FieldName))
+			}
+
+			fields.Set = append(fields.Set, name, value)
+		case partial.FieldOpClear:
+			fields.Delete = append(fields.Delete, name)
+		case partial.FieldOpIncrement:
+			fields.Increment[name] = op.Value
+		}
+	}
+
+	return fields, nil
+}
+
+// DefaultEncode writes strings, byte slices, numbers and booleans as-is, types
+// implementing encoding.TextMarshaler (such as time.Time) as text, and anything else as
+// JSON.
+func DefaultEncode(value any) (any, error) {
+	switch value := value.(type) {
+	case string, []byte,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+
+		return string(text), nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,130 @@
+package partialredis_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/partialredis"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type Document struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Archived  bool              `json:"archived"`
+	CreatedAt time.Time         `json:"created_at"`
+	Labels    map[string]string `json:"labels"`
+	Views     int               `json:"views"`
+	Internal  string            `json:"-"`
+	Secret    string            `json:"secret" partial:"encrypted"`
+}
+
+// reverseEncryptor "encrypts" by reversing the bytes, which is enough to tell whether a
+// value has been encrypted.
+type reverseEncryptor struct{}
+
+func (reverseEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	reversed := []byte{}
+	for idx := len(plaintext) - 1; idx >= 0; idx-- {
+		reversed = append(reversed, plaintext[idx])
+	}
+
+	return reversed, nil
+}
+
+func (e reverseEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	return e.Encrypt(ciphertext)
+}
+
+var _ = Describe("HSet", func() {
+	build := func(opts ...partial.Option[Document]) partial.Partial[Document] {
+		model, err := partial.New(&Document{})
+		Expect(err).NotTo(HaveOccurred())
+
+		return model.Without(model.FieldNames...).Add(opts...)
+	}
+
+	It("sets, deletes and increments tracked fields by JSON name", func() {
+		model := build(
+			func(doc *Document) []string {
+				doc.Name = "Peanuts"
+				doc.Archived = true
+				doc.CreatedAt = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+				doc.Internal = "secret"
+				return []string{"Name", "Archived", "CreatedAt", "Labels", "Internal"}
+			},
+		).Increment("Views", 1)
+
+		fields, err := partialredis.HSet(model, partialredis.Mapper{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields).To(Equal(partialredis.Fields{
+			Set:       []any{"name", "Peanuts", "archived", "true", "created_at", "2022-06-01T12:00:00Z"},
+			Delete:    []string{"labels"},
+			Increment: map[string]any{"views": 1},
+		}))
+	})
+
+	It("uses the mapper's field names and encoder", func() {
+		model := build(
+			func(doc *Document) []string {
+				doc.Name = "Peanuts"
+				doc.Labels = map[string]string{"team": "core"}
+				return []string{"Name", "Labels"}
+			},
+		)
+
+		fields, err := partialredis.HSet(model, partialredis.Mapper{
+			FieldName: func(op partial.FieldOp) string {
+				return "doc:" + op.JSONName
+			},
+			Encode: func(value any) (any, error) {
+				if name, ok := value.(string); ok {
+					return strings.ToUpper(name), nil
+				}
+
+				return partialredis.DefaultEncode(value)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields.Set).To(Equal([]any{"doc:name", "PEANUTS", "doc:labels", `{"team":"core"}`}))
+	})
+
+	It("is empty when nothing is tracked", func() {
+		fields, err := partialredis.HSet(build(), partialredis.Mapper{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fields.Empty()).To(BeTrue())
+	})
+
+	Context("with encrypted fields", func() {
+		var model partial.Partial[Document]
+
+		BeforeEach(func() {
+			model = build(
+				func(doc *Document) []string {
+					doc.Secret = "secret"
+					return []string{"Secret"}
+				},
+			)
+		})
+
+		AfterEach(func() {
+			partial.RegisterEncryptor(nil)
+		})
+
+		It("writes them encrypted", func() {
+			partial.RegisterEncryptor(reverseEncryptor{})
+
+			fields, err := partialredis.HSet(model, partialredis.Mapper{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fields.Set).To(Equal([]any{"secret", "dGVyY2Vz"})) // base64 of "terces"
+		})
+
+		It("fails if no encryptor is registered", func() {
+			_, err := partialredis.HSet(model, partialredis.Mapper{})
+			Expect(err).To(MatchError(ContainSubstring("no encryptor has been registered")))
+		})
+	})
+})
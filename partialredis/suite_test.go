@@ -0,0 +1,13 @@
+package partialredis_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPartialredis(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Partialredis Suite")
+}
@@ -0,0 +1,165 @@
+// Package patch provides a generic PATCH pipeline built from partials, so each resource
+// only has to say how it's loaded, authorised and saved:
+//
+//	registry := patch.NewRegistry()
+//	patch.Register(registry, "incident", patch.Resource[Incident]{
+//		Load: func(ctx context.Context, id string) (*Incident, error) {
+//			return incidentStore.Get(ctx, id)
+//		},
+//		Save: func(ctx context.Context, model partial.Partial[Incident], updated *Incident) error {
+//			return incidentStore.Update(ctx, updated.ID, model)
+//		},
+//	})
+//
+//	updated, err := registry.Patch(ctx, "incident", id, body)
+package patch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/incident-io/partial"
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownResource is returned when patching a resource that hasn't been registered.
+var ErrUnknownResource = errors.New("unknown resource")
+
+// InvalidPatchError is returned when the patch itself is at fault, such as naming a field
+// that doesn't exist or trying to update an immutable field. Handlers will usually want
+// to respond to this with a 400.
+type InvalidPatchError struct {
+	err error
+}
+
+func (e InvalidPatchError) Error() string {
+	return fmt.Sprintf("invalid patch: %s", e.err)
+}
+
+func (e InvalidPatchError) Cause() error {
+	return e.err
+}
+
+func (e InvalidPatchError) Unwrap() error {
+	return e.err
+}
+
+// Resource describes how to patch a single type.
+type Resource[T any] struct {
+	// Load finds the existing record, and is required.
+	Load func(ctx context.Context, id string) (*T, error)
+	// Authorise decides whether the caller may apply the partial to the existing record.
+	// It is optional, and any error it returns aborts the patch.
+	Authorise func(ctx context.Context, existing *T, model partial.Partial[T]) error
+	// Save persists the partial, and is required. The updated record is the result of
+	// applying the partial to the existing one, so has its encrypted fields encrypted.
+	Save func(ctx context.Context, model partial.Partial[T], updated *T) error
+}
+
+// Patch loads the record with the given ID, builds a partial from the JSON merge patch,
+// authorises it, then applies and saves it, returning the updated record.
+//
+// If the patch wouldn't change the record, it is not saved.
+func (r Resource[T]) Patch(ctx context.Context, id string, patch []byte) (*T, error) {
+	existing, err := r.Load(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading existing record")
+	}
+
+	model, err := partial.NewFromMergePatch[T](patch)
+	if err != nil {
+		return nil, InvalidPatchError{err}
+	}
+	if err := model.Validate(partial.OperationUpdate); err != nil {
+		return nil, InvalidPatchError{err}
+	}
+
+	if r.Authorise != nil {
+		if err := r.Authorise(ctx, existing, model); err != nil {
+			return nil, errors.Wrap(err, "authorising patch")
+		}
+	}
+
+	if model.Match(existing) {
+		return existing, nil
+	}
+
+	updated := model.Apply(*existing)
+	if err := r.Save(ctx, model, updated); err != nil {
+		return nil, errors.Wrap(err, "saving patched record")
+	}
+
+	// Apply encrypted the record for saving, but callers expect it as it was loaded
+	if err := partial.Decrypt(updated); err != nil {
+		return nil, errors.Wrap(err, "decrypting patched record")
+	}
+
+	return updated, nil
+}
+
+// Registry holds resources by name, so a single handler can patch any of them.
+type Registry struct {
+	mu        sync.RWMutex
+	resources map[string]func(ctx context.Context, id string, patch []byte) (any, error)
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		resources: map[string]func(ctx context.Context, id string, patch []byte) (any, error){},
+	}
+}
+
+// Register adds a resource to the registry under the given name. It panics if the name
+// has already been registered, or the resource is missing its Load or Save functions.
+func Register[T any](registry *Registry, name string, resource Resource[T]) {
+	if resource.Load == nil || resource.Save == nil {
+		panic(fmt.Sprintf("patch: resource %s must have both Load and Save", name))
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, ok := registry.resources[name]; ok {
+		panic(fmt.Sprintf("patch: resource %s is already registered", name))
+	}
+
+	registry.resources[name] = func(ctx context.Context, id string, patch []byte) (any, error) {
+		updated, err := resource.Patch(ctx, id, patch)
+		if err != nil {
+			return nil, err
+		}
+
+		return updated, nil
+	}
+}
+
+// Names returns the name of every registered resource, sorted alphabetically.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := []string{}
+	for name := range r.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Patch applies the JSON merge patch to the record of the named resource, returning the
+// updated record as a pointer to the resource's type.
+func (r *Registry) Patch(ctx context.Context, name, id string, patch []byte) (any, error) {
+	r.mu.RLock()
+	resource, ok := r.resources[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownResource, name)
+	}
+
+	return resource(ctx, id, patch)
+}
@@ -0,0 +1,119 @@
+package patch_test
+
+import (
+	"context"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/patch"
+	"github.com/incident-io/partial/test"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var (
+		ctx      context.Context
+		registry *patch.Registry
+		existing *test.Incident
+		saved    []partial.Partial[test.Incident]
+		denied   bool
+		updated  any
+		err      error
+		body     []byte
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		existing = &test.Incident{ID: "incident-id", OrganisationID: "org-id"}
+		saved = nil
+		denied = false
+
+		registry = patch.NewRegistry()
+		patch.Register(registry, "incident", patch.Resource[test.Incident]{
+			Load: func(ctx context.Context, id string) (*test.Incident, error) {
+				if id != existing.ID {
+					return nil, errors.New("not found")
+				}
+
+				return existing, nil
+			},
+			Authorise: func(ctx context.Context, existing *test.Incident, model partial.Partial[test.Incident]) error {
+				if denied {
+					return errors.New("forbidden")
+				}
+
+				return nil
+			},
+			Save: func(ctx context.Context, model partial.Partial[test.Incident], updated *test.Incident) error {
+				saved = append(saved, model)
+				return nil
+			},
+		})
+	})
+
+	JustBeforeEach(func() {
+		updated, err = registry.Patch(ctx, "incident", "incident-id", body)
+	})
+
+	Context("with a valid patch", func() {
+		BeforeEach(func() {
+			body = []byte(`{"organisation_id":"other-org-id"}`)
+		})
+
+		It("saves the partial and returns the updated record", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(HaveLen(1))
+			Expect(saved[0].FieldNames).To(ConsistOf("OrganisationID"))
+			Expect(updated).To(test.IncidentMatcher(
+				test.IncidentMatcher.ID("incident-id"),
+				test.IncidentMatcher.OrganisationID("other-org-id"),
+			))
+		})
+	})
+
+	Context("when the patch doesn't change anything", func() {
+		BeforeEach(func() {
+			body = []byte(`{"organisation_id":"org-id"}`)
+		})
+
+		It("does not save", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(BeEmpty())
+			Expect(updated).To(Equal(existing))
+		})
+	})
+
+	Context("when the patch updates an immutable field", func() {
+		BeforeEach(func() {
+			body = []byte(`{"id":"new-id"}`)
+		})
+
+		It("returns an invalid patch error", func() {
+			Expect(errors.As(err, &patch.InvalidPatchError{})).To(BeTrue())
+			Expect(saved).To(BeEmpty())
+		})
+	})
+
+	Context("when the caller isn't authorised", func() {
+		BeforeEach(func() {
+			body = []byte(`{"organisation_id":"other-org-id"}`)
+			denied = true
+		})
+
+		It("does not save", func() {
+			Expect(err).To(MatchError(ContainSubstring("forbidden")))
+			Expect(saved).To(BeEmpty())
+		})
+	})
+
+	It("errors for unknown resources", func() {
+		_, err := registry.Patch(ctx, "unknown", "id", []byte(`{}`))
+		Expect(errors.Cause(err)).To(Equal(patch.ErrUnknownResource))
+	})
+
+	It("lists registered resources", func() {
+		Expect(registry.Names()).To(Equal([]string{"incident"}))
+	})
+})
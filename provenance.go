@@ -0,0 +1,57 @@
+package partial
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/onsi/gomega/types"
+)
+
+var provenanceEnabled int32
+
+// EnableProvenance makes generated matchers record where each of their options was
+// called, and include it in failure messages. This makes failures in large composed
+// matchers much easier to trace back to the expectation that caused them, at the cost of
+// looking up the caller every time an option is used.
+func EnableProvenance() {
+	atomic.StoreInt32(&provenanceEnabled, 1)
+}
+
+// DisableProvenance stops generated matchers from recording where options were called.
+func DisableProvenance() {
+	atomic.StoreInt32(&provenanceEnabled, 0)
+}
+
+// WithProvenance is called by generated matcher options, such as
+// IncidentMatcher.OrganisationID, to annotate failures of the matcher with the option
+// and the location that called it. This returns the matcher unchanged unless provenance
+// has been enabled.
+func WithProvenance(matcher types.GomegaMatcher, option string) types.GomegaMatcher {
+	if atomic.LoadInt32(&provenanceEnabled) == 0 {
+		return matcher
+	}
+
+	// Skip ourselves and the generated option, to find whoever called the option
+	provenance := option
+	if _, file, line, ok := runtime.Caller(2); ok {
+		provenance = fmt.Sprintf("%s at %s:%d", option, filepath.Base(file), line)
+	}
+
+	return &provenanceMatcher{GomegaMatcher: matcher, provenance: provenance}
+}
+
+// provenanceMatcher wraps a matcher, adding where it came from to its failure messages.
+type provenanceMatcher struct {
+	types.GomegaMatcher
+	provenance string // IncidentMatcher.OrganisationID at incident_test.go:42
+}
+
+func (m *provenanceMatcher) FailureMessage(actual any) string {
+	return fmt.Sprintf("%s\n(expected by %s)", m.GomegaMatcher.FailureMessage(actual), m.provenance)
+}
+
+func (m *provenanceMatcher) NegatedFailureMessage(actual any) string {
+	return fmt.Sprintf("%s\n(expected by %s)", m.GomegaMatcher.NegatedFailureMessage(actual), m.provenance)
+}
@@ -0,0 +1,56 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Provenance", func() {
+	var (
+		incident = &test.Incident{OrganisationID: "org-id"}
+	)
+
+	Context("when enabled", func() {
+		BeforeEach(func() {
+			partial.EnableProvenance()
+		})
+
+		AfterEach(func() {
+			partial.DisableProvenance()
+		})
+
+		It("names the option and where it was called in failure messages", func() {
+			matcher := test.IncidentMatcher(
+				test.IncidentMatcher.OrganisationID("other-org-id"),
+			)
+
+			match, err := matcher.Match(incident)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(match).To(BeFalse())
+			Expect(matcher.FailureMessage(incident)).To(MatchRegexp(
+				`\(expected by IncidentMatcher.OrganisationID at provenance_test.go:\d+\)`,
+			))
+		})
+
+		It("still matches", func() {
+			Expect(incident).To(test.IncidentMatcher(
+				test.IncidentMatcher.OrganisationID("org-id"),
+			))
+		})
+	})
+
+	Context("when disabled", func() {
+		It("leaves failure messages untouched", func() {
+			matcher := test.IncidentMatcher(
+				test.IncidentMatcher.OrganisationID("other-org-id"),
+			)
+
+			_, err := matcher.Match(incident)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matcher.FailureMessage(incident)).NotTo(ContainSubstring("expected by"))
+		})
+	})
+})
@@ -0,0 +1,186 @@
+package partial
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gorm.io/gorm/schema"
+)
+
+// fieldInfo describes a single field of a struct that can be tracked by a Partial, as
+// parsed from the field's struct tags.
+type fieldInfo struct {
+	Name       string // ID
+	Index      []int  // position in the struct, for FieldByIndex
+	JSONName   string // id, or CreatedAt for untagged fields promoted from gorm.Model
+	ColumnName string // id, or created_at for untagged fields promoted from gorm.Model
+	Immutable  bool   // partial:"immutable"
+	ReadOnly   bool   // partial:"readonly"
+	Encrypted  bool   // partial:"encrypted"
+}
+
+// DatabaseBacked is true if the field maps onto a column, which we infer from the field
+// having a JSON name. Associations and other in-memory fields have no JSON tag.
+func (f fieldInfo) DatabaseBacked() bool {
+	return f.ColumnName != ""
+}
+
+// schemaCache caches the parsed fields for each struct type, as the tags for a type can
+// never change at runtime.
+var schemaCache sync.Map // map[reflect.Type][]fieldInfo
+
+// schemaFor returns the parsed fields for the given struct type, including those
+// promoted from embedded structs. Unexported fields are skipped, as reflect can neither
+// read nor set them, and types that aren't structs have no fields at all.
+func schemaFor(subjectType reflect.Type) []fieldInfo {
+	if cached, ok := schemaCache.Load(subjectType); ok {
+		return cached.([]fieldInfo)
+	}
+
+	fields := []fieldInfo{}
+	if subjectType.Kind() == reflect.Struct {
+		fields = structFieldsFor(subjectType, subjectType, nil)
+	}
+
+	schemaCache.Store(subjectType, fields)
+
+	return fields
+}
+
+// structFieldsFor parses the fields of structType, which is found at index within
+// subjectType.
+//
+// Structs embedded without a JSON tag, such as gorm.Model, have their fields promoted
+// into the parent as they do for encoding/json and gorm. We can't tag those fields, so
+// any without a JSON tag have the JSON name encoding/json gives them, which is the field
+// name, and the column name gorm gives them. Every other field's column is named by its
+// JSON tag. As in Go, a promoted field is shadowed by any other of the same name nearer
+// the top.
+func structFieldsFor(subjectType, structType reflect.Type, index []int) []fieldInfo {
+	fields := []fieldInfo{}
+	for idx := 0; idx < structType.NumField(); idx++ {
+		field := structType.Field(idx)
+		fieldIndex := append(append([]int{}, index...), idx)
+
+		_, hasJSONTag := field.Tag.Lookup("json")
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && !hasJSONTag {
+			fields = append(fields, structFieldsFor(subjectType, field.Type, fieldIndex)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		jsonName := jsonNameFor(field)
+		columnName := jsonName
+		if len(index) > 0 {
+			if promoted, ok := subjectType.FieldByName(field.Name); !ok || !slices.Equal(promoted.Index, fieldIndex) {
+				continue
+			}
+			if !hasJSONTag {
+				jsonName, columnName = field.Name, columnNamer.ColumnName("", field.Name)
+			}
+		}
+
+		options := parseTagOptions(field.Tag.Get("partial"))
+
+		fields = append(fields, fieldInfo{
+			Name:       field.Name,
+			Index:      fieldIndex,
+			JSONName:   jsonName,
+			ColumnName: columnName,
+			Immutable:  options.Has("immutable"),
+			ReadOnly:   options.Has("readonly"),
+			Encrypted:  options.Has("encrypted"),
+		})
+	}
+
+	return fields
+}
+
+// columnNamer names columns for fields promoted from embedded structs, the same way gorm
+// does by default.
+var columnNamer = schema.NamingStrategy{}
+
+// checkSubject errors if we can't track the fields of the subject, which must be a
+// non-nil pointer to a struct, rather than leaving reflect to panic on it later.
+func checkSubject[T any](subjectPtr *T) error {
+	subjectType := reflect.TypeOf(subjectPtr).Elem()
+	if subjectPtr == nil {
+		return errors.New(fmt.Sprintf("cannot track fields of a nil *%s", subjectType))
+	}
+
+	switch subjectType.Kind() {
+	case reflect.Struct:
+		return nil
+	case reflect.Interface:
+		boxed := reflect.ValueOf(subjectPtr).Elem()
+		if boxed.IsNil() {
+			return errors.New(fmt.Sprintf("cannot track fields of a nil %s", subjectType))
+		}
+
+		return errors.New(fmt.Sprintf("cannot track fields of %s boxed in %s, pass a pointer to the struct itself", boxed.Elem().Type(), subjectType))
+	case reflect.Pointer:
+		return errors.New(fmt.Sprintf("cannot track fields of %s, pass a pointer to the struct rather than a pointer to a pointer", subjectType))
+	default:
+		return errors.New(fmt.Sprintf("cannot track fields of %s, which is not a struct", subjectType))
+	}
+}
+
+// schemaFieldFor finds the parsed field with the given name in the schema.
+func schemaFieldFor(subjectType reflect.Type, fieldName string) (fieldInfo, bool) {
+	for _, field := range schemaFor(subjectType) {
+		if field.Name == fieldName {
+			return field, true
+		}
+	}
+
+	return fieldInfo{}, false
+}
+
+// jsonNameFor returns the name of the field when serialised to JSON, or an empty string
+// if the field is not serialised.
+func jsonNameFor(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return ""
+	}
+
+	name := strings.Split(tag, ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+// tagOptions are the comma separated options from a `partial:"..."` struct tag, such as
+// `partial:"immutable,encrypted"`. Options may also take a value, as in key=value.
+type tagOptions map[string]string
+
+func parseTagOptions(tag string) tagOptions {
+	options := tagOptions{}
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(option, "=")
+		options[key] = value
+	}
+
+	return options
+}
+
+func (o tagOptions) Has(key string) bool {
+	_, ok := o[key]
+	return ok
+}
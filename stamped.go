@@ -0,0 +1,71 @@
+package partial
+
+import "time"
+
+// Write records when, and by whom, a field was set.
+type Write struct {
+	At time.Time
+	By string // the actor, such as a user or service ID
+}
+
+// Stamped wraps a Partial, recording the last write to each tracked field. Use it when
+// resolving conflicts between patches that may arrive out of order, where the most
+// recent write to a field should win regardless of the order patches are merged in:
+//
+//	first := partial.Stamp(fromWorker, workerSentAt, "worker")
+//	second := partial.Stamp(fromAPI, apiSentAt, "user-id")
+//
+//	merged := first.Merge(second) // each field takes the value written last
+type Stamped[T any] struct {
+	Partial[T]
+	writes map[string]Write // keyed by field name
+}
+
+// Stamp records every field tracked by the partial as written at the given time, by the
+// given actor.
+func Stamp[T any](model Partial[T], at time.Time, by string) Stamped[T] {
+	writes := map[string]Write{}
+	for _, fieldName := range model.FieldNames {
+		writes[fieldName] = Write{At: at, By: by}
+	}
+
+	return Stamped[T]{Partial: model, writes: writes}
+}
+
+// LastWrite returns when and by whom the field was last written, or false if the field
+// isn't tracked.
+func (s Stamped[T]) LastWrite(fieldName string) (Write, bool) {
+	if !s.Tracks(fieldName) {
+		return Write{}, false
+	}
+
+	write, ok := s.writes[fieldName]
+	return write, ok
+}
+
+// Merge combines two stamped partials, taking each field from whichever wrote it last.
+// If both wrote a field at the same time, other wins, as with Partial.Merge.
+func (s Stamped[T]) Merge(other Stamped[T]) Stamped[T] {
+	newer, older := []string{}, []string{}
+	for _, fieldName := range other.FieldNames {
+		existing, ok := s.LastWrite(fieldName)
+		if ok && existing.At.After(other.writes[fieldName].At) {
+			older = append(older, fieldName)
+		} else {
+			newer = append(newer, fieldName)
+		}
+	}
+
+	writes := map[string]Write{}
+	for fieldName, write := range s.writes {
+		writes[fieldName] = write
+	}
+	for _, fieldName := range newer {
+		writes[fieldName] = other.writes[fieldName]
+	}
+
+	return Stamped[T]{
+		Partial: s.Partial.Merge(other.Partial.Without(older...)),
+		writes:  writes,
+	}
+}
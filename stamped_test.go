@@ -0,0 +1,62 @@
+package partial_test
+
+import (
+	"time"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stamped", func() {
+	var (
+		earlier = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+		later   = earlier.Add(time.Minute)
+	)
+
+	It("records the last write to each tracked field", func() {
+		stamped := partial.Stamp(test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+		), earlier, "user-id")
+
+		write, ok := stamped.LastWrite("Name")
+		Expect(ok).To(BeTrue())
+		Expect(write).To(Equal(partial.Write{At: earlier, By: "user-id"}))
+
+		_, ok = stamped.LastWrite("BoolFlag")
+		Expect(ok).To(BeFalse())
+	})
+
+	Describe("Merge", func() {
+		var worker, api partial.Stamped[test.Organisation]
+
+		BeforeEach(func() {
+			worker = partial.Stamp(test.OrganisationBuilder(
+				test.OrganisationBuilder.Name("From worker"),
+				test.OrganisationBuilder.BoolFlag(true),
+			), later, "worker")
+			api = partial.Stamp(test.OrganisationBuilder(
+				test.OrganisationBuilder.Name("From API"),
+				test.OrganisationBuilder.IncidentCount(3),
+			), earlier, "user-id")
+		})
+
+		It("takes each field from whichever wrote it last, regardless of merge order", func() {
+			for _, merged := range []partial.Stamped[test.Organisation]{worker.Merge(api), api.Merge(worker)} {
+				Expect(merged.FieldNames).To(ConsistOf("Name", "BoolFlag", "IncidentCount"))
+				Expect(merged.Apply(test.Organisation{})).To(test.OrganisationMatcher(
+					test.OrganisationMatcher.Name("From worker"),
+					test.OrganisationMatcher.BoolFlag(true),
+					test.OrganisationMatcher.IncidentCount(3),
+				))
+
+				write, _ := merged.LastWrite("Name")
+				Expect(write).To(Equal(partial.Write{At: later, By: "worker"}))
+				write, _ = merged.LastWrite("IncidentCount")
+				Expect(write).To(Equal(partial.Write{At: earlier, By: "user-id"}))
+			}
+		})
+	})
+})
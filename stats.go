@@ -0,0 +1,91 @@
+package partial
+
+import (
+	"expvar"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// TypeStats counts how often partials of a type were applied, and how often each field
+// was included in them.
+type TypeStats struct {
+	Applied int64            `json:"applied"`
+	Fields  map[string]int64 `json:"fields"` // keyed by field name
+}
+
+var (
+	statsEnabled int32
+	statsMu      sync.Mutex
+	stats        = map[string]*TypeStats{} // keyed by type, such as test.Incident
+)
+
+// EnableStats starts counting how often each field is included in applied partials. Use
+// this to spot fields being rewritten far more often than expected, such as a timestamp
+// that every update path touches.
+func EnableStats() {
+	atomic.StoreInt32(&statsEnabled, 1)
+}
+
+// DisableStats stops counting applied partials.
+func DisableStats() {
+	atomic.StoreInt32(&statsEnabled, 0)
+}
+
+// ResetStats forgets everything counted so far.
+func ResetStats() {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	stats = map[string]*TypeStats{}
+}
+
+// recordStats counts an applied partial. This does nothing unless stats have been
+// enabled.
+func recordStats(subjectType reflect.Type, fieldNames []string) {
+	if atomic.LoadInt32(&statsEnabled) == 0 {
+		return
+	}
+
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	typeStats, ok := stats[subjectType.String()]
+	if !ok {
+		typeStats = &TypeStats{Fields: map[string]int64{}}
+		stats[subjectType.String()] = typeStats
+	}
+
+	typeStats.Applied++
+	for _, fieldName := range fieldNames {
+		typeStats.Fields[fieldName]++
+	}
+}
+
+// Stats returns a copy of the counts for each type, keyed by the package qualified name
+// of the type such as test.Incident.
+func Stats() map[string]TypeStats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	snapshot := map[string]TypeStats{}
+	for typeName, typeStats := range stats {
+		fields := map[string]int64{}
+		for fieldName, count := range typeStats.Fields {
+			fields[fieldName] = count
+		}
+
+		snapshot[typeName] = TypeStats{Applied: typeStats.Applied, Fields: fields}
+	}
+
+	return snapshot
+}
+
+// PublishStats exposes Stats as an expvar with the given name, to be served from
+// /debug/vars or scraped by an expvar collector. Like expvar.Publish, this panics if
+// the name is already in use.
+func PublishStats(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return Stats()
+	}))
+}
@@ -0,0 +1,66 @@
+package partial_test
+
+import (
+	"encoding/json"
+	"expvar"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stats", func() {
+	apply := func() {
+		test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+		).Apply(test.Organisation{})
+		test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+			test.OrganisationBuilder.BoolFlag(true),
+		).Apply(test.Organisation{})
+	}
+
+	BeforeEach(func() {
+		partial.ResetStats()
+	})
+
+	Context("when enabled", func() {
+		BeforeEach(func() {
+			partial.EnableStats()
+		})
+
+		AfterEach(func() {
+			partial.DisableStats()
+		})
+
+		It("counts how often each field is applied", func() {
+			apply()
+
+			Expect(partial.Stats()).To(Equal(map[string]partial.TypeStats{
+				"test.Organisation": {
+					Applied: 2,
+					Fields:  map[string]int64{"Name": 2, "BoolFlag": 1},
+				},
+			}))
+		})
+
+		It("publishes stats as an expvar", func() {
+			partial.PublishStats("partial_stats_test")
+			apply()
+
+			var published map[string]partial.TypeStats
+			Expect(json.Unmarshal([]byte(expvar.Get("partial_stats_test").String()), &published)).To(Succeed())
+			Expect(published["test.Organisation"].Fields["BoolFlag"]).To(Equal(int64(1)))
+		})
+	})
+
+	Context("when disabled", func() {
+		It("counts nothing", func() {
+			apply()
+
+			Expect(partial.Stats()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,21 @@
+// Package external stands in for a package whose types we can't annotate, such as a
+// vendored dependency, to test generation from partial.types.yaml.
+package external
+
+type Vendor struct {
+	ID     string                         `json:"id"`
+	Name   string                         `json:"name"`
+	Tier   VendorTier                     `json:"tier"`
+	Labels map[VendorLabelKey]VendorLabel `json:"labels"`
+	APIKey [VendorAPIKeyLength]byte       `json:"api_key"`
+	secret string
+}
+
+const VendorAPIKeyLength = 16
+
+type VendorTier string
+
+const (
+	VendorTierFree VendorTier = "free"
+	VendorTierPaid VendorTier = "paid"
+)
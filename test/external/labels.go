@@ -0,0 +1,7 @@
+package external
+
+// VendorLabelKey and VendorLabel are declared apart from Vendor, so generated code for
+// it has to qualify types from sibling files.
+type VendorLabelKey string
+
+type VendorLabel string
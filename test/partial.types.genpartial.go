@@ -0,0 +1,457 @@
+// Code generated by github.com/incident-io/partial/gen, DO NOT EDIT.
+// partial generator version: 8
+
+package test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test/external"
+	"github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+)
+
+func init() {
+	partial.RequireGeneratorVersion("partial.types.genpartial.go", 8)
+}
+
+// VendorBuilder initialises a external.Vendor struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Setters: ID, Name, Tier, Labels, APIKey.
+//
+// For example:
+//
+//	model := VendorBuilder(
+//		VendorBuilder.ID(id),
+//	)
+var VendorBuilder = VendorBuilderFunc(func(opts ...partial.Option[external.Vendor]) partial.Partial[external.Vendor] {
+	apply := func(base external.Vendor) partial.Partial[external.Vendor] {
+		model := partial.Partial[external.Vendor]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(external.Vendor{})
+	model.SetApply(func(base external.Vendor) *external.Vendor {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type VendorBuilderFunc func(opts ...partial.Option[external.Vendor]) partial.Partial[external.Vendor]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "external.Vendor", "builder",
+		"ID",
+		"Name",
+		"Tier",
+		"Labels",
+		"APIKey",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b VendorBuilderFunc) From(existing external.Vendor) partial.Option[external.Vendor] {
+	return func(subject *external.Vendor) []string {
+		subject.ID = existing.ID
+		subject.Name = existing.Name
+		subject.Tier = existing.Tier
+		subject.Labels = existing.Labels
+		subject.APIKey = existing.APIKey
+
+		return []string{
+			"ID",
+			"Name",
+			"Tier",
+			"Labels",
+			"APIKey",
+		}
+	}
+}
+
+func (b VendorBuilderFunc) ID(value string) partial.Option[external.Vendor] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "builder", "ID")
+
+	return func(subject *external.Vendor) []string {
+		subject.ID = value
+
+		return []string{
+			"ID",
+		}
+	}
+}
+
+// IDIfSet sets ID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b VendorBuilderFunc) IDIfSet(value *string) partial.Option[external.Vendor] {
+	if value == nil {
+		return func(*external.Vendor) []string {
+			return nil
+		}
+	}
+
+	return b.ID(*value)
+}
+
+func (b VendorBuilderFunc) Name(value string) partial.Option[external.Vendor] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "builder", "Name")
+
+	return func(subject *external.Vendor) []string {
+		subject.Name = value
+
+		return []string{
+			"Name",
+		}
+	}
+}
+
+// NameIfSet sets Name to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b VendorBuilderFunc) NameIfSet(value *string) partial.Option[external.Vendor] {
+	if value == nil {
+		return func(*external.Vendor) []string {
+			return nil
+		}
+	}
+
+	return b.Name(*value)
+}
+
+func (b VendorBuilderFunc) Tier(value external.VendorTier) partial.Option[external.Vendor] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "builder", "Tier")
+
+	return func(subject *external.Vendor) []string {
+		subject.Tier = value
+
+		return []string{
+			"Tier",
+		}
+	}
+}
+
+// TierIfSet sets Tier to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b VendorBuilderFunc) TierIfSet(value *external.VendorTier) partial.Option[external.Vendor] {
+	if value == nil {
+		return func(*external.Vendor) []string {
+			return nil
+		}
+	}
+
+	return b.Tier(*value)
+}
+
+func (b VendorBuilderFunc) Labels(value map[external.VendorLabelKey]external.VendorLabel) partial.Option[external.Vendor] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "builder", "Labels")
+
+	return func(subject *external.Vendor) []string {
+		subject.Labels = value
+
+		return []string{
+			"Labels",
+		}
+	}
+}
+
+// LabelsIfSet sets Labels to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b VendorBuilderFunc) LabelsIfSet(value *map[external.VendorLabelKey]external.VendorLabel) partial.Option[external.Vendor] {
+	if value == nil {
+		return func(*external.Vendor) []string {
+			return nil
+		}
+	}
+
+	return b.Labels(*value)
+}
+
+func (b VendorBuilderFunc) APIKey(value [16]byte) partial.Option[external.Vendor] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "builder", "APIKey")
+
+	return func(subject *external.Vendor) []string {
+		subject.APIKey = value
+
+		return []string{
+			"APIKey",
+		}
+	}
+}
+
+// APIKeyIfSet sets APIKey to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b VendorBuilderFunc) APIKeyIfSet(value *[16]byte) partial.Option[external.Vendor] {
+	if value == nil {
+		return func(*external.Vendor) []string {
+			return nil
+		}
+	}
+
+	return b.APIKey(*value)
+}
+
+// VendorMatcher creates a Gomega matcher for external.Vendor against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: ID, Name, Tier, Labels,
+// APIKey.
+//
+// For example:
+//
+//	Expect(vendor).To(VendorMatcher(
+//		VendorMatcher.ID(id),
+//		VendorMatcher.MatchName(Not(BeZero())),
+//	))
+var VendorMatcher = VendorMatcherFunc(func(opts ...func(*external.Vendor, *gstruct.Fields)) types.GomegaMatcher {
+	fields := gstruct.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+	matcher := gstruct.PointTo(
+		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+	)
+
+	// On failure, diff the actual value against a copy with every field we expected an
+	// exact value for set to that value, so only those fields can differ.
+	return partial.WithDiff(matcher, func(actual any) string {
+		subject, ok := actual.(*external.Vendor)
+		if !ok || subject == nil {
+			return ""
+		}
+
+		expected := *subject
+		for _, opt := range opts {
+			opt(&expected, &gstruct.Fields{})
+		}
+
+		return DiffVendor(expected, *subject)
+	})
+})
+
+type VendorMatcherFunc func(opts ...func(*external.Vendor, *gstruct.Fields)) types.GomegaMatcher
+
+type VendorMatcherMatchers struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b VendorMatcherFunc) Match() VendorMatcherMatchers {
+	return VendorMatcherMatchers{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher",
+		"ID",
+		"MatchID",
+		"Match().ID",
+		"Name",
+		"MatchName",
+		"Match().Name",
+		"Tier",
+		"MatchTier",
+		"Match().Tier",
+		"Labels",
+		"MatchLabels",
+		"Match().Labels",
+		"APIKey",
+		"MatchAPIKey",
+		"Match().APIKey",
+		"APIKeyHex",
+		"APIKeyBase64",
+	)
+}
+
+func (b VendorMatcherFunc) ID(value string) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "ID")
+	matcher := partial.WithProvenance(partial.Equal(value), "VendorMatcher.ID")
+
+	return func(expected *external.Vendor, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.ID = value
+		}
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) MatchID(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "MatchID")
+	matcher := partial.WithProvenance(value, "VendorMatcher.MatchID")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b VendorMatcherMatchers) ID(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "Match().ID")
+	matcher := partial.WithProvenance(value, "VendorMatcher.Match().ID")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) Name(value string) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "Name")
+	matcher := partial.WithProvenance(partial.Equal(value), "VendorMatcher.Name")
+
+	return func(expected *external.Vendor, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Name = value
+		}
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) MatchName(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "MatchName")
+	matcher := partial.WithProvenance(value, "VendorMatcher.MatchName")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b VendorMatcherMatchers) Name(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "Match().Name")
+	matcher := partial.WithProvenance(value, "VendorMatcher.Match().Name")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) Tier(value external.VendorTier) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "Tier")
+	matcher := partial.WithProvenance(partial.Equal(value), "VendorMatcher.Tier")
+
+	return func(expected *external.Vendor, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Tier = value
+		}
+		(*fields)["Tier"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) MatchTier(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "MatchTier")
+	matcher := partial.WithProvenance(value, "VendorMatcher.MatchTier")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["Tier"] = matcher
+	}
+}
+
+func (b VendorMatcherMatchers) Tier(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "Match().Tier")
+	matcher := partial.WithProvenance(value, "VendorMatcher.Match().Tier")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["Tier"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) Labels(value map[external.VendorLabelKey]external.VendorLabel) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "Labels")
+	matcher := partial.WithProvenance(partial.Equal(value), "VendorMatcher.Labels")
+
+	return func(expected *external.Vendor, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Labels = value
+		}
+		(*fields)["Labels"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) MatchLabels(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "MatchLabels")
+	matcher := partial.WithProvenance(value, "VendorMatcher.MatchLabels")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["Labels"] = matcher
+	}
+}
+
+func (b VendorMatcherMatchers) Labels(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "Match().Labels")
+	matcher := partial.WithProvenance(value, "VendorMatcher.Match().Labels")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["Labels"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) APIKey(value [16]byte) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "APIKey")
+	matcher := partial.WithProvenance(partial.EqualBytes(value[:]), "VendorMatcher.APIKey")
+
+	return func(expected *external.Vendor, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.APIKey = value
+		}
+		(*fields)["APIKey"] = matcher
+	}
+}
+
+// APIKeyHex matches APIKey against the bytes written as hex in value.
+func (b VendorMatcherFunc) APIKeyHex(value string) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "APIKeyHex")
+	matcher := partial.WithProvenance(partial.EqualHex(value), "VendorMatcher.APIKeyHex")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["APIKey"] = matcher
+	}
+}
+
+// APIKeyBase64 matches APIKey against the bytes written as base64 in value.
+func (b VendorMatcherFunc) APIKeyBase64(value string) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "APIKeyBase64")
+	matcher := partial.WithProvenance(partial.EqualBase64(value), "VendorMatcher.APIKeyBase64")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["APIKey"] = matcher
+	}
+}
+
+func (b VendorMatcherFunc) MatchAPIKey(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "MatchAPIKey")
+	matcher := partial.WithProvenance(value, "VendorMatcher.MatchAPIKey")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["APIKey"] = matcher
+	}
+}
+
+func (b VendorMatcherMatchers) APIKey(value types.GomegaMatcher) func(*external.Vendor, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "external.Vendor", "matcher", "Match().APIKey")
+	matcher := partial.WithProvenance(value, "VendorMatcher.Match().APIKey")
+
+	return func(_ *external.Vendor, fields *gstruct.Fields) {
+		(*fields)["APIKey"] = matcher
+	}
+}
+
+// DiffVendor describes how each database-backed field differs between a and b,
+// returning an empty string if they match. Useful when a external.Vendor matcher fails, as
+// the output is much smaller than printing each struct in full.
+func DiffVendor(a, b external.Vendor) string {
+	return partial.Diff("external.Vendor", []partial.FieldDiff{
+		{FieldName: "ID", A: a.ID, B: b.ID},
+		{FieldName: "Name", A: a.Name, B: b.Name},
+		{FieldName: "Tier", A: a.Tier, B: b.Tier},
+		{FieldName: "Labels", A: a.Labels, B: b.Labels},
+		{FieldName: "APIKey", A: a.APIKey, B: b.APIKey},
+	})
+}
@@ -1,253 +1,455 @@
 // Code generated by github.com/incident-io/partial/gen, DO NOT EDIT.
+// partial generator version: 8
 
 package test
 
 import (
+	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/incident-io/partial"
+	"github.com/onsi/ginkgo/extensions/table"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/gstruct"
 	"github.com/onsi/gomega/types"
-	"gopkg.in/guregu/null.v3"
+	"github.com/spf13/pflag"
+	null "gopkg.in/guregu/null.v3"
 )
 
-// IncidentBuilder initialises a Incident struct with fields from the given setters. Setters
+func init() {
+	partial.RequireGeneratorVersion("structs.genpartial.go", 8)
+}
+
+// ActionBuilder initialises a Action struct with fields from the given setters. Setters
 // are applied first to last, with subsequent sets taking precedence.
-var IncidentBuilder = IncidentBuilderFunc(func(opts ...func(*Incident) []string) partial.Partial[Incident] {
-	apply := func(base Incident) partial.Partial[Incident] {
-		model := partial.Partial[Incident]{
-			Subject:    base,
-			FieldNames: []string{},
-		}
-		for _, opt := range opts {
-			model.FieldNames = append(model.FieldNames, opt(&model.Subject)...)
+//
+// Setters: ID, Description, Assignee, SearchText, Timestamps().DueAt,
+// Timestamps().DueAtValue, Timestamps().DueAtNull, Timestamps().ClearDueAt,
+// Timestamps().CompletedAt, Timestamps().CompletedAtValue, Timestamps().CompletedAtNull,
+// Timestamps().ClearCompletedAt, Priority, PriorityValue, PriorityNull, ClearPriority,
+// Severity.
+//
+// For example:
+//
+//	model := ActionBuilder(
+//		ActionBuilder.ID(id),
+//	)
+var ActionBuilder = ActionBuilderFunc(func(opts ...partial.Option[Action]) partial.Partial[Action] {
+	apply := func(base Action) partial.Partial[Action] {
+		model := partial.Partial[Action]{
+			Subject: base,
 		}
 
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
 		return model
 	}
 
-	model := apply(Incident{})
-	model.SetApply(func(base Incident) *Incident {
+	model := apply(Action{})
+	model.SetApply(func(base Action) *Action {
 		patched := apply(base).Subject
 		return &patched
 	})
 
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
 	return model
 })
 
-type IncidentBuilderFunc func(opts ...func(*Incident) []string) partial.Partial[Incident]
+type ActionBuilderFunc func(opts ...partial.Option[Action]) partial.Partial[Action]
 
-func (b IncidentBuilderFunc) ID(value string) func(*Incident) []string {
-	return func(subject *Incident) []string {
-		subject.ID = value
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Action", "builder",
+		"ID",
+		"Description",
+		"Assignee",
+		"SearchText",
+		"Timestamps().DueAt",
+		"Timestamps().DueAtValue",
+		"Timestamps().DueAtNull",
+		"Timestamps().ClearDueAt",
+		"Timestamps().CompletedAt",
+		"Timestamps().CompletedAtValue",
+		"Timestamps().CompletedAtNull",
+		"Timestamps().ClearCompletedAt",
+		"Priority",
+		"PriorityValue",
+		"PriorityNull",
+		"ClearPriority",
+		"Severity",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b ActionBuilderFunc) From(existing Action) partial.Option[Action] {
+	return func(subject *Action) []string {
+		subject.ID = existing.ID
+		subject.Description = existing.Description
+		subject.Assignee = existing.Assignee
+		subject.SearchText = existing.SearchText
+		subject.DueAt = existing.DueAt
+		subject.CompletedAt = existing.CompletedAt
+		subject.Priority = existing.Priority
+		subject.Severity = existing.Severity
 
 		return []string{
 			"ID",
+			"Description",
+			"Assignee",
+			"SearchText",
+			"DueAt",
+			"CompletedAt",
+			"Priority",
+			"Severity",
 		}
 	}
 }
 
-func (b IncidentBuilderFunc) OrganisationID(value string) func(*Incident) []string {
-	return func(subject *Incident) []string {
-		subject.OrganisationID = value
+// Timestamps returns the setters for the Timestamps fields of Action.
+func (b ActionBuilderFunc) Timestamps() ActionBuilderTimestamps {
+	return ActionBuilderTimestamps{}
+}
+
+type ActionBuilderTimestamps struct{}
+
+func (b ActionBuilderFunc) ID(value string) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "ID")
+
+	return func(subject *Action) []string {
+		subject.ID = value
 
 		return []string{
-			"OrganisationID",
+			"ID",
 		}
 	}
 }
 
-func (b IncidentBuilderFunc) Organisation(value *Organisation) func(*Incident) []string {
-	return func(subject *Incident) []string {
-		subject.Organisation = value
-
-		return []string{
-			"Organisation",
+// IDIfSet sets ID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ActionBuilderFunc) IDIfSet(value *string) partial.Option[Action] {
+	if value == nil {
+		return func(*Action) []string {
+			return nil
 		}
 	}
+
+	return b.ID(*value)
 }
 
-func (b IncidentBuilderFunc) CreatedAt(value time.Time) func(*Incident) []string {
-	return func(subject *Incident) []string {
-		subject.CreatedAt = value
+func (b ActionBuilderFunc) Description(value string) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Description")
+
+	return func(subject *Action) []string {
+		subject.Description = value
 
 		return []string{
-			"CreatedAt",
+			"Description",
 		}
 	}
 }
 
-// IncidentMatcher creates a Gomega matcher for Incident against the given
-// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
-var IncidentMatcher = IncidentMatcherFunc(func(opts ...func(*Incident, *gstruct.Fields)) types.GomegaMatcher {
-	fields := gstruct.Fields{}
-	for _, opt := range opts {
-		opt(nil, &fields)
+// DescriptionIfSet sets Description to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ActionBuilderFunc) DescriptionIfSet(value *string) partial.Option[Action] {
+	if value == nil {
+		return func(*Action) []string {
+			return nil
+		}
 	}
 
-	return gstruct.PointTo(
-		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
-	)
-})
-
-// Matcher is added to the base type, permitting other generic functions to build matchers
-// from each of the matcher-setter functions.
-func (b Incident) Matcher(opts ...func(*Incident, *gstruct.Fields)) types.GomegaMatcher {
-	return IncidentMatcher(opts...)
+	return b.Description(*value)
 }
 
-type IncidentMatcherFunc func(opts ...func(*Incident, *gstruct.Fields)) types.GomegaMatcher
+func (b ActionBuilderFunc) Assignee(value string) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Assignee")
 
-type IncidentMatcherMatchers struct{}
+	return func(subject *Action) []string {
+		subject.Assignee = value
 
-// Match returns an interface with the same methods as the base matcher, but accepting
-// GomegaMatcher parameters instead of the exact equality matches.
-func (b IncidentMatcherFunc) Match() IncidentMatcherMatchers {
-	return IncidentMatcherMatchers{}
+		return []string{
+			"Assignee",
+		}
+	}
 }
 
-func (b IncidentMatcherFunc) ID(value string) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["ID"] = gomega.Equal(value)
+// AssigneeIfSet sets Assignee to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ActionBuilderFunc) AssigneeIfSet(value *string) partial.Option[Action] {
+	if value == nil {
+		return func(*Action) []string {
+			return nil
+		}
 	}
+
+	return b.Assignee(*value)
 }
 
-func (b IncidentMatcherFunc) MatchID(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["ID"] = value
+func (b ActionBuilderFunc) SearchText(value string) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "SearchText")
+
+	return func(subject *Action) []string {
+		subject.SearchText = value
+
+		return []string{
+			"SearchText",
+		}
 	}
 }
 
-func (b IncidentMatcherMatchers) ID(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["ID"] = value
+// SearchTextIfSet sets SearchText to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ActionBuilderFunc) SearchTextIfSet(value *string) partial.Option[Action] {
+	if value == nil {
+		return func(*Action) []string {
+			return nil
+		}
 	}
+
+	return b.SearchText(*value)
 }
 
-func (b IncidentMatcherFunc) OrganisationID(value string) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["OrganisationID"] = gomega.Equal(value)
+func (b ActionBuilderTimestamps) DueAt(value null.Time) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Timestamps().DueAt")
+
+	return func(subject *Action) []string {
+		subject.DueAt = value
+
+		return []string{
+			"DueAt",
+		}
 	}
 }
 
-func (b IncidentMatcherFunc) MatchOrganisationID(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["OrganisationID"] = value
+// DueAtValue sets DueAt to a valid null.Time holding value.
+func (b ActionBuilderTimestamps) DueAtValue(value time.Time) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Timestamps().DueAtValue")
+
+	return func(subject *Action) []string {
+		subject.DueAt = null.TimeFrom(value)
+
+		return []string{
+			"DueAt",
+		}
 	}
 }
 
-func (b IncidentMatcherMatchers) OrganisationID(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["OrganisationID"] = value
+// DueAtNull sets DueAt to null.
+func (b ActionBuilderTimestamps) DueAtNull() partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Timestamps().DueAtNull")
+
+	return func(subject *Action) []string {
+		subject.DueAt = null.Time{}
+
+		return []string{
+			"DueAt",
+		}
 	}
 }
 
-func (b IncidentMatcherFunc) Organisation(value *Organisation) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["Organisation"] = gomega.Equal(value)
+// ClearDueAt sets DueAt to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b ActionBuilderTimestamps) ClearDueAt() partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Timestamps().ClearDueAt")
+
+	return func(subject *Action) []string {
+		subject.DueAt = null.Time{}
+
+		return []string{
+			"DueAt",
+		}
 	}
 }
 
-func (b IncidentMatcherFunc) MatchOrganisation(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["Organisation"] = value
+// DueAtIfSet sets DueAt to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ActionBuilderTimestamps) DueAtIfSet(value *null.Time) partial.Option[Action] {
+	if value == nil {
+		return func(*Action) []string {
+			return nil
+		}
 	}
+
+	return b.DueAt(*value)
 }
 
-func (b IncidentMatcherMatchers) Organisation(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["Organisation"] = value
+func (b ActionBuilderTimestamps) CompletedAt(value null.Time) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Timestamps().CompletedAt")
+
+	return func(subject *Action) []string {
+		subject.CompletedAt = value
+
+		return []string{
+			"CompletedAt",
+		}
 	}
 }
 
-func (b IncidentMatcherFunc) CreatedAt(value time.Time) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["CreatedAt"] = gomega.Equal(value)
+// CompletedAtValue sets CompletedAt to a valid null.Time holding value.
+func (b ActionBuilderTimestamps) CompletedAtValue(value time.Time) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Timestamps().CompletedAtValue")
+
+	return func(subject *Action) []string {
+		subject.CompletedAt = null.TimeFrom(value)
+
+		return []string{
+			"CompletedAt",
+		}
 	}
 }
 
-func (b IncidentMatcherFunc) MatchCreatedAt(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["CreatedAt"] = value
+// CompletedAtNull sets CompletedAt to null.
+func (b ActionBuilderTimestamps) CompletedAtNull() partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Timestamps().CompletedAtNull")
+
+	return func(subject *Action) []string {
+		subject.CompletedAt = null.Time{}
+
+		return []string{
+			"CompletedAt",
+		}
 	}
 }
 
-func (b IncidentMatcherMatchers) CreatedAt(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
-	return func(_ *Incident, fields *gstruct.Fields) {
-		(*fields)["CreatedAt"] = value
+// ClearCompletedAt sets CompletedAt to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b ActionBuilderTimestamps) ClearCompletedAt() partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Timestamps().ClearCompletedAt")
+
+	return func(subject *Action) []string {
+		subject.CompletedAt = null.Time{}
+
+		return []string{
+			"CompletedAt",
+		}
 	}
 }
 
-// OrganisationBuilder initialises a Organisation struct with fields from the given setters. Setters
-// are applied first to last, with subsequent sets taking precedence.
-var OrganisationBuilder = OrganisationBuilderFunc(func(opts ...func(*Organisation) []string) partial.Partial[Organisation] {
-	apply := func(base Organisation) partial.Partial[Organisation] {
-		model := partial.Partial[Organisation]{
-			Subject:    base,
-			FieldNames: []string{},
+// CompletedAtIfSet sets CompletedAt to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ActionBuilderTimestamps) CompletedAtIfSet(value *null.Time) partial.Option[Action] {
+	if value == nil {
+		return func(*Action) []string {
+			return nil
 		}
-		for _, opt := range opts {
-			model.FieldNames = append(model.FieldNames, opt(&model.Subject)...)
-		}
-
-		return model
 	}
 
-	model := apply(Organisation{})
-	model.SetApply(func(base Organisation) *Organisation {
-		patched := apply(base).Subject
-		return &patched
-	})
+	return b.CompletedAt(*value)
+}
 
-	return model
-})
+func (b ActionBuilderFunc) Priority(value sql.NullInt64) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Priority")
 
-type OrganisationBuilderFunc func(opts ...func(*Organisation) []string) partial.Partial[Organisation]
+	return func(subject *Action) []string {
+		subject.Priority = value
 
-func (b OrganisationBuilderFunc) ID(value string) func(*Organisation) []string {
-	return func(subject *Organisation) []string {
-		subject.ID = value
+		return []string{
+			"Priority",
+		}
+	}
+}
+
+// PriorityValue sets Priority to a valid sql.NullInt64 holding value.
+func (b ActionBuilderFunc) PriorityValue(value int64) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "PriorityValue")
+
+	return func(subject *Action) []string {
+		subject.Priority = sql.NullInt64{Int64: value, Valid: true}
 
 		return []string{
-			"ID",
+			"Priority",
 		}
 	}
 }
 
-func (b OrganisationBuilderFunc) Name(value string) func(*Organisation) []string {
-	return func(subject *Organisation) []string {
-		subject.Name = value
+// PriorityNull sets Priority to null.
+func (b ActionBuilderFunc) PriorityNull() partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "PriorityNull")
+
+	return func(subject *Action) []string {
+		subject.Priority = sql.NullInt64{}
 
 		return []string{
-			"Name",
+			"Priority",
 		}
 	}
 }
 
-func (b OrganisationBuilderFunc) OptionalString(value null.String) func(*Organisation) []string {
-	return func(subject *Organisation) []string {
-		subject.OptionalString = value
+// ClearPriority sets Priority to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b ActionBuilderFunc) ClearPriority() partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "ClearPriority")
+
+	return func(subject *Action) []string {
+		subject.Priority = sql.NullInt64{}
 
 		return []string{
-			"OptionalString",
+			"Priority",
 		}
 	}
 }
 
-func (b OrganisationBuilderFunc) BoolFlag(value bool) func(*Organisation) []string {
-	return func(subject *Organisation) []string {
-		subject.BoolFlag = value
+// PriorityIfSet sets Priority to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ActionBuilderFunc) PriorityIfSet(value *sql.NullInt64) partial.Option[Action] {
+	if value == nil {
+		return func(*Action) []string {
+			return nil
+		}
+	}
+
+	return b.Priority(*value)
+}
+
+// Severity converts value with ParseSeverity. If that fails, the option is skipped and the
+// error is returned by Err on the built partial.
+func (b ActionBuilderFunc) Severity(value string) partial.Option[Action] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "builder", "Severity")
+
+	parsed, err := ParseSeverity(value)
+	if err != nil {
+		return partial.Fail[Action](fmt.Errorf("parsing Severity: %w", err))
+	}
+
+	return func(subject *Action) []string {
+		subject.Severity = parsed
 
 		return []string{
-			"BoolFlag",
+			"Severity",
 		}
 	}
 }
 
-// OrganisationMatcher creates a Gomega matcher for Organisation against the given
+// SeverityIfSet sets Severity to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ActionBuilderFunc) SeverityIfSet(value *string) partial.Option[Action] {
+	if value == nil {
+		return func(*Action) []string {
+			return nil
+		}
+	}
+
+	return b.Severity(*value)
+}
+
+// ActionMatcher creates a Gomega matcher for Action against the given
 // fields. Matchers are applied first to last, with subsequent matchers taking precedence.
-var OrganisationMatcher = OrganisationMatcherFunc(func(opts ...func(*Organisation, *gstruct.Fields)) types.GomegaMatcher {
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: ID, Description, Assignee,
+// SearchText, DueAt, CompletedAt, Priority, Severity.
+//
+// For example:
+//
+//	Expect(action).To(ActionMatcher(
+//		ActionMatcher.ID(id),
+//		ActionMatcher.MatchDescription(Not(BeZero())),
+//	))
+var ActionMatcher = ActionMatcherFunc(func(opts ...func(*Action, *gstruct.Fields)) types.GomegaMatcher {
 	fields := gstruct.Fields{}
 	for _, opt := range opts {
 		opt(nil, &fields)
@@ -260,88 +462,4223 @@ var OrganisationMatcher = OrganisationMatcherFunc(func(opts ...func(*Organisatio
 
 // Matcher is added to the base type, permitting other generic functions to build matchers
 // from each of the matcher-setter functions.
-func (b Organisation) Matcher(opts ...func(*Organisation, *gstruct.Fields)) types.GomegaMatcher {
-	return OrganisationMatcher(opts...)
+func (b Action) Matcher(opts ...func(*Action, *gstruct.Fields)) types.GomegaMatcher {
+	return ActionMatcher(opts...)
 }
 
-type OrganisationMatcherFunc func(opts ...func(*Organisation, *gstruct.Fields)) types.GomegaMatcher
+type ActionMatcherFunc func(opts ...func(*Action, *gstruct.Fields)) types.GomegaMatcher
 
-type OrganisationMatcherMatchers struct{}
+type ActionMatcherMatchers struct{}
 
 // Match returns an interface with the same methods as the base matcher, but accepting
 // GomegaMatcher parameters instead of the exact equality matches.
-func (b OrganisationMatcherFunc) Match() OrganisationMatcherMatchers {
-	return OrganisationMatcherMatchers{}
+func (b ActionMatcherFunc) Match() ActionMatcherMatchers {
+	return ActionMatcherMatchers{}
 }
 
-func (b OrganisationMatcherFunc) ID(value string) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["ID"] = gomega.Equal(value)
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Action", "matcher",
+		"ID",
+		"MatchID",
+		"Match().ID",
+		"Description",
+		"MatchDescription",
+		"Match().Description",
+		"Assignee",
+		"MatchAssignee",
+		"Match().Assignee",
+		"SearchText",
+		"MatchSearchText",
+		"Match().SearchText",
+		"DueAt",
+		"MatchDueAt",
+		"Match().DueAt",
+		"CompletedAt",
+		"MatchCompletedAt",
+		"Match().CompletedAt",
+		"Priority",
+		"MatchPriority",
+		"Match().Priority",
+		"Severity",
+		"MatchSeverity",
+		"Match().Severity",
+	)
+}
+
+func (b ActionMatcherFunc) ID(value string) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "ID")
+	matcher := partial.WithProvenance(partial.Equal(value), "ActionMatcher.ID")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
 	}
 }
 
-func (b OrganisationMatcherFunc) MatchID(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["ID"] = value
+func (b ActionMatcherFunc) MatchID(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "MatchID")
+	matcher := partial.WithProvenance(value, "ActionMatcher.MatchID")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
 	}
 }
 
-func (b OrganisationMatcherMatchers) ID(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["ID"] = value
+func (b ActionMatcherMatchers) ID(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Match().ID")
+	matcher := partial.WithProvenance(value, "ActionMatcher.Match().ID")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
 	}
 }
 
-func (b OrganisationMatcherFunc) Name(value string) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["Name"] = gomega.Equal(value)
+func (b ActionMatcherFunc) Description(value string) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Description")
+	matcher := partial.WithProvenance(partial.Equal(value), "ActionMatcher.Description")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Description"] = matcher
 	}
 }
 
-func (b OrganisationMatcherFunc) MatchName(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["Name"] = value
+func (b ActionMatcherFunc) MatchDescription(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "MatchDescription")
+	matcher := partial.WithProvenance(value, "ActionMatcher.MatchDescription")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Description"] = matcher
 	}
 }
 
-func (b OrganisationMatcherMatchers) Name(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["Name"] = value
+func (b ActionMatcherMatchers) Description(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Match().Description")
+	matcher := partial.WithProvenance(value, "ActionMatcher.Match().Description")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Description"] = matcher
 	}
 }
 
-func (b OrganisationMatcherFunc) OptionalString(value null.String) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["OptionalString"] = gomega.Equal(value)
+func (b ActionMatcherFunc) Assignee(value string) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Assignee")
+	matcher := partial.WithProvenance(MatchEmail(value), "ActionMatcher.Assignee")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Assignee"] = matcher
 	}
 }
 
-func (b OrganisationMatcherFunc) MatchOptionalString(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["OptionalString"] = value
+func (b ActionMatcherFunc) MatchAssignee(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "MatchAssignee")
+	matcher := partial.WithProvenance(value, "ActionMatcher.MatchAssignee")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Assignee"] = matcher
 	}
 }
 
-func (b OrganisationMatcherMatchers) OptionalString(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["OptionalString"] = value
+func (b ActionMatcherMatchers) Assignee(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Match().Assignee")
+	matcher := partial.WithProvenance(value, "ActionMatcher.Match().Assignee")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Assignee"] = matcher
 	}
 }
 
-func (b OrganisationMatcherFunc) BoolFlag(value bool) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["BoolFlag"] = gomega.Equal(value)
+func (b ActionMatcherFunc) SearchText(value string) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "SearchText")
+	matcher := partial.WithProvenance(partial.Equal(value), "ActionMatcher.SearchText")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["SearchText"] = matcher
 	}
 }
 
-func (b OrganisationMatcherFunc) MatchBoolFlag(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["BoolFlag"] = value
+func (b ActionMatcherFunc) MatchSearchText(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "MatchSearchText")
+	matcher := partial.WithProvenance(value, "ActionMatcher.MatchSearchText")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["SearchText"] = matcher
 	}
 }
 
-func (b OrganisationMatcherMatchers) BoolFlag(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
-	return func(_ *Organisation, fields *gstruct.Fields) {
-		(*fields)["BoolFlag"] = value
+func (b ActionMatcherMatchers) SearchText(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Match().SearchText")
+	matcher := partial.WithProvenance(value, "ActionMatcher.Match().SearchText")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["SearchText"] = matcher
+	}
+}
+
+func (b ActionMatcherFunc) DueAt(value null.Time) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "DueAt")
+	matcher := partial.WithProvenance(partial.Equal(value), "ActionMatcher.DueAt")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["DueAt"] = matcher
+	}
+}
+
+func (b ActionMatcherFunc) MatchDueAt(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "MatchDueAt")
+	matcher := partial.WithProvenance(value, "ActionMatcher.MatchDueAt")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["DueAt"] = matcher
+	}
+}
+
+func (b ActionMatcherMatchers) DueAt(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Match().DueAt")
+	matcher := partial.WithProvenance(value, "ActionMatcher.Match().DueAt")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["DueAt"] = matcher
+	}
+}
+
+func (b ActionMatcherFunc) CompletedAt(value null.Time) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "CompletedAt")
+	matcher := partial.WithProvenance(partial.Equal(value), "ActionMatcher.CompletedAt")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["CompletedAt"] = matcher
+	}
+}
+
+func (b ActionMatcherFunc) MatchCompletedAt(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "MatchCompletedAt")
+	matcher := partial.WithProvenance(value, "ActionMatcher.MatchCompletedAt")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["CompletedAt"] = matcher
+	}
+}
+
+func (b ActionMatcherMatchers) CompletedAt(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Match().CompletedAt")
+	matcher := partial.WithProvenance(value, "ActionMatcher.Match().CompletedAt")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["CompletedAt"] = matcher
+	}
+}
+
+func (b ActionMatcherFunc) Priority(value sql.NullInt64) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Priority")
+	matcher := partial.WithProvenance(partial.Equal(value), "ActionMatcher.Priority")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Priority"] = matcher
+	}
+}
+
+func (b ActionMatcherFunc) MatchPriority(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "MatchPriority")
+	matcher := partial.WithProvenance(value, "ActionMatcher.MatchPriority")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Priority"] = matcher
+	}
+}
+
+func (b ActionMatcherMatchers) Priority(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Match().Priority")
+	matcher := partial.WithProvenance(value, "ActionMatcher.Match().Priority")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Priority"] = matcher
 	}
 }
+
+func (b ActionMatcherFunc) Severity(value Severity) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Severity")
+	matcher := partial.WithProvenance(partial.Equal(value), "ActionMatcher.Severity")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Severity"] = matcher
+	}
+}
+
+func (b ActionMatcherFunc) MatchSeverity(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "MatchSeverity")
+	matcher := partial.WithProvenance(value, "ActionMatcher.MatchSeverity")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Severity"] = matcher
+	}
+}
+
+func (b ActionMatcherMatchers) Severity(value types.GomegaMatcher) func(*Action, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Action", "matcher", "Match().Severity")
+	matcher := partial.WithProvenance(value, "ActionMatcher.Match().Severity")
+
+	return func(_ *Action, fields *gstruct.Fields) {
+		(*fields)["Severity"] = matcher
+	}
+}
+
+// ActionOption is a setter for Action, as taken by ActionBuilder.
+type ActionOption = partial.Option[Action]
+
+// SetActionID is ActionBuilder.ID, for code that stores or passes setters around.
+func SetActionID(value string) ActionOption {
+	return ActionBuilder.ID(value)
+}
+
+// SetActionDescription is ActionBuilder.Description, for code that stores or passes setters around.
+func SetActionDescription(value string) ActionOption {
+	return ActionBuilder.Description(value)
+}
+
+// SetActionAssignee is ActionBuilder.Assignee, for code that stores or passes setters around.
+func SetActionAssignee(value string) ActionOption {
+	return ActionBuilder.Assignee(value)
+}
+
+// SetActionSearchText is ActionBuilder.SearchText, for code that stores or passes setters around.
+func SetActionSearchText(value string) ActionOption {
+	return ActionBuilder.SearchText(value)
+}
+
+// SetActionDueAt is ActionBuilder.Timestamps().DueAt, for code that stores or passes setters around.
+func SetActionDueAt(value null.Time) ActionOption {
+	return ActionBuilder.Timestamps().DueAt(value)
+}
+
+// SetActionDueAtValue is ActionBuilder.Timestamps().DueAtValue, for code that stores or passes setters around.
+func SetActionDueAtValue(value time.Time) ActionOption {
+	return ActionBuilder.Timestamps().DueAtValue(value)
+}
+
+// SetActionDueAtNull is ActionBuilder.Timestamps().DueAtNull, for code that stores or passes setters around.
+func SetActionDueAtNull() ActionOption {
+	return ActionBuilder.Timestamps().DueAtNull()
+}
+
+// SetActionCompletedAt is ActionBuilder.Timestamps().CompletedAt, for code that stores or passes setters around.
+func SetActionCompletedAt(value null.Time) ActionOption {
+	return ActionBuilder.Timestamps().CompletedAt(value)
+}
+
+// SetActionCompletedAtValue is ActionBuilder.Timestamps().CompletedAtValue, for code that stores or passes setters around.
+func SetActionCompletedAtValue(value time.Time) ActionOption {
+	return ActionBuilder.Timestamps().CompletedAtValue(value)
+}
+
+// SetActionCompletedAtNull is ActionBuilder.Timestamps().CompletedAtNull, for code that stores or passes setters around.
+func SetActionCompletedAtNull() ActionOption {
+	return ActionBuilder.Timestamps().CompletedAtNull()
+}
+
+// SetActionPriority is ActionBuilder.Priority, for code that stores or passes setters around.
+func SetActionPriority(value sql.NullInt64) ActionOption {
+	return ActionBuilder.Priority(value)
+}
+
+// SetActionPriorityValue is ActionBuilder.PriorityValue, for code that stores or passes setters around.
+func SetActionPriorityValue(value int64) ActionOption {
+	return ActionBuilder.PriorityValue(value)
+}
+
+// SetActionPriorityNull is ActionBuilder.PriorityNull, for code that stores or passes setters around.
+func SetActionPriorityNull() ActionOption {
+	return ActionBuilder.PriorityNull()
+}
+
+// SetActionSeverity is ActionBuilder.Severity, for code that stores or passes setters around.
+func SetActionSeverity(value string) ActionOption {
+	return ActionBuilder.Severity(value)
+}
+
+// AlertBuilder initialises a Alert struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Setters: Priority, Urgency, Labels, Digest, Sender.
+//
+// For example:
+//
+//	model := AlertBuilder(
+//		AlertBuilder.Priority(priority),
+//	)
+var AlertBuilder = AlertBuilderFunc(func(opts ...partial.Option[Alert]) partial.Partial[Alert] {
+	apply := func(base Alert) partial.Partial[Alert] {
+		model := partial.Partial[Alert]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(Alert{})
+	model.SetApply(func(base Alert) *Alert {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type AlertBuilderFunc func(opts ...partial.Option[Alert]) partial.Partial[Alert]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Alert", "builder",
+		"Priority",
+		"Urgency",
+		"Labels",
+		"Digest",
+		"Sender",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b AlertBuilderFunc) From(existing Alert) partial.Option[Alert] {
+	return func(subject *Alert) []string {
+		subject.Priority = existing.Priority
+		subject.Urgency = existing.Urgency
+		subject.Labels = alertCopyLabels(existing.Labels)
+		subject.Digest = existing.Digest
+		subject.From = existing.From
+
+		return []string{
+			"Priority",
+			"Urgency",
+			"Labels",
+			"Digest",
+			"From",
+		}
+	}
+}
+
+func (b AlertBuilderFunc) Priority(value Priority) partial.Option[Alert] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "builder", "Priority")
+
+	return func(subject *Alert) []string {
+		subject.Priority = value
+
+		return []string{
+			"Priority",
+		}
+	}
+}
+
+// PriorityIfSet sets Priority to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b AlertBuilderFunc) PriorityIfSet(value *Priority) partial.Option[Alert] {
+	if value == nil {
+		return func(*Alert) []string {
+			return nil
+		}
+	}
+
+	return b.Priority(*value)
+}
+
+func (b AlertBuilderFunc) Urgency(value Urgency) partial.Option[Alert] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "builder", "Urgency")
+
+	return func(subject *Alert) []string {
+		subject.Urgency = value
+
+		return []string{
+			"Urgency",
+		}
+	}
+}
+
+// UrgencyIfSet sets Urgency to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b AlertBuilderFunc) UrgencyIfSet(value *Urgency) partial.Option[Alert] {
+	if value == nil {
+		return func(*Alert) []string {
+			return nil
+		}
+	}
+
+	return b.Urgency(*value)
+}
+
+// Labels deep copies value, so changing it afterwards won't change the partial.
+func (b AlertBuilderFunc) Labels(value Labels) partial.Option[Alert] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "builder", "Labels")
+	value = alertCopyLabels(value)
+
+	return func(subject *Alert) []string {
+		subject.Labels = alertCopyLabels(value)
+
+		return []string{
+			"Labels",
+		}
+	}
+}
+
+// LabelsIfSet sets Labels to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b AlertBuilderFunc) LabelsIfSet(value *Labels) partial.Option[Alert] {
+	if value == nil {
+		return func(*Alert) []string {
+			return nil
+		}
+	}
+
+	return b.Labels(*value)
+}
+
+// alertCopyLabels deep copies a Labels for the Labels setter.
+func alertCopyLabels(value Labels) Labels {
+	var copied []string
+	if value != nil {
+		copied = make([]string, len(value))
+		copy(copied, value)
+	}
+
+	return copied
+}
+
+func (b AlertBuilderFunc) Digest(value Digest) partial.Option[Alert] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "builder", "Digest")
+
+	return func(subject *Alert) []string {
+		subject.Digest = value
+
+		return []string{
+			"Digest",
+		}
+	}
+}
+
+// DigestIfSet sets Digest to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b AlertBuilderFunc) DigestIfSet(value *Digest) partial.Option[Alert] {
+	if value == nil {
+		return func(*Alert) []string {
+			return nil
+		}
+	}
+
+	return b.Digest(*value)
+}
+
+func (b AlertBuilderFunc) Sender(value string) partial.Option[Alert] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "builder", "Sender")
+
+	return func(subject *Alert) []string {
+		subject.From = value
+
+		return []string{
+			"From",
+		}
+	}
+}
+
+// SenderIfSet sets From to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b AlertBuilderFunc) SenderIfSet(value *string) partial.Option[Alert] {
+	if value == nil {
+		return func(*Alert) []string {
+			return nil
+		}
+	}
+
+	return b.Sender(*value)
+}
+
+// AlertMatcher creates a Gomega matcher for Alert against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: Priority, Urgency, Labels,
+// Digest, Sender.
+//
+// For example:
+//
+//	Expect(alert).To(AlertMatcher(
+//		AlertMatcher.Priority(priority),
+//		AlertMatcher.MatchUrgency(Not(BeZero())),
+//	))
+var AlertMatcher = AlertMatcherFunc(func(opts ...func(*Alert, *gstruct.Fields)) types.GomegaMatcher {
+	fields := gstruct.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+	return gstruct.PointTo(
+		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+	)
+})
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b Alert) Matcher(opts ...func(*Alert, *gstruct.Fields)) types.GomegaMatcher {
+	return AlertMatcher(opts...)
+}
+
+type AlertMatcherFunc func(opts ...func(*Alert, *gstruct.Fields)) types.GomegaMatcher
+
+type AlertMatcherMatchers struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b AlertMatcherFunc) Match() AlertMatcherMatchers {
+	return AlertMatcherMatchers{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Alert", "matcher",
+		"Priority",
+		"MatchPriority",
+		"Match().Priority",
+		"Urgency",
+		"MatchUrgency",
+		"Match().Urgency",
+		"Labels",
+		"MatchLabels",
+		"Match().Labels",
+		"Digest",
+		"MatchDigest",
+		"Match().Digest",
+		"DigestHex",
+		"DigestBase64",
+		"Sender",
+		"MatchSender",
+		"Match().Sender",
+	)
+}
+
+func (b AlertMatcherFunc) Priority(value Priority) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Priority")
+	matcher := partial.WithProvenance(partial.Equal(value), "AlertMatcher.Priority")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Priority"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) MatchPriority(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "MatchPriority")
+	matcher := partial.WithProvenance(value, "AlertMatcher.MatchPriority")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Priority"] = matcher
+	}
+}
+
+func (b AlertMatcherMatchers) Priority(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Match().Priority")
+	matcher := partial.WithProvenance(value, "AlertMatcher.Match().Priority")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Priority"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) Urgency(value Urgency) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Urgency")
+	matcher := partial.WithProvenance(partial.Equal(value), "AlertMatcher.Urgency")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Urgency"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) MatchUrgency(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "MatchUrgency")
+	matcher := partial.WithProvenance(value, "AlertMatcher.MatchUrgency")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Urgency"] = matcher
+	}
+}
+
+func (b AlertMatcherMatchers) Urgency(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Match().Urgency")
+	matcher := partial.WithProvenance(value, "AlertMatcher.Match().Urgency")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Urgency"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) Labels(value Labels) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Labels")
+	matcher := partial.WithProvenance(partial.Equal(value), "AlertMatcher.Labels")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Labels"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) MatchLabels(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "MatchLabels")
+	matcher := partial.WithProvenance(value, "AlertMatcher.MatchLabels")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Labels"] = matcher
+	}
+}
+
+func (b AlertMatcherMatchers) Labels(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Match().Labels")
+	matcher := partial.WithProvenance(value, "AlertMatcher.Match().Labels")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Labels"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) Digest(value Digest) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Digest")
+	matcher := partial.WithProvenance(partial.EqualBytes(value[:]), "AlertMatcher.Digest")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Digest"] = matcher
+	}
+}
+
+// DigestHex matches Digest against the bytes written as hex in value.
+func (b AlertMatcherFunc) DigestHex(value string) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "DigestHex")
+	matcher := partial.WithProvenance(partial.EqualHex(value), "AlertMatcher.DigestHex")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Digest"] = matcher
+	}
+}
+
+// DigestBase64 matches Digest against the bytes written as base64 in value.
+func (b AlertMatcherFunc) DigestBase64(value string) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "DigestBase64")
+	matcher := partial.WithProvenance(partial.EqualBase64(value), "AlertMatcher.DigestBase64")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Digest"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) MatchDigest(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "MatchDigest")
+	matcher := partial.WithProvenance(value, "AlertMatcher.MatchDigest")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Digest"] = matcher
+	}
+}
+
+func (b AlertMatcherMatchers) Digest(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Match().Digest")
+	matcher := partial.WithProvenance(value, "AlertMatcher.Match().Digest")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["Digest"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) Sender(value string) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Sender")
+	matcher := partial.WithProvenance(partial.Equal(value), "AlertMatcher.Sender")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["From"] = matcher
+	}
+}
+
+func (b AlertMatcherFunc) MatchSender(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "MatchSender")
+	matcher := partial.WithProvenance(value, "AlertMatcher.MatchSender")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["From"] = matcher
+	}
+}
+
+func (b AlertMatcherMatchers) Sender(value types.GomegaMatcher) func(*Alert, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Alert", "matcher", "Match().Sender")
+	matcher := partial.WithProvenance(value, "AlertMatcher.Match().Sender")
+
+	return func(_ *Alert, fields *gstruct.Fields) {
+		(*fields)["From"] = matcher
+	}
+}
+
+// CLIConfigFlagSet binds a command line flag to each field of CLIConfig, for CLIs
+// that should only override the fields whose flags were passed.
+type CLIConfigFlagSet struct {
+	flags  *pflag.FlagSet
+	values CLIConfig
+}
+
+// CLIConfigFlags registers a flag for each field of CLIConfig on flags, such as
+// those of a cobra command. Once they're parsed, Partial tracks the flags that were set:
+//
+//	flags := CLIConfigFlags(cmd.Flags())
+//	...
+//	overrides := flags.Partial()
+func CLIConfigFlags(flags *pflag.FlagSet) *CLIConfigFlagSet {
+	f := &CLIConfigFlagSet{flags: flags}
+	flags.StringVar(&f.values.Endpoint, "endpoint", "https://api.incident.io", "The API to send requests to.")
+	flags.IntVar(&f.values.MaxRetries, "max-retries", 3, "How many times to retry failed requests.")
+	flags.DurationVar(&f.values.Timeout, "timeout", 30000000000, "Timeout")
+	flags.BoolVar(&f.values.Verbose, "verbose", false, "Verbose")
+	flags.StringSliceVar(&f.values.Tags, "tags", nil, "Tags")
+	flags.StringVar((*string)(&f.values.Priority), "priority", "p2", "Priority")
+
+	return f
+}
+
+// Partial returns a partial tracking the fields whose flags were set, to the values they
+// were set to.
+func (f *CLIConfigFlagSet) Partial() partial.Partial[CLIConfig] {
+	values := f.values
+
+	return partial.NewWithCapacity[CLIConfig](6).Add(func(subject *CLIConfig) []string {
+		fieldNames := []string{}
+		if f.flags.Changed("endpoint") {
+			subject.Endpoint = values.Endpoint
+			fieldNames = append(fieldNames, "Endpoint")
+		}
+		if f.flags.Changed("max-retries") {
+			subject.MaxRetries = values.MaxRetries
+			fieldNames = append(fieldNames, "MaxRetries")
+		}
+		if f.flags.Changed("timeout") {
+			subject.Timeout = values.Timeout
+			fieldNames = append(fieldNames, "Timeout")
+		}
+		if f.flags.Changed("verbose") {
+			subject.Verbose = values.Verbose
+			fieldNames = append(fieldNames, "Verbose")
+		}
+		if f.flags.Changed("tags") {
+			subject.Tags = values.Tags
+			fieldNames = append(fieldNames, "Tags")
+		}
+		if f.flags.Changed("priority") {
+			subject.Priority = values.Priority
+			fieldNames = append(fieldNames, "Priority")
+		}
+
+		return fieldNames
+	})
+}
+
+// CustomFieldBuilder initialises a CustomField struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Default values are set and tracked before any setters are applied. Use WithoutDefaults
+// to build without them.
+//
+// Setters: ID, Name, Description, Kind, Required, SchemaVersion.
+//
+// For example:
+//
+//	model := CustomFieldBuilder(
+//		CustomFieldBuilder.ID(id),
+//	)
+var CustomFieldBuilder = CustomFieldBuilderFunc(func(opts ...partial.Option[CustomField]) partial.Partial[CustomField] {
+	defaults := []partial.Option[CustomField]{
+		func(subject *CustomField) []string {
+			subject.Kind = "text"
+			return []string{"Kind"}
+		},
+		func(subject *CustomField) []string {
+			subject.Required = true
+			return []string{"Required"}
+		},
+		func(subject *CustomField) []string {
+			subject.SchemaVersion = "2"
+			return []string{"SchemaVersion"}
+		},
+	}
+
+	return customFieldBuilderWithoutDefaults(append(defaults, opts...)...)
+})
+
+// WithoutDefaults returns a builder that doesn't set any default values.
+func (b CustomFieldBuilderFunc) WithoutDefaults() CustomFieldBuilderFunc {
+	return customFieldBuilderWithoutDefaults
+}
+
+var customFieldBuilderWithoutDefaults = CustomFieldBuilderFunc(func(opts ...partial.Option[CustomField]) partial.Partial[CustomField] {
+	apply := func(base CustomField) partial.Partial[CustomField] {
+		model := partial.Partial[CustomField]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(CustomField{})
+	model.SetApply(func(base CustomField) *CustomField {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type CustomFieldBuilderFunc func(opts ...partial.Option[CustomField]) partial.Partial[CustomField]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "CustomField", "builder",
+		"ID",
+		"Name",
+		"Description",
+		"Kind",
+		"Required",
+		"SchemaVersion",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b CustomFieldBuilderFunc) From(existing CustomField) partial.Option[CustomField] {
+	return func(subject *CustomField) []string {
+		subject.ID = existing.ID
+		subject.Name = existing.Name
+		subject.Description = existing.Description
+		subject.Kind = existing.Kind
+		subject.Required = existing.Required
+		subject.SchemaVersion = existing.SchemaVersion
+
+		return []string{
+			"ID",
+			"Name",
+			"Description",
+			"Kind",
+			"Required",
+			"SchemaVersion",
+		}
+	}
+}
+
+func (b CustomFieldBuilderFunc) ID(value string) partial.Option[CustomField] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "builder", "ID")
+
+	return func(subject *CustomField) []string {
+		subject.ID = value
+
+		return []string{
+			"ID",
+		}
+	}
+}
+
+// IDIfSet sets ID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b CustomFieldBuilderFunc) IDIfSet(value *string) partial.Option[CustomField] {
+	if value == nil {
+		return func(*CustomField) []string {
+			return nil
+		}
+	}
+
+	return b.ID(*value)
+}
+
+func (b CustomFieldBuilderFunc) Name(value string) partial.Option[CustomField] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "builder", "Name")
+
+	return func(subject *CustomField) []string {
+		subject.Name = value
+
+		return []string{
+			"Name",
+		}
+	}
+}
+
+// NameIfSet sets Name to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b CustomFieldBuilderFunc) NameIfSet(value *string) partial.Option[CustomField] {
+	if value == nil {
+		return func(*CustomField) []string {
+			return nil
+		}
+	}
+
+	return b.Name(*value)
+}
+
+func (b CustomFieldBuilderFunc) Description(value string) partial.Option[CustomField] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "builder", "Description")
+
+	return func(subject *CustomField) []string {
+		subject.Description = value
+
+		return []string{
+			"Description",
+		}
+	}
+}
+
+// DescriptionIfSet sets Description to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b CustomFieldBuilderFunc) DescriptionIfSet(value *string) partial.Option[CustomField] {
+	if value == nil {
+		return func(*CustomField) []string {
+			return nil
+		}
+	}
+
+	return b.Description(*value)
+}
+
+func (b CustomFieldBuilderFunc) Kind(value string) partial.Option[CustomField] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "builder", "Kind")
+
+	return func(subject *CustomField) []string {
+		subject.Kind = value
+
+		return []string{
+			"Kind",
+		}
+	}
+}
+
+// KindIfSet sets Kind to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b CustomFieldBuilderFunc) KindIfSet(value *string) partial.Option[CustomField] {
+	if value == nil {
+		return func(*CustomField) []string {
+			return nil
+		}
+	}
+
+	return b.Kind(*value)
+}
+
+func (b CustomFieldBuilderFunc) Required(value bool) partial.Option[CustomField] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "builder", "Required")
+
+	return func(subject *CustomField) []string {
+		subject.Required = value
+
+		return []string{
+			"Required",
+		}
+	}
+}
+
+// RequiredIfSet sets Required to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b CustomFieldBuilderFunc) RequiredIfSet(value *bool) partial.Option[CustomField] {
+	if value == nil {
+		return func(*CustomField) []string {
+			return nil
+		}
+	}
+
+	return b.Required(*value)
+}
+
+func (b CustomFieldBuilderFunc) SchemaVersion(value string) partial.Option[CustomField] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "builder", "SchemaVersion")
+
+	return func(subject *CustomField) []string {
+		subject.SchemaVersion = value
+
+		return []string{
+			"SchemaVersion",
+		}
+	}
+}
+
+// SchemaVersionIfSet sets SchemaVersion to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b CustomFieldBuilderFunc) SchemaVersionIfSet(value *string) partial.Option[CustomField] {
+	if value == nil {
+		return func(*CustomField) []string {
+			return nil
+		}
+	}
+
+	return b.SchemaVersion(*value)
+}
+
+// CustomFieldMatcher creates a Gomega matcher for CustomField against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: ID, Name, Description,
+// Kind, Required, SchemaVersion.
+//
+// For example:
+//
+//	Expect(customField).To(CustomFieldMatcher(
+//		CustomFieldMatcher.ID(id),
+//		CustomFieldMatcher.MatchName(Not(BeZero())),
+//	))
+var CustomFieldMatcher = CustomFieldMatcherFunc(func(opts ...func(*CustomField, *gstruct.Fields)) types.GomegaMatcher {
+	fields := gstruct.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+	return gstruct.PointTo(
+		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+	)
+})
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b CustomField) Matcher(opts ...func(*CustomField, *gstruct.Fields)) types.GomegaMatcher {
+	return CustomFieldMatcher(opts...)
+}
+
+type CustomFieldMatcherFunc func(opts ...func(*CustomField, *gstruct.Fields)) types.GomegaMatcher
+
+type CustomFieldMatcherMatchers struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b CustomFieldMatcherFunc) Match() CustomFieldMatcherMatchers {
+	return CustomFieldMatcherMatchers{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "CustomField", "matcher",
+		"ID",
+		"MatchID",
+		"Match().ID",
+		"Name",
+		"MatchName",
+		"Match().Name",
+		"Description",
+		"MatchDescription",
+		"Match().Description",
+		"Kind",
+		"MatchKind",
+		"Match().Kind",
+		"Required",
+		"MatchRequired",
+		"Match().Required",
+		"SchemaVersion",
+		"MatchSchemaVersion",
+		"Match().SchemaVersion",
+	)
+}
+
+func (b CustomFieldMatcherFunc) ID(value string) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "ID")
+	matcher := partial.WithProvenance(partial.Equal(value), "CustomFieldMatcher.ID")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) MatchID(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "MatchID")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.MatchID")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherMatchers) ID(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Match().ID")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.Match().ID")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) Name(value string) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Name")
+	matcher := partial.WithProvenance(partial.Equal(value), "CustomFieldMatcher.Name")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) MatchName(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "MatchName")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.MatchName")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherMatchers) Name(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Match().Name")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.Match().Name")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) Description(value string) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Description")
+	matcher := partial.WithProvenance(partial.Equal(value), "CustomFieldMatcher.Description")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Description"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) MatchDescription(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "MatchDescription")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.MatchDescription")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Description"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherMatchers) Description(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Match().Description")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.Match().Description")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Description"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) Kind(value string) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Kind")
+	matcher := partial.WithProvenance(partial.Equal(value), "CustomFieldMatcher.Kind")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Kind"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) MatchKind(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "MatchKind")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.MatchKind")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Kind"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherMatchers) Kind(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Match().Kind")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.Match().Kind")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Kind"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) Required(value bool) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Required")
+	matcher := partial.WithProvenance(partial.Equal(value), "CustomFieldMatcher.Required")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Required"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) MatchRequired(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "MatchRequired")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.MatchRequired")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Required"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherMatchers) Required(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Match().Required")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.Match().Required")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["Required"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) SchemaVersion(value string) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "SchemaVersion")
+	matcher := partial.WithProvenance(partial.Equal(value), "CustomFieldMatcher.SchemaVersion")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["SchemaVersion"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherFunc) MatchSchemaVersion(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "MatchSchemaVersion")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.MatchSchemaVersion")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["SchemaVersion"] = matcher
+	}
+}
+
+func (b CustomFieldMatcherMatchers) SchemaVersion(value types.GomegaMatcher) func(*CustomField, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "CustomField", "matcher", "Match().SchemaVersion")
+	matcher := partial.WithProvenance(value, "CustomFieldMatcher.Match().SchemaVersion")
+
+	return func(_ *CustomField, fields *gstruct.Fields) {
+		(*fields)["SchemaVersion"] = matcher
+	}
+}
+
+// IncidentBuilder initialises a Incident struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Setters: OrganisationID, Organisation, ClearOrganisation, Parent, ClearParent, Actions.
+//
+// For example:
+//
+//	model := IncidentBuilder(
+//		IncidentBuilder.OrganisationID(organisationID),
+//	)
+var IncidentBuilder = IncidentBuilderFunc(func(opts ...partial.Option[Incident]) partial.Partial[Incident] {
+	apply := func(base Incident) partial.Partial[Incident] {
+		model := partial.Partial[Incident]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(Incident{})
+	model.SetApply(func(base Incident) *Incident {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type IncidentBuilderFunc func(opts ...partial.Option[Incident]) partial.Partial[Incident]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Incident", "builder",
+		"OrganisationID",
+		"Organisation",
+		"ClearOrganisation",
+		"Parent",
+		"ClearParent",
+		"Actions",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b IncidentBuilderFunc) From(existing Incident) partial.Option[Incident] {
+	return func(subject *Incident) []string {
+		subject.OrganisationID = existing.OrganisationID
+
+		return []string{
+			"OrganisationID",
+		}
+	}
+}
+
+func (b IncidentBuilderFunc) OrganisationID(value string) partial.Option[Incident] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "builder", "OrganisationID")
+
+	return func(subject *Incident) []string {
+		subject.OrganisationID = value
+
+		return []string{
+			"OrganisationID",
+		}
+	}
+}
+
+// OrganisationIDIfSet sets OrganisationID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b IncidentBuilderFunc) OrganisationIDIfSet(value *string) partial.Option[Incident] {
+	if value == nil {
+		return func(*Incident) []string {
+			return nil
+		}
+	}
+
+	return b.OrganisationID(*value)
+}
+
+func (b IncidentBuilderFunc) Organisation(value *Organisation) partial.Option[Incident] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "builder", "Organisation")
+
+	return func(subject *Incident) []string {
+		subject.Organisation = value
+
+		return []string{
+			"Organisation",
+		}
+	}
+}
+
+// ClearOrganisation sets Organisation to nil and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b IncidentBuilderFunc) ClearOrganisation() partial.Option[Incident] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "builder", "ClearOrganisation")
+
+	return func(subject *Incident) []string {
+		subject.Organisation = nil
+
+		return []string{
+			"Organisation",
+		}
+	}
+}
+
+func (b IncidentBuilderFunc) Parent(value *Incident) partial.Option[Incident] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "builder", "Parent")
+
+	return func(subject *Incident) []string {
+		subject.Parent = value
+
+		return []string{
+			"Parent",
+		}
+	}
+}
+
+// ClearParent sets Parent to nil and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b IncidentBuilderFunc) ClearParent() partial.Option[Incident] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "builder", "ClearParent")
+
+	return func(subject *Incident) []string {
+		subject.Parent = nil
+
+		return []string{
+			"Parent",
+		}
+	}
+}
+
+// Actions deep copies value, so changing it afterwards won't change the partial.
+func (b IncidentBuilderFunc) Actions(value []Action) partial.Option[Incident] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "builder", "Actions")
+	value = incidentCopyActions(value)
+
+	return func(subject *Incident) []string {
+		subject.Actions = incidentCopyActions(value)
+
+		return []string{
+			"Actions",
+		}
+	}
+}
+
+// ActionsIfSet sets Actions to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b IncidentBuilderFunc) ActionsIfSet(value *[]Action) partial.Option[Incident] {
+	if value == nil {
+		return func(*Incident) []string {
+			return nil
+		}
+	}
+
+	return b.Actions(*value)
+}
+
+// incidentCopyActions deep copies a []Action for the Actions setter.
+func incidentCopyActions(value []Action) []Action {
+	var copied []Action
+	if value != nil {
+		copied = make([]Action, len(value))
+		copy(copied, value)
+	}
+
+	return copied
+}
+
+// IncidentMatcher creates a Gomega matcher for Incident against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: ID, OrganisationID,
+// Organisation, Parent, CreatedAt, Actions, SearchVector.
+//
+// For example:
+//
+//	Expect(incident).To(IncidentMatcher(
+//		IncidentMatcher.ID(id),
+//		IncidentMatcher.MatchOrganisationID(Not(BeZero())),
+//	))
+var IncidentMatcher = IncidentMatcherFunc(func(opts ...func(*Incident, *gstruct.Fields)) types.GomegaMatcher {
+	fields := gstruct.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+	matcher := gstruct.PointTo(
+		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+	)
+
+	// On failure, diff the actual value against a copy with every field we expected an
+	// exact value for set to that value, so only those fields can differ.
+	return partial.WithDiff(matcher, func(actual any) string {
+		subject, ok := actual.(*Incident)
+		if !ok || subject == nil {
+			return ""
+		}
+
+		expected := *subject
+		for _, opt := range opts {
+			opt(&expected, &gstruct.Fields{})
+		}
+
+		return DiffIncident(expected, *subject)
+	})
+})
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b Incident) Matcher(opts ...func(*Incident, *gstruct.Fields)) types.GomegaMatcher {
+	return IncidentMatcher(opts...)
+}
+
+type IncidentMatcherFunc func(opts ...func(*Incident, *gstruct.Fields)) types.GomegaMatcher
+
+type IncidentMatcherMatchers struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b IncidentMatcherFunc) Match() IncidentMatcherMatchers {
+	return IncidentMatcherMatchers{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Incident", "matcher",
+		"ID",
+		"MatchID",
+		"Match().ID",
+		"OrganisationID",
+		"MatchOrganisationID",
+		"Match().OrganisationID",
+		"Organisation",
+		"MatchOrganisation",
+		"Match().Organisation",
+		"MatchOrganisationWith",
+		"Parent",
+		"MatchParent",
+		"Match().Parent",
+		"MatchParentWith",
+		"CreatedAt",
+		"MatchCreatedAt",
+		"Match().CreatedAt",
+		"Actions",
+		"MatchActions",
+		"Match().Actions",
+		"MatchActionsConsistOf",
+		"SearchVector",
+		"MatchSearchVector",
+		"Match().SearchVector",
+	)
+}
+
+func (b IncidentMatcherFunc) ID(value string) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "ID")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentMatcher.ID")
+
+	return func(expected *Incident, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.ID = value
+		}
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) MatchID(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchID")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.MatchID")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b IncidentMatcherMatchers) ID(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Match().ID")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.Match().ID")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) OrganisationID(value string) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "OrganisationID")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentMatcher.OrganisationID")
+
+	return func(expected *Incident, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.OrganisationID = value
+		}
+		(*fields)["OrganisationID"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) MatchOrganisationID(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchOrganisationID")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.MatchOrganisationID")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["OrganisationID"] = matcher
+	}
+}
+
+func (b IncidentMatcherMatchers) OrganisationID(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Match().OrganisationID")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.Match().OrganisationID")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["OrganisationID"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) Organisation(value *Organisation) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Organisation")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentMatcher.Organisation")
+
+	return func(expected *Incident, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Organisation = value
+		}
+		(*fields)["Organisation"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) MatchOrganisation(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchOrganisation")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.MatchOrganisation")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Organisation"] = matcher
+	}
+}
+
+func (b IncidentMatcherMatchers) Organisation(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Match().Organisation")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.Match().Organisation")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Organisation"] = matcher
+	}
+}
+
+// MatchOrganisationWith matches Organisation against the given Organisation matchers,
+// failing rather than panicking if it is nil.
+func (b IncidentMatcherFunc) MatchOrganisationWith(opts ...func(*Organisation, *gstruct.Fields)) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchOrganisationWith")
+	matcher := partial.WithProvenance(OrganisationMatcher(opts...), "IncidentMatcher.MatchOrganisationWith")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Organisation"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) Parent(value *Incident) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Parent")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentMatcher.Parent")
+
+	return func(expected *Incident, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Parent = value
+		}
+		(*fields)["Parent"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) MatchParent(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchParent")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.MatchParent")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Parent"] = matcher
+	}
+}
+
+func (b IncidentMatcherMatchers) Parent(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Match().Parent")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.Match().Parent")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Parent"] = matcher
+	}
+}
+
+// MatchParentWith matches Parent against the given Incident matchers,
+// failing rather than panicking if it is nil.
+func (b IncidentMatcherFunc) MatchParentWith(opts ...func(*Incident, *gstruct.Fields)) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchParentWith")
+	matcher := partial.WithProvenance(IncidentMatcher(opts...), "IncidentMatcher.MatchParentWith")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Parent"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) CreatedAt(value time.Time) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "CreatedAt")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentMatcher.CreatedAt")
+
+	return func(expected *Incident, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.CreatedAt = value
+		}
+		(*fields)["CreatedAt"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) MatchCreatedAt(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchCreatedAt")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.MatchCreatedAt")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["CreatedAt"] = matcher
+	}
+}
+
+func (b IncidentMatcherMatchers) CreatedAt(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Match().CreatedAt")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.Match().CreatedAt")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["CreatedAt"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) Actions(value []Action) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Actions")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentMatcher.Actions")
+
+	return func(expected *Incident, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Actions = value
+		}
+		(*fields)["Actions"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) MatchActions(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchActions")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.MatchActions")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Actions"] = matcher
+	}
+}
+
+func (b IncidentMatcherMatchers) Actions(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Match().Actions")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.Match().Actions")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Actions"] = matcher
+	}
+}
+
+// MatchActionsConsistOf matches when Actions has exactly one element matching each of
+// the given matchers, in any order. Build each with ActionMatcher, passing it
+// every option the element should match.
+func (b IncidentMatcherFunc) MatchActionsConsistOf(elements ...types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchActionsConsistOf")
+
+	// Element matchers expect pointers, so point at each element before matching.
+	matcher := partial.WithProvenance(gomega.WithTransform(func(items []Action) []*Action {
+		pointers := []*Action{}
+		for idx := range items {
+			pointers = append(pointers, &items[idx])
+		}
+
+		return pointers
+	}, gomega.ConsistOf(elements)), "IncidentMatcher.MatchActionsConsistOf")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["Actions"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) SearchVector(value string) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "SearchVector")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentMatcher.SearchVector")
+
+	return func(expected *Incident, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.SearchVector = value
+		}
+		(*fields)["SearchVector"] = matcher
+	}
+}
+
+func (b IncidentMatcherFunc) MatchSearchVector(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "MatchSearchVector")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.MatchSearchVector")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["SearchVector"] = matcher
+	}
+}
+
+func (b IncidentMatcherMatchers) SearchVector(value types.GomegaMatcher) func(*Incident, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Incident", "matcher", "Match().SearchVector")
+	matcher := partial.WithProvenance(value, "IncidentMatcher.Match().SearchVector")
+
+	return func(_ *Incident, fields *gstruct.Fields) {
+		(*fields)["SearchVector"] = matcher
+	}
+}
+
+// DiffIncident describes how each database-backed field differs between a and b,
+// returning an empty string if they match. Useful when a Incident matcher fails, as
+// the output is much smaller than printing each struct in full.
+func DiffIncident(a, b Incident) string {
+	return partial.Diff("Incident", []partial.FieldDiff{
+		{FieldName: "ID", A: a.ID, B: b.ID},
+		{FieldName: "OrganisationID", A: a.OrganisationID, B: b.OrganisationID},
+		{FieldName: "CreatedAt", A: a.CreatedAt, B: b.CreatedAt},
+		{FieldName: "SearchVector", A: a.SearchVector, B: b.SearchVector},
+	})
+}
+
+// IncidentChangedEvent is the payload emitted when a Incident changes, carrying the new
+// values of only the fields that changed.
+type IncidentChangedEvent struct {
+	EntityID      string         `json:"entity_id"`
+	ChangedFields []string       `json:"changed_fields"` // JSON names of the changed fields
+	Values        map[string]any `json:"values"`         // new values, keyed by JSON name
+	Actor         string         `json:"actor"`
+}
+
+// NewIncidentChangedEvent builds the change event for applying the partial to the Incident
+// with the given ID.
+func NewIncidentChangedEvent(entityID string, model partial.Partial[Incident], actor string) IncidentChangedEvent {
+	changedFields, values := partial.ChangedValues(model)
+
+	return IncidentChangedEvent{
+		EntityID:      entityID,
+		ChangedFields: changedFields,
+		Values:        values,
+		Actor:         actor,
+	}
+}
+
+// NewIncident builds a Incident from its required fields, followed by builder setters
+// for any others.
+func NewIncident(id string, organisationID string, opts ...partial.Option[Incident]) partial.Partial[Incident] {
+	required := []partial.Option[Incident]{
+		func(subject *Incident) []string {
+			subject.ID = id
+			return []string{"ID"}
+		},
+		func(subject *Incident) []string {
+			subject.OrganisationID = organisationID
+			return []string{"OrganisationID"}
+		},
+	}
+
+	return IncidentBuilder(append(required, opts...)...)
+}
+
+// IncidentEntry builds a DescribeTable entry from a Incident partial and a matcher for the
+// expected result, which the table body receives as its parameters:
+//
+//	DescribeTable("updating", func(model partial.Partial[Incident], expected types.GomegaMatcher) {
+//		Expect(model.Apply(existing)).To(expected)
+//	},
+//		IncidentEntry("sets the name",
+//			func(b IncidentBuilderFunc) partial.Partial[Incident] { return b(b.Name("name")) },
+//			func(m IncidentMatcherFunc) types.GomegaMatcher { return m(m.Name("name")) },
+//		),
+//	)
+func IncidentEntry(description string, build func(IncidentBuilderFunc) partial.Partial[Incident], expect func(IncidentMatcherFunc) types.GomegaMatcher) table.TableEntry {
+	return table.Entry(description, build(IncidentBuilder), expect(IncidentMatcher))
+}
+
+// ListingBuilder initialises a Listing struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Setters: Heading, Page, ClearPage, Previous.
+//
+// For example:
+//
+//	model := ListingBuilder(
+//		ListingBuilder.Heading(heading),
+//	)
+var ListingBuilder = ListingBuilderFunc(func(opts ...partial.Option[Listing]) partial.Partial[Listing] {
+	apply := func(base Listing) partial.Partial[Listing] {
+		model := partial.Partial[Listing]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(Listing{})
+	model.SetApply(func(base Listing) *Listing {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type ListingBuilderFunc func(opts ...partial.Option[Listing]) partial.Partial[Listing]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Listing", "builder",
+		"Heading",
+		"Page",
+		"ClearPage",
+		"Previous",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b ListingBuilderFunc) From(existing Listing) partial.Option[Listing] {
+	return func(subject *Listing) []string {
+		subject.Heading = existing.Heading
+		subject.Page = listingCopyPage(existing.Page)
+		subject.Previous = existing.Previous
+
+		return []string{
+			"Heading",
+			"Page",
+			"Previous",
+		}
+	}
+}
+
+func (b ListingBuilderFunc) Heading(value Pair[string, int]) partial.Option[Listing] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "builder", "Heading")
+
+	return func(subject *Listing) []string {
+		subject.Heading = value
+
+		return []string{
+			"Heading",
+		}
+	}
+}
+
+// HeadingIfSet sets Heading to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ListingBuilderFunc) HeadingIfSet(value *Pair[string, int]) partial.Option[Listing] {
+	if value == nil {
+		return func(*Listing) []string {
+			return nil
+		}
+	}
+
+	return b.Heading(*value)
+}
+
+// Page deep copies value, so changing it afterwards won't change the partial.
+func (b ListingBuilderFunc) Page(value *Page[Incident]) partial.Option[Listing] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "builder", "Page")
+	value = listingCopyPage(value)
+
+	return func(subject *Listing) []string {
+		subject.Page = listingCopyPage(value)
+
+		return []string{
+			"Page",
+		}
+	}
+}
+
+// ClearPage sets Page to nil and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b ListingBuilderFunc) ClearPage() partial.Option[Listing] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "builder", "ClearPage")
+
+	return func(subject *Listing) []string {
+		subject.Page = nil
+
+		return []string{
+			"Page",
+		}
+	}
+}
+
+// listingCopyPage deep copies a *Page[Incident] for the Page setter.
+func listingCopyPage(value *Page[Incident]) *Page[Incident] {
+	var copied *Page[Incident]
+	if value != nil {
+		value0 := *value
+		copied = &value0
+	}
+
+	return copied
+}
+
+func (b ListingBuilderFunc) Previous(value map[string]Page[string]) partial.Option[Listing] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "builder", "Previous")
+
+	return func(subject *Listing) []string {
+		subject.Previous = value
+
+		return []string{
+			"Previous",
+		}
+	}
+}
+
+// PreviousIfSet sets Previous to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b ListingBuilderFunc) PreviousIfSet(value *map[string]Page[string]) partial.Option[Listing] {
+	if value == nil {
+		return func(*Listing) []string {
+			return nil
+		}
+	}
+
+	return b.Previous(*value)
+}
+
+// ListingMatcher creates a Gomega matcher for Listing against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: Heading, Page, Previous.
+//
+// For example:
+//
+//	Expect(listing).To(ListingMatcher(
+//		ListingMatcher.Heading(heading),
+//		ListingMatcher.MatchPage(Not(BeZero())),
+//	))
+var ListingMatcher = ListingMatcherFunc(func(opts ...func(*Listing, *gstruct.Fields)) types.GomegaMatcher {
+	fields := gstruct.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+	return gstruct.PointTo(
+		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+	)
+})
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b Listing) Matcher(opts ...func(*Listing, *gstruct.Fields)) types.GomegaMatcher {
+	return ListingMatcher(opts...)
+}
+
+type ListingMatcherFunc func(opts ...func(*Listing, *gstruct.Fields)) types.GomegaMatcher
+
+type ListingMatcherMatchers struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b ListingMatcherFunc) Match() ListingMatcherMatchers {
+	return ListingMatcherMatchers{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Listing", "matcher",
+		"Heading",
+		"MatchHeading",
+		"Match().Heading",
+		"Page",
+		"MatchPage",
+		"Match().Page",
+		"Previous",
+		"MatchPrevious",
+		"Match().Previous",
+	)
+}
+
+func (b ListingMatcherFunc) Heading(value Pair[string, int]) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "Heading")
+	matcher := partial.WithProvenance(partial.Equal(value), "ListingMatcher.Heading")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Heading"] = matcher
+	}
+}
+
+func (b ListingMatcherFunc) MatchHeading(value types.GomegaMatcher) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "MatchHeading")
+	matcher := partial.WithProvenance(value, "ListingMatcher.MatchHeading")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Heading"] = matcher
+	}
+}
+
+func (b ListingMatcherMatchers) Heading(value types.GomegaMatcher) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "Match().Heading")
+	matcher := partial.WithProvenance(value, "ListingMatcher.Match().Heading")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Heading"] = matcher
+	}
+}
+
+func (b ListingMatcherFunc) Page(value *Page[Incident]) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "Page")
+	matcher := partial.WithProvenance(partial.Equal(value), "ListingMatcher.Page")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Page"] = matcher
+	}
+}
+
+func (b ListingMatcherFunc) MatchPage(value types.GomegaMatcher) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "MatchPage")
+	matcher := partial.WithProvenance(value, "ListingMatcher.MatchPage")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Page"] = matcher
+	}
+}
+
+func (b ListingMatcherMatchers) Page(value types.GomegaMatcher) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "Match().Page")
+	matcher := partial.WithProvenance(value, "ListingMatcher.Match().Page")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Page"] = matcher
+	}
+}
+
+func (b ListingMatcherFunc) Previous(value map[string]Page[string]) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "Previous")
+	matcher := partial.WithProvenance(partial.Equal(value), "ListingMatcher.Previous")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Previous"] = matcher
+	}
+}
+
+func (b ListingMatcherFunc) MatchPrevious(value types.GomegaMatcher) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "MatchPrevious")
+	matcher := partial.WithProvenance(value, "ListingMatcher.MatchPrevious")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Previous"] = matcher
+	}
+}
+
+func (b ListingMatcherMatchers) Previous(value types.GomegaMatcher) func(*Listing, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Listing", "matcher", "Match().Previous")
+	matcher := partial.WithProvenance(value, "ListingMatcher.Match().Previous")
+
+	return func(_ *Listing, fields *gstruct.Fields) {
+		(*fields)["Previous"] = matcher
+	}
+}
+
+// OrganisationBuilder initialises a Organisation struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Organisation is a customer account, which owns incidents.
+//
+// Setters: ID, Name, OptionalString, OptionalStringValue, OptionalStringNull,
+// ClearOptionalString, BoolFlag, IncidentCount, SigningKey, LogoDigest, WebhookSecret,
+// LatestIncident, ClearLatestIncident, Incidents.
+//
+// For example:
+//
+//	model := OrganisationBuilder(
+//		OrganisationBuilder.ID(id),
+//	)
+var OrganisationBuilder = OrganisationBuilderFunc(func(opts ...partial.Option[Organisation]) partial.Partial[Organisation] {
+	apply := func(base Organisation) partial.Partial[Organisation] {
+		model := partial.Partial[Organisation]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(Organisation{})
+	model.SetApply(func(base Organisation) *Organisation {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type OrganisationBuilderFunc func(opts ...partial.Option[Organisation]) partial.Partial[Organisation]
+
+// OrganisationForCreate stops model from tracking any of the fields with a gorm default that
+// are set to their zero value, so creating a record from it uses the database default instead.
+func OrganisationForCreate(model partial.Partial[Organisation]) partial.Partial[Organisation] {
+	return partial.WithoutZeroFields(model,
+		"ID",
+	)
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Organisation", "builder",
+		"ID",
+		"Name",
+		"OptionalString",
+		"OptionalStringValue",
+		"OptionalStringNull",
+		"ClearOptionalString",
+		"BoolFlag",
+		"IncidentCount",
+		"SigningKey",
+		"LogoDigest",
+		"WebhookSecret",
+		"LatestIncident",
+		"ClearLatestIncident",
+		"Incidents",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b OrganisationBuilderFunc) From(existing Organisation) partial.Option[Organisation] {
+	return func(subject *Organisation) []string {
+		subject.ID = existing.ID
+		subject.Name = existing.Name
+		subject.OptionalString = existing.OptionalString
+		subject.BoolFlag = existing.BoolFlag
+		subject.IncidentCount = existing.IncidentCount
+		subject.SigningKey = organisationCopySigningKey(existing.SigningKey)
+		subject.LogoDigest = existing.LogoDigest
+		subject.WebhookSecret = existing.WebhookSecret
+
+		return []string{
+			"ID",
+			"Name",
+			"OptionalString",
+			"BoolFlag",
+			"IncidentCount",
+			"SigningKey",
+			"LogoDigest",
+			"WebhookSecret",
+		}
+	}
+}
+
+func (b OrganisationBuilderFunc) ID(value string) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "ID")
+
+	return func(subject *Organisation) []string {
+		subject.ID = value
+
+		return []string{
+			"ID",
+		}
+	}
+}
+
+// IDIfSet sets ID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) IDIfSet(value *string) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.ID(*value)
+}
+
+func (b OrganisationBuilderFunc) Name(value string) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "Name")
+
+	return func(subject *Organisation) []string {
+		subject.Name = value
+
+		return []string{
+			"Name",
+		}
+	}
+}
+
+// NameIfSet sets Name to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) NameIfSet(value *string) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.Name(*value)
+}
+
+func (b OrganisationBuilderFunc) OptionalString(value null.String) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "OptionalString")
+
+	return func(subject *Organisation) []string {
+		subject.OptionalString = value
+
+		return []string{
+			"OptionalString",
+		}
+	}
+}
+
+// OptionalStringValue sets OptionalString to a valid null.String holding value.
+func (b OrganisationBuilderFunc) OptionalStringValue(value string) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "OptionalStringValue")
+
+	return func(subject *Organisation) []string {
+		subject.OptionalString = null.StringFrom(value)
+
+		return []string{
+			"OptionalString",
+		}
+	}
+}
+
+// OptionalStringNull sets OptionalString to null.
+func (b OrganisationBuilderFunc) OptionalStringNull() partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "OptionalStringNull")
+
+	return func(subject *Organisation) []string {
+		subject.OptionalString = null.String{}
+
+		return []string{
+			"OptionalString",
+		}
+	}
+}
+
+// ClearOptionalString sets OptionalString to null and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b OrganisationBuilderFunc) ClearOptionalString() partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "ClearOptionalString")
+
+	return func(subject *Organisation) []string {
+		subject.OptionalString = null.String{}
+
+		return []string{
+			"OptionalString",
+		}
+	}
+}
+
+// OptionalStringIfSet sets OptionalString to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) OptionalStringIfSet(value *null.String) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.OptionalString(*value)
+}
+
+func (b OrganisationBuilderFunc) BoolFlag(value bool) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "BoolFlag")
+
+	return func(subject *Organisation) []string {
+		subject.BoolFlag = value
+
+		return []string{
+			"BoolFlag",
+		}
+	}
+}
+
+// BoolFlagIfSet sets BoolFlag to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) BoolFlagIfSet(value *bool) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.BoolFlag(*value)
+}
+
+func (b OrganisationBuilderFunc) IncidentCount(value int) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "IncidentCount")
+
+	return func(subject *Organisation) []string {
+		subject.IncidentCount = value
+
+		return []string{
+			"IncidentCount",
+		}
+	}
+}
+
+// IncidentCountIfSet sets IncidentCount to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) IncidentCountIfSet(value *int) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.IncidentCount(*value)
+}
+
+// SigningKey deep copies value, so changing it afterwards won't change the partial.
+func (b OrganisationBuilderFunc) SigningKey(value []byte) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "SigningKey")
+	value = organisationCopySigningKey(value)
+
+	return func(subject *Organisation) []string {
+		subject.SigningKey = organisationCopySigningKey(value)
+
+		return []string{
+			"SigningKey",
+		}
+	}
+}
+
+// SigningKeyIfSet sets SigningKey to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) SigningKeyIfSet(value *[]byte) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.SigningKey(*value)
+}
+
+// organisationCopySigningKey deep copies a []byte for the SigningKey setter.
+func organisationCopySigningKey(value []byte) []byte {
+	var copied []byte
+	if value != nil {
+		copied = make([]byte, len(value))
+		copy(copied, value)
+	}
+
+	return copied
+}
+
+func (b OrganisationBuilderFunc) LogoDigest(value [4]byte) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "LogoDigest")
+
+	return func(subject *Organisation) []string {
+		subject.LogoDigest = value
+
+		return []string{
+			"LogoDigest",
+		}
+	}
+}
+
+// LogoDigestIfSet sets LogoDigest to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) LogoDigestIfSet(value *[4]byte) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.LogoDigest(*value)
+}
+
+func (b OrganisationBuilderFunc) WebhookSecret(value string) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "WebhookSecret")
+
+	return func(subject *Organisation) []string {
+		subject.WebhookSecret = value
+
+		return []string{
+			"WebhookSecret",
+		}
+	}
+}
+
+// WebhookSecretIfSet sets WebhookSecret to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) WebhookSecretIfSet(value *string) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.WebhookSecret(*value)
+}
+
+func (b OrganisationBuilderFunc) LatestIncident(value *Incident) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "LatestIncident")
+
+	return func(subject *Organisation) []string {
+		subject.LatestIncident = value
+
+		return []string{
+			"LatestIncident",
+		}
+	}
+}
+
+// ClearLatestIncident sets LatestIncident to nil and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b OrganisationBuilderFunc) ClearLatestIncident() partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "ClearLatestIncident")
+
+	return func(subject *Organisation) []string {
+		subject.LatestIncident = nil
+
+		return []string{
+			"LatestIncident",
+		}
+	}
+}
+
+func (b OrganisationBuilderFunc) Incidents(value []*Incident) partial.Option[Organisation] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "builder", "Incidents")
+
+	return func(subject *Organisation) []string {
+		subject.Incidents = value
+
+		return []string{
+			"Incidents",
+		}
+	}
+}
+
+// IncidentsIfSet sets Incidents to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b OrganisationBuilderFunc) IncidentsIfSet(value *[]*Incident) partial.Option[Organisation] {
+	if value == nil {
+		return func(*Organisation) []string {
+			return nil
+		}
+	}
+
+	return b.Incidents(*value)
+}
+
+// OrganisationMatcher creates a Gomega matcher for Organisation against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Organisation is a customer account, which owns incidents.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: ID, Name, OptionalString,
+// BoolFlag, IncidentCount, SigningKey, LogoDigest, WebhookSecret, LatestIncident,
+// Incidents.
+//
+// For example:
+//
+//	Expect(organisation).To(OrganisationMatcher(
+//		OrganisationMatcher.ID(id),
+//		OrganisationMatcher.MatchName(Not(BeZero())),
+//	))
+var OrganisationMatcher = OrganisationMatcherFunc(func(opts ...func(*Organisation, *gstruct.Fields)) types.GomegaMatcher {
+	fields := gstruct.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+	matcher := gstruct.PointTo(
+		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+	)
+
+	// On failure, diff the actual value against a copy with every field we expected an
+	// exact value for set to that value, so only those fields can differ.
+	return partial.WithDiff(matcher, func(actual any) string {
+		subject, ok := actual.(*Organisation)
+		if !ok || subject == nil {
+			return ""
+		}
+
+		expected := *subject
+		for _, opt := range opts {
+			opt(&expected, &gstruct.Fields{})
+		}
+
+		return DiffOrganisation(expected, *subject)
+	})
+})
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b Organisation) Matcher(opts ...func(*Organisation, *gstruct.Fields)) types.GomegaMatcher {
+	return OrganisationMatcher(opts...)
+}
+
+type OrganisationMatcherFunc func(opts ...func(*Organisation, *gstruct.Fields)) types.GomegaMatcher
+
+type OrganisationMatcherMatchers struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b OrganisationMatcherFunc) Match() OrganisationMatcherMatchers {
+	return OrganisationMatcherMatchers{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Organisation", "matcher",
+		"ID",
+		"MatchID",
+		"Match().ID",
+		"Name",
+		"MatchName",
+		"Match().Name",
+		"OptionalString",
+		"MatchOptionalString",
+		"Match().OptionalString",
+		"BoolFlag",
+		"MatchBoolFlag",
+		"Match().BoolFlag",
+		"IncidentCount",
+		"MatchIncidentCount",
+		"Match().IncidentCount",
+		"SigningKey",
+		"MatchSigningKey",
+		"Match().SigningKey",
+		"SigningKeyHex",
+		"SigningKeyBase64",
+		"LogoDigest",
+		"MatchLogoDigest",
+		"Match().LogoDigest",
+		"LogoDigestHex",
+		"LogoDigestBase64",
+		"WebhookSecret",
+		"MatchWebhookSecret",
+		"Match().WebhookSecret",
+		"LatestIncident",
+		"MatchLatestIncident",
+		"Match().LatestIncident",
+		"MatchLatestIncidentWith",
+		"Incidents",
+		"MatchIncidents",
+		"Match().Incidents",
+		"MatchIncidentsConsistOf",
+	)
+}
+
+func (b OrganisationMatcherFunc) ID(value string) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "ID")
+	matcher := partial.WithProvenance(partial.Equal(value), "OrganisationMatcher.ID")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.ID = value
+		}
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchID(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchID")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchID")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) ID(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().ID")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().ID")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) Name(value string) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Name")
+	matcher := partial.WithProvenance(partial.Equal(value), "OrganisationMatcher.Name")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Name = value
+		}
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchName(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchName")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchName")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) Name(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().Name")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().Name")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) OptionalString(value null.String) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "OptionalString")
+	matcher := partial.WithProvenance(partial.Equal(value), "OrganisationMatcher.OptionalString")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.OptionalString = value
+		}
+		(*fields)["OptionalString"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchOptionalString(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchOptionalString")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchOptionalString")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["OptionalString"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) OptionalString(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().OptionalString")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().OptionalString")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["OptionalString"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) BoolFlag(value bool) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "BoolFlag")
+	matcher := partial.WithProvenance(partial.Equal(value), "OrganisationMatcher.BoolFlag")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.BoolFlag = value
+		}
+		(*fields)["BoolFlag"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchBoolFlag(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchBoolFlag")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchBoolFlag")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["BoolFlag"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) BoolFlag(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().BoolFlag")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().BoolFlag")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["BoolFlag"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) IncidentCount(value int) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "IncidentCount")
+	matcher := partial.WithProvenance(partial.Equal(value), "OrganisationMatcher.IncidentCount")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.IncidentCount = value
+		}
+		(*fields)["IncidentCount"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchIncidentCount(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchIncidentCount")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchIncidentCount")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["IncidentCount"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) IncidentCount(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().IncidentCount")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().IncidentCount")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["IncidentCount"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) SigningKey(value []byte) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "SigningKey")
+	matcher := partial.WithProvenance(partial.EqualBytes(value[:]), "OrganisationMatcher.SigningKey")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.SigningKey = value
+		}
+		(*fields)["SigningKey"] = matcher
+	}
+}
+
+// SigningKeyHex matches SigningKey against the bytes written as hex in value.
+func (b OrganisationMatcherFunc) SigningKeyHex(value string) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "SigningKeyHex")
+	matcher := partial.WithProvenance(partial.EqualHex(value), "OrganisationMatcher.SigningKeyHex")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["SigningKey"] = matcher
+	}
+}
+
+// SigningKeyBase64 matches SigningKey against the bytes written as base64 in value.
+func (b OrganisationMatcherFunc) SigningKeyBase64(value string) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "SigningKeyBase64")
+	matcher := partial.WithProvenance(partial.EqualBase64(value), "OrganisationMatcher.SigningKeyBase64")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["SigningKey"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchSigningKey(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchSigningKey")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchSigningKey")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["SigningKey"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) SigningKey(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().SigningKey")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().SigningKey")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["SigningKey"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) LogoDigest(value [4]byte) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "LogoDigest")
+	matcher := partial.WithProvenance(partial.EqualBytes(value[:]), "OrganisationMatcher.LogoDigest")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.LogoDigest = value
+		}
+		(*fields)["LogoDigest"] = matcher
+	}
+}
+
+// LogoDigestHex matches LogoDigest against the bytes written as hex in value.
+func (b OrganisationMatcherFunc) LogoDigestHex(value string) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "LogoDigestHex")
+	matcher := partial.WithProvenance(partial.EqualHex(value), "OrganisationMatcher.LogoDigestHex")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["LogoDigest"] = matcher
+	}
+}
+
+// LogoDigestBase64 matches LogoDigest against the bytes written as base64 in value.
+func (b OrganisationMatcherFunc) LogoDigestBase64(value string) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "LogoDigestBase64")
+	matcher := partial.WithProvenance(partial.EqualBase64(value), "OrganisationMatcher.LogoDigestBase64")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["LogoDigest"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchLogoDigest(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchLogoDigest")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchLogoDigest")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["LogoDigest"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) LogoDigest(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().LogoDigest")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().LogoDigest")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["LogoDigest"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) WebhookSecret(value string) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "WebhookSecret")
+	matcher := partial.WithProvenance(partial.Equal(value), "OrganisationMatcher.WebhookSecret")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.WebhookSecret = value
+		}
+		(*fields)["WebhookSecret"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchWebhookSecret(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchWebhookSecret")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchWebhookSecret")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["WebhookSecret"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) WebhookSecret(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().WebhookSecret")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().WebhookSecret")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["WebhookSecret"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) LatestIncident(value *Incident) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "LatestIncident")
+	matcher := partial.WithProvenance(partial.Equal(value), "OrganisationMatcher.LatestIncident")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.LatestIncident = value
+		}
+		(*fields)["LatestIncident"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchLatestIncident(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchLatestIncident")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchLatestIncident")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["LatestIncident"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) LatestIncident(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().LatestIncident")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().LatestIncident")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["LatestIncident"] = matcher
+	}
+}
+
+// MatchLatestIncidentWith matches LatestIncident against the given Incident matchers,
+// failing rather than panicking if it is nil.
+func (b OrganisationMatcherFunc) MatchLatestIncidentWith(opts ...func(*Incident, *gstruct.Fields)) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchLatestIncidentWith")
+	matcher := partial.WithProvenance(IncidentMatcher(opts...), "OrganisationMatcher.MatchLatestIncidentWith")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["LatestIncident"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) Incidents(value []*Incident) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Incidents")
+	matcher := partial.WithProvenance(partial.Equal(value), "OrganisationMatcher.Incidents")
+
+	return func(expected *Organisation, fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Incidents = value
+		}
+		(*fields)["Incidents"] = matcher
+	}
+}
+
+func (b OrganisationMatcherFunc) MatchIncidents(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchIncidents")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.MatchIncidents")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["Incidents"] = matcher
+	}
+}
+
+func (b OrganisationMatcherMatchers) Incidents(value types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "Match().Incidents")
+	matcher := partial.WithProvenance(value, "OrganisationMatcher.Match().Incidents")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["Incidents"] = matcher
+	}
+}
+
+// MatchIncidentsConsistOf matches when Incidents has exactly one element matching each of
+// the given matchers, in any order. Build each with IncidentMatcher, passing it
+// every option the element should match.
+func (b OrganisationMatcherFunc) MatchIncidentsConsistOf(elements ...types.GomegaMatcher) func(*Organisation, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Organisation", "matcher", "MatchIncidentsConsistOf")
+
+	matcher := partial.WithProvenance(gomega.ConsistOf(elements), "OrganisationMatcher.MatchIncidentsConsistOf")
+
+	return func(_ *Organisation, fields *gstruct.Fields) {
+		(*fields)["Incidents"] = matcher
+	}
+}
+
+// DiffOrganisation describes how each database-backed field differs between a and b,
+// returning an empty string if they match. Useful when a Organisation matcher fails, as
+// the output is much smaller than printing each struct in full.
+func DiffOrganisation(a, b Organisation) string {
+	return partial.Diff("Organisation", []partial.FieldDiff{
+		{FieldName: "ID", A: a.ID, B: b.ID},
+		{FieldName: "Name", A: a.Name, B: b.Name},
+		{FieldName: "OptionalString", A: a.OptionalString, B: b.OptionalString},
+		{FieldName: "BoolFlag", A: a.BoolFlag, B: b.BoolFlag},
+		{FieldName: "IncidentCount", A: a.IncidentCount, B: b.IncidentCount},
+		{FieldName: "SigningKey", A: a.SigningKey, B: b.SigningKey},
+		{FieldName: "LogoDigest", A: a.LogoDigest, B: b.LogoDigest},
+		{FieldName: "WebhookSecret", A: a.WebhookSecret, B: b.WebhookSecret},
+	})
+}
+
+// OrganisationUpdateParams holds the fields of Organisation to update, for clients of a service that
+// shouldn't build partials themselves. Fields left nil aren't updated.
+type OrganisationUpdateParams struct {
+	ID             *string      `json:"id,omitempty"`
+	Name           *string      `json:"name,omitempty"`
+	OptionalString *null.String `json:"optional_string,omitempty"`
+	BoolFlag       *bool        `json:"bool_flag,omitempty"`
+	IncidentCount  *int         `json:"incident_count,omitempty"`
+	SigningKey     *[]byte      `json:"signing_key,omitempty"`
+	LogoDigest     *[4]byte     `json:"logo_digest,omitempty"`
+	WebhookSecret  *string      `json:"webhook_secret,omitempty"`
+}
+
+// Partial returns a partial tracking the fields of p that aren't nil, to the values they
+// point to.
+func (p OrganisationUpdateParams) Partial() partial.Partial[Organisation] {
+	return partial.NewWithCapacity[Organisation](8).Add(func(subject *Organisation) []string {
+		fieldNames := []string{}
+		if p.ID != nil {
+			subject.ID = *p.ID
+			fieldNames = append(fieldNames, "ID")
+		}
+		if p.Name != nil {
+			subject.Name = *p.Name
+			fieldNames = append(fieldNames, "Name")
+		}
+		if p.OptionalString != nil {
+			subject.OptionalString = *p.OptionalString
+			fieldNames = append(fieldNames, "OptionalString")
+		}
+		if p.BoolFlag != nil {
+			subject.BoolFlag = *p.BoolFlag
+			fieldNames = append(fieldNames, "BoolFlag")
+		}
+		if p.IncidentCount != nil {
+			subject.IncidentCount = *p.IncidentCount
+			fieldNames = append(fieldNames, "IncidentCount")
+		}
+		if p.SigningKey != nil {
+			subject.SigningKey = *p.SigningKey
+			fieldNames = append(fieldNames, "SigningKey")
+		}
+		if p.LogoDigest != nil {
+			subject.LogoDigest = *p.LogoDigest
+			fieldNames = append(fieldNames, "LogoDigest")
+		}
+		if p.WebhookSecret != nil {
+			subject.WebhookSecret = *p.WebhookSecret
+			fieldNames = append(fieldNames, "WebhookSecret")
+		}
+
+		return fieldNames
+	})
+}
+
+// PageBuilder initialises a Page[T] struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Page is a page of results of any type, as returned by paginated list endpoints.
+//
+// Setters: Items, NextCursor, Counts().Total.
+//
+// For example:
+//
+//	model := PageBuilder[T]()(
+//		PageBuilder[T]().Items(items),
+//	)
+func PageBuilder[T any]() PageBuilderFunc[T] {
+	return PageBuilderFunc[T](func(opts ...partial.Option[Page[T]]) partial.Partial[Page[T]] {
+		apply := func(base Page[T]) partial.Partial[Page[T]] {
+			model := partial.Partial[Page[T]]{
+				Subject: base,
+			}
+
+			fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+			model.FieldNames = fieldNames
+			model.SetErr(err)
+
+			return model
+		}
+
+		model := apply(Page[T]{})
+		model.SetApply(func(base Page[T]) *Page[T] {
+			patched := apply(base).Subject
+			return &patched
+		})
+
+		model = model.TrackDerived()
+		partial.RunBuildHooks(&model)
+
+		return model
+	})
+}
+
+type PageBuilderFunc[T any] func(opts ...partial.Option[Page[T]]) partial.Partial[Page[T]]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Page[T]", "builder",
+		"Items",
+		"NextCursor",
+		"Counts().Total",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b PageBuilderFunc[T]) From(existing Page[T]) partial.Option[Page[T]] {
+	return func(subject *Page[T]) []string {
+		subject.Items = pageCopyItems[T](existing.Items)
+		subject.NextCursor = existing.NextCursor
+		subject.Total = existing.Total
+
+		return []string{
+			"Items",
+			"NextCursor",
+			"Total",
+		}
+	}
+}
+
+// Counts returns the setters for the Counts fields of Page[T].
+func (b PageBuilderFunc[T]) Counts() PageBuilderCounts[T] {
+	return PageBuilderCounts[T]{}
+}
+
+type PageBuilderCounts[T any] struct{}
+
+// Items deep copies value, so changing it afterwards won't change the partial.
+func (b PageBuilderFunc[T]) Items(value []T) partial.Option[Page[T]] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "builder", "Items")
+	value = pageCopyItems[T](value)
+
+	return func(subject *Page[T]) []string {
+		subject.Items = pageCopyItems[T](value)
+
+		return []string{
+			"Items",
+		}
+	}
+}
+
+// ItemsIfSet sets Items to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PageBuilderFunc[T]) ItemsIfSet(value *[]T) partial.Option[Page[T]] {
+	if value == nil {
+		return func(*Page[T]) []string {
+			return nil
+		}
+	}
+
+	return b.Items(*value)
+}
+
+// pageCopyItems deep copies a []T for the Items setter.
+func pageCopyItems[T any](value []T) []T {
+	var copied []T
+	if value != nil {
+		copied = make([]T, len(value))
+		copy(copied, value)
+	}
+
+	return copied
+}
+
+func (b PageBuilderFunc[T]) NextCursor(value string) partial.Option[Page[T]] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "builder", "NextCursor")
+
+	return func(subject *Page[T]) []string {
+		subject.NextCursor = value
+
+		return []string{
+			"NextCursor",
+		}
+	}
+}
+
+// NextCursorIfSet sets NextCursor to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PageBuilderFunc[T]) NextCursorIfSet(value *string) partial.Option[Page[T]] {
+	if value == nil {
+		return func(*Page[T]) []string {
+			return nil
+		}
+	}
+
+	return b.NextCursor(*value)
+}
+
+func (b PageBuilderCounts[T]) Total(value int) partial.Option[Page[T]] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "builder", "Counts().Total")
+
+	return func(subject *Page[T]) []string {
+		subject.Total = value
+
+		return []string{
+			"Total",
+		}
+	}
+}
+
+// TotalIfSet sets Total to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PageBuilderCounts[T]) TotalIfSet(value *int) partial.Option[Page[T]] {
+	if value == nil {
+		return func(*Page[T]) []string {
+			return nil
+		}
+	}
+
+	return b.Total(*value)
+}
+
+// PageMatcher creates a Gomega matcher for Page[T] against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Page is a page of results of any type, as returned by paginated list endpoints.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: Items, NextCursor, Total.
+//
+// For example:
+//
+//	Expect(page).To(PageMatcher[T]()(
+//		PageMatcher[T]().Items(items),
+//		PageMatcher[T]().MatchNextCursor(Not(BeZero())),
+//	))
+func PageMatcher[T any]() PageMatcherFunc[T] {
+	return PageMatcherFunc[T](func(opts ...func(*Page[T], *gstruct.Fields)) types.GomegaMatcher {
+		fields := gstruct.Fields{}
+		for _, opt := range opts {
+			opt(nil, &fields)
+		}
+
+		matcher := gstruct.PointTo(
+			gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+		)
+
+		// On failure, diff the actual value against a copy with every field we expected an
+		// exact value for set to that value, so only those fields can differ.
+		return partial.WithDiff(matcher, func(actual any) string {
+			subject, ok := actual.(*Page[T])
+			if !ok || subject == nil {
+				return ""
+			}
+
+			expected := *subject
+			for _, opt := range opts {
+				opt(&expected, &gstruct.Fields{})
+			}
+
+			return DiffPage[T](expected, *subject)
+		})
+	})
+}
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b Page[T]) Matcher(opts ...func(*Page[T], *gstruct.Fields)) types.GomegaMatcher {
+	return PageMatcher[T]()(opts...)
+}
+
+type PageMatcherFunc[T any] func(opts ...func(*Page[T], *gstruct.Fields)) types.GomegaMatcher
+
+type PageMatcherMatchers[T any] struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b PageMatcherFunc[T]) Match() PageMatcherMatchers[T] {
+	return PageMatcherMatchers[T]{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher",
+		"Items",
+		"MatchItems",
+		"Match().Items",
+		"NextCursor",
+		"MatchNextCursor",
+		"Match().NextCursor",
+		"Total",
+		"MatchTotal",
+		"Match().Total",
+	)
+}
+
+func (b PageMatcherFunc[T]) Items(value []T) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "Items")
+	matcher := partial.WithProvenance(partial.Equal(value), "PageMatcher.Items")
+
+	return func(expected *Page[T], fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Items = value
+		}
+		(*fields)["Items"] = matcher
+	}
+}
+
+func (b PageMatcherFunc[T]) MatchItems(value types.GomegaMatcher) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "MatchItems")
+	matcher := partial.WithProvenance(value, "PageMatcher.MatchItems")
+
+	return func(_ *Page[T], fields *gstruct.Fields) {
+		(*fields)["Items"] = matcher
+	}
+}
+
+func (b PageMatcherMatchers[T]) Items(value types.GomegaMatcher) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "Match().Items")
+	matcher := partial.WithProvenance(value, "PageMatcher.Match().Items")
+
+	return func(_ *Page[T], fields *gstruct.Fields) {
+		(*fields)["Items"] = matcher
+	}
+}
+
+func (b PageMatcherFunc[T]) NextCursor(value string) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "NextCursor")
+	matcher := partial.WithProvenance(partial.Equal(value), "PageMatcher.NextCursor")
+
+	return func(expected *Page[T], fields *gstruct.Fields) {
+		if expected != nil {
+			expected.NextCursor = value
+		}
+		(*fields)["NextCursor"] = matcher
+	}
+}
+
+func (b PageMatcherFunc[T]) MatchNextCursor(value types.GomegaMatcher) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "MatchNextCursor")
+	matcher := partial.WithProvenance(value, "PageMatcher.MatchNextCursor")
+
+	return func(_ *Page[T], fields *gstruct.Fields) {
+		(*fields)["NextCursor"] = matcher
+	}
+}
+
+func (b PageMatcherMatchers[T]) NextCursor(value types.GomegaMatcher) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "Match().NextCursor")
+	matcher := partial.WithProvenance(value, "PageMatcher.Match().NextCursor")
+
+	return func(_ *Page[T], fields *gstruct.Fields) {
+		(*fields)["NextCursor"] = matcher
+	}
+}
+
+func (b PageMatcherFunc[T]) Total(value int) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "Total")
+	matcher := partial.WithProvenance(partial.Equal(value), "PageMatcher.Total")
+
+	return func(expected *Page[T], fields *gstruct.Fields) {
+		if expected != nil {
+			expected.Total = value
+		}
+		(*fields)["Total"] = matcher
+	}
+}
+
+func (b PageMatcherFunc[T]) MatchTotal(value types.GomegaMatcher) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "MatchTotal")
+	matcher := partial.WithProvenance(value, "PageMatcher.MatchTotal")
+
+	return func(_ *Page[T], fields *gstruct.Fields) {
+		(*fields)["Total"] = matcher
+	}
+}
+
+func (b PageMatcherMatchers[T]) Total(value types.GomegaMatcher) func(*Page[T], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Page[T]", "matcher", "Match().Total")
+	matcher := partial.WithProvenance(value, "PageMatcher.Match().Total")
+
+	return func(_ *Page[T], fields *gstruct.Fields) {
+		(*fields)["Total"] = matcher
+	}
+}
+
+// DiffPage describes how each database-backed field differs between a and b,
+// returning an empty string if they match. Useful when a Page[T] matcher fails, as
+// the output is much smaller than printing each struct in full.
+func DiffPage[T any](a, b Page[T]) string {
+	return partial.Diff("Page[T]", []partial.FieldDiff{
+		{FieldName: "Items", A: a.Items, B: b.Items},
+		{FieldName: "NextCursor", A: a.NextCursor, B: b.NextCursor},
+		{FieldName: "Total", A: a.Total, B: b.Total},
+	})
+}
+
+// NewPage builds a Page[T] from its required fields, followed by builder setters
+// for any others.
+func NewPage[T any](nextCursor string, opts ...partial.Option[Page[T]]) partial.Partial[Page[T]] {
+	required := []partial.Option[Page[T]]{
+		func(subject *Page[T]) []string {
+			subject.NextCursor = nextCursor
+			return []string{"NextCursor"}
+		},
+	}
+
+	return PageBuilder[T]()(append(required, opts...)...)
+}
+
+// PairBuilder initialises a Pair[K, V] struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Default values are set and tracked before any setters are applied. Use WithoutDefaults
+// to build without them.
+//
+// Setters: Key, Value, Label.
+//
+// For example:
+//
+//	model := PairBuilder[K, V]()(
+//		PairBuilder[K, V]().Key(key),
+//	)
+func PairBuilder[K comparable, V any]() PairBuilderFunc[K, V] {
+	return PairBuilderFunc[K, V](func(opts ...partial.Option[Pair[K, V]]) partial.Partial[Pair[K, V]] {
+		defaults := []partial.Option[Pair[K, V]]{
+			func(subject *Pair[K, V]) []string {
+				subject.Label = "unlabelled"
+				return []string{"Label"}
+			},
+		}
+
+		return pairBuilderWithoutDefaults[K, V]()(append(defaults, opts...)...)
+	})
+}
+
+// WithoutDefaults returns a builder that doesn't set any default values.
+func (b PairBuilderFunc[K, V]) WithoutDefaults() PairBuilderFunc[K, V] {
+	return pairBuilderWithoutDefaults[K, V]()
+}
+
+func pairBuilderWithoutDefaults[K comparable, V any]() PairBuilderFunc[K, V] {
+	return PairBuilderFunc[K, V](func(opts ...partial.Option[Pair[K, V]]) partial.Partial[Pair[K, V]] {
+		apply := func(base Pair[K, V]) partial.Partial[Pair[K, V]] {
+			model := partial.Partial[Pair[K, V]]{
+				Subject: base,
+			}
+
+			fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+			model.FieldNames = fieldNames
+			model.SetErr(err)
+
+			return model
+		}
+
+		model := apply(Pair[K, V]{})
+		model.SetApply(func(base Pair[K, V]) *Pair[K, V] {
+			patched := apply(base).Subject
+			return &patched
+		})
+
+		model = model.TrackDerived()
+		partial.RunBuildHooks(&model)
+
+		return model
+	})
+}
+
+type PairBuilderFunc[K comparable, V any] func(opts ...partial.Option[Pair[K, V]]) partial.Partial[Pair[K, V]]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "builder",
+		"Key",
+		"Value",
+		"Label",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b PairBuilderFunc[K, V]) From(existing Pair[K, V]) partial.Option[Pair[K, V]] {
+	return func(subject *Pair[K, V]) []string {
+		subject.Key = existing.Key
+		subject.Value = existing.Value
+		subject.Label = existing.Label
+
+		return []string{
+			"Key",
+			"Value",
+			"Label",
+		}
+	}
+}
+
+func (b PairBuilderFunc[K, V]) Key(value K) partial.Option[Pair[K, V]] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "builder", "Key")
+
+	return func(subject *Pair[K, V]) []string {
+		subject.Key = value
+
+		return []string{
+			"Key",
+		}
+	}
+}
+
+// KeyIfSet sets Key to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PairBuilderFunc[K, V]) KeyIfSet(value *K) partial.Option[Pair[K, V]] {
+	if value == nil {
+		return func(*Pair[K, V]) []string {
+			return nil
+		}
+	}
+
+	return b.Key(*value)
+}
+
+func (b PairBuilderFunc[K, V]) Value(value V) partial.Option[Pair[K, V]] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "builder", "Value")
+
+	return func(subject *Pair[K, V]) []string {
+		subject.Value = value
+
+		return []string{
+			"Value",
+		}
+	}
+}
+
+// ValueIfSet sets Value to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PairBuilderFunc[K, V]) ValueIfSet(value *V) partial.Option[Pair[K, V]] {
+	if value == nil {
+		return func(*Pair[K, V]) []string {
+			return nil
+		}
+	}
+
+	return b.Value(*value)
+}
+
+func (b PairBuilderFunc[K, V]) Label(value string) partial.Option[Pair[K, V]] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "builder", "Label")
+
+	return func(subject *Pair[K, V]) []string {
+		subject.Label = value
+
+		return []string{
+			"Label",
+		}
+	}
+}
+
+// LabelIfSet sets Label to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PairBuilderFunc[K, V]) LabelIfSet(value *string) partial.Option[Pair[K, V]] {
+	if value == nil {
+		return func(*Pair[K, V]) []string {
+			return nil
+		}
+	}
+
+	return b.Label(*value)
+}
+
+// PairMatcher creates a Gomega matcher for Pair[K, V] against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: Key, Value, Label.
+//
+// For example:
+//
+//	Expect(pair).To(PairMatcher[K, V]()(
+//		PairMatcher[K, V]().Key(key),
+//		PairMatcher[K, V]().MatchValue(Not(BeZero())),
+//	))
+func PairMatcher[K comparable, V any]() PairMatcherFunc[K, V] {
+	return PairMatcherFunc[K, V](func(opts ...func(*Pair[K, V], *gstruct.Fields)) types.GomegaMatcher {
+		fields := gstruct.Fields{}
+		for _, opt := range opts {
+			opt(nil, &fields)
+		}
+
+		return gstruct.PointTo(
+			gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+		)
+	})
+}
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b Pair[K, V]) Matcher(opts ...func(*Pair[K, V], *gstruct.Fields)) types.GomegaMatcher {
+	return PairMatcher[K, V]()(opts...)
+}
+
+type PairMatcherFunc[K comparable, V any] func(opts ...func(*Pair[K, V], *gstruct.Fields)) types.GomegaMatcher
+
+type PairMatcherMatchers[K comparable, V any] struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b PairMatcherFunc[K, V]) Match() PairMatcherMatchers[K, V] {
+	return PairMatcherMatchers[K, V]{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher",
+		"Key",
+		"MatchKey",
+		"Match().Key",
+		"Value",
+		"MatchValue",
+		"Match().Value",
+		"Label",
+		"MatchLabel",
+		"Match().Label",
+	)
+}
+
+func (b PairMatcherFunc[K, V]) Key(value K) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "Key")
+	matcher := partial.WithProvenance(partial.Equal(value), "PairMatcher.Key")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Key"] = matcher
+	}
+}
+
+func (b PairMatcherFunc[K, V]) MatchKey(value types.GomegaMatcher) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "MatchKey")
+	matcher := partial.WithProvenance(value, "PairMatcher.MatchKey")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Key"] = matcher
+	}
+}
+
+func (b PairMatcherMatchers[K, V]) Key(value types.GomegaMatcher) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "Match().Key")
+	matcher := partial.WithProvenance(value, "PairMatcher.Match().Key")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Key"] = matcher
+	}
+}
+
+func (b PairMatcherFunc[K, V]) Value(value V) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "Value")
+	matcher := partial.WithProvenance(partial.Equal(value), "PairMatcher.Value")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Value"] = matcher
+	}
+}
+
+func (b PairMatcherFunc[K, V]) MatchValue(value types.GomegaMatcher) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "MatchValue")
+	matcher := partial.WithProvenance(value, "PairMatcher.MatchValue")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Value"] = matcher
+	}
+}
+
+func (b PairMatcherMatchers[K, V]) Value(value types.GomegaMatcher) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "Match().Value")
+	matcher := partial.WithProvenance(value, "PairMatcher.Match().Value")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Value"] = matcher
+	}
+}
+
+func (b PairMatcherFunc[K, V]) Label(value string) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "Label")
+	matcher := partial.WithProvenance(partial.Equal(value), "PairMatcher.Label")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Label"] = matcher
+	}
+}
+
+func (b PairMatcherFunc[K, V]) MatchLabel(value types.GomegaMatcher) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "MatchLabel")
+	matcher := partial.WithProvenance(value, "PairMatcher.MatchLabel")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Label"] = matcher
+	}
+}
+
+func (b PairMatcherMatchers[K, V]) Label(value types.GomegaMatcher) func(*Pair[K, V], *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Pair[K, V]", "matcher", "Match().Label")
+	matcher := partial.WithProvenance(value, "PairMatcher.Match().Label")
+
+	return func(_ *Pair[K, V], fields *gstruct.Fields) {
+		(*fields)["Label"] = matcher
+	}
+}
+
+// PreferencesBuilder initialises a Preferences struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Setters: Settings, Limits, ClearLimits, Token, Channels.
+//
+// For example:
+//
+//	model := PreferencesBuilder(
+//		PreferencesBuilder.Settings(settings),
+//	)
+var PreferencesBuilder = PreferencesBuilderFunc(func(opts ...partial.Option[Preferences]) partial.Partial[Preferences] {
+	apply := func(base Preferences) partial.Partial[Preferences] {
+		model := partial.Partial[Preferences]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(Preferences{})
+	model.SetApply(func(base Preferences) *Preferences {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type PreferencesBuilderFunc func(opts ...partial.Option[Preferences]) partial.Partial[Preferences]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Preferences", "builder",
+		"Settings",
+		"Limits",
+		"ClearLimits",
+		"Token",
+		"Channels",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b PreferencesBuilderFunc) From(existing Preferences) partial.Option[Preferences] {
+	return func(subject *Preferences) []string {
+		subject.Settings = existing.Settings
+		subject.Limits = existing.Limits
+		subject.Token = existing.Token
+		subject.Channels = existing.Channels
+
+		return []string{
+			"Settings",
+			"Limits",
+			"Token",
+			"Channels",
+		}
+	}
+}
+
+func (b PreferencesBuilderFunc) Settings(value struct {
+	Enabled bool   "json:\"enabled\""
+	Channel string "json:\"channel\""
+}) partial.Option[Preferences] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "builder", "Settings")
+
+	return func(subject *Preferences) []string {
+		subject.Settings = value
+
+		return []string{
+			"Settings",
+		}
+	}
+}
+
+// SettingsIfSet sets Settings to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PreferencesBuilderFunc) SettingsIfSet(value *struct {
+	Enabled bool   "json:\"enabled\""
+	Channel string "json:\"channel\""
+}) partial.Option[Preferences] {
+	if value == nil {
+		return func(*Preferences) []string {
+			return nil
+		}
+	}
+
+	return b.Settings(*value)
+}
+
+func (b PreferencesBuilderFunc) Limits(value *struct {
+	Daily  int
+	Weekly int
+}) partial.Option[Preferences] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "builder", "Limits")
+
+	return func(subject *Preferences) []string {
+		subject.Limits = value
+
+		return []string{
+			"Limits",
+		}
+	}
+}
+
+// ClearLimits sets Limits to nil and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b PreferencesBuilderFunc) ClearLimits() partial.Option[Preferences] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "builder", "ClearLimits")
+
+	return func(subject *Preferences) []string {
+		subject.Limits = nil
+
+		return []string{
+			"Limits",
+		}
+	}
+}
+
+func (b PreferencesBuilderFunc) Token(value [16]byte) partial.Option[Preferences] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "builder", "Token")
+
+	return func(subject *Preferences) []string {
+		subject.Token = value
+
+		return []string{
+			"Token",
+		}
+	}
+}
+
+// TokenIfSet sets Token to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PreferencesBuilderFunc) TokenIfSet(value *[16]byte) partial.Option[Preferences] {
+	if value == nil {
+		return func(*Preferences) []string {
+			return nil
+		}
+	}
+
+	return b.Token(*value)
+}
+
+func (b PreferencesBuilderFunc) Channels(value [2]string) partial.Option[Preferences] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "builder", "Channels")
+
+	return func(subject *Preferences) []string {
+		subject.Channels = value
+
+		return []string{
+			"Channels",
+		}
+	}
+}
+
+// ChannelsIfSet sets Channels to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b PreferencesBuilderFunc) ChannelsIfSet(value *[2]string) partial.Option[Preferences] {
+	if value == nil {
+		return func(*Preferences) []string {
+			return nil
+		}
+	}
+
+	return b.Channels(*value)
+}
+
+// PreferencesMatcher creates a Gomega matcher for Preferences against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: ID, Settings, Limits,
+// Token, Channels.
+//
+// For example:
+//
+//	Expect(preferences).To(PreferencesMatcher(
+//		PreferencesMatcher.ID(id),
+//		PreferencesMatcher.MatchSettings(Not(BeZero())),
+//	))
+var PreferencesMatcher = PreferencesMatcherFunc(func(opts ...func(*Preferences, *gstruct.Fields)) types.GomegaMatcher {
+	fields := gstruct.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+	return gstruct.PointTo(
+		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+	)
+})
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b Preferences) Matcher(opts ...func(*Preferences, *gstruct.Fields)) types.GomegaMatcher {
+	return PreferencesMatcher(opts...)
+}
+
+type PreferencesMatcherFunc func(opts ...func(*Preferences, *gstruct.Fields)) types.GomegaMatcher
+
+type PreferencesMatcherMatchers struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b PreferencesMatcherFunc) Match() PreferencesMatcherMatchers {
+	return PreferencesMatcherMatchers{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "Preferences", "matcher",
+		"ID",
+		"MatchID",
+		"Match().ID",
+		"Settings",
+		"MatchSettings",
+		"Match().Settings",
+		"Limits",
+		"MatchLimits",
+		"Match().Limits",
+		"Token",
+		"MatchToken",
+		"Match().Token",
+		"TokenHex",
+		"TokenBase64",
+		"Channels",
+		"MatchChannels",
+		"Match().Channels",
+	)
+}
+
+func (b PreferencesMatcherFunc) ID(value string) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "ID")
+	matcher := partial.WithProvenance(partial.Equal(value), "PreferencesMatcher.ID")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) MatchID(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "MatchID")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.MatchID")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b PreferencesMatcherMatchers) ID(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Match().ID")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.Match().ID")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) Settings(value struct {
+	Enabled bool   "json:\"enabled\""
+	Channel string "json:\"channel\""
+}) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Settings")
+	matcher := partial.WithProvenance(partial.Equal(value), "PreferencesMatcher.Settings")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Settings"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) MatchSettings(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "MatchSettings")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.MatchSettings")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Settings"] = matcher
+	}
+}
+
+func (b PreferencesMatcherMatchers) Settings(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Match().Settings")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.Match().Settings")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Settings"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) Limits(value *struct {
+	Daily  int
+	Weekly int
+}) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Limits")
+	matcher := partial.WithProvenance(partial.Equal(value), "PreferencesMatcher.Limits")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Limits"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) MatchLimits(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "MatchLimits")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.MatchLimits")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Limits"] = matcher
+	}
+}
+
+func (b PreferencesMatcherMatchers) Limits(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Match().Limits")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.Match().Limits")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Limits"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) Token(value [16]byte) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Token")
+	matcher := partial.WithProvenance(partial.EqualBytes(value[:]), "PreferencesMatcher.Token")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Token"] = matcher
+	}
+}
+
+// TokenHex matches Token against the bytes written as hex in value.
+func (b PreferencesMatcherFunc) TokenHex(value string) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "TokenHex")
+	matcher := partial.WithProvenance(partial.EqualHex(value), "PreferencesMatcher.TokenHex")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Token"] = matcher
+	}
+}
+
+// TokenBase64 matches Token against the bytes written as base64 in value.
+func (b PreferencesMatcherFunc) TokenBase64(value string) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "TokenBase64")
+	matcher := partial.WithProvenance(partial.EqualBase64(value), "PreferencesMatcher.TokenBase64")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Token"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) MatchToken(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "MatchToken")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.MatchToken")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Token"] = matcher
+	}
+}
+
+func (b PreferencesMatcherMatchers) Token(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Match().Token")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.Match().Token")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Token"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) Channels(value [2]string) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Channels")
+	matcher := partial.WithProvenance(partial.Equal(value), "PreferencesMatcher.Channels")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Channels"] = matcher
+	}
+}
+
+func (b PreferencesMatcherFunc) MatchChannels(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "MatchChannels")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.MatchChannels")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Channels"] = matcher
+	}
+}
+
+func (b PreferencesMatcherMatchers) Channels(value types.GomegaMatcher) func(*Preferences, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "Preferences", "matcher", "Match().Channels")
+	matcher := partial.WithProvenance(value, "PreferencesMatcher.Match().Channels")
+
+	return func(_ *Preferences, fields *gstruct.Fields) {
+		(*fields)["Channels"] = matcher
+	}
+}
+
+// RetryPolicyBuilder initialises a RetryPolicy struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Default values are set and tracked before any setters are applied. Use WithoutDefaults
+// to build without them.
+//
+// Setters: MaxAttempts, Backoff.
+//
+// For example:
+//
+//	model := RetryPolicyBuilder(
+//		RetryPolicyBuilder.MaxAttempts(maxAttempts),
+//	)
+var RetryPolicyBuilder = RetryPolicyBuilderFunc(func(opts ...partial.Option[RetryPolicy]) partial.Partial[RetryPolicy] {
+	defaults := []partial.Option[RetryPolicy]{
+		func(subject *RetryPolicy) []string {
+			subject.MaxAttempts = 3
+			return []string{"MaxAttempts"}
+		},
+		func(subject *RetryPolicy) []string {
+			subject.Backoff = time.Duration(30000000000)
+			return []string{"Backoff"}
+		},
+	}
+
+	return retryPolicyBuilderWithoutDefaults(append(defaults, opts...)...)
+})
+
+// WithoutDefaults returns a builder that doesn't set any default values.
+func (b RetryPolicyBuilderFunc) WithoutDefaults() RetryPolicyBuilderFunc {
+	return retryPolicyBuilderWithoutDefaults
+}
+
+var retryPolicyBuilderWithoutDefaults = RetryPolicyBuilderFunc(func(opts ...partial.Option[RetryPolicy]) partial.Partial[RetryPolicy] {
+	apply := func(base RetryPolicy) partial.Partial[RetryPolicy] {
+		model := partial.Partial[RetryPolicy]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(RetryPolicy{})
+	model.SetApply(func(base RetryPolicy) *RetryPolicy {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type RetryPolicyBuilderFunc func(opts ...partial.Option[RetryPolicy]) partial.Partial[RetryPolicy]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "RetryPolicy", "builder",
+		"MaxAttempts",
+		"Backoff",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b RetryPolicyBuilderFunc) From(existing RetryPolicy) partial.Option[RetryPolicy] {
+	return func(subject *RetryPolicy) []string {
+		subject.MaxAttempts = existing.MaxAttempts
+		subject.Backoff = existing.Backoff
+
+		return []string{
+			"MaxAttempts",
+			"Backoff",
+		}
+	}
+}
+
+func (b RetryPolicyBuilderFunc) MaxAttempts(value int) partial.Option[RetryPolicy] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "RetryPolicy", "builder", "MaxAttempts")
+
+	return func(subject *RetryPolicy) []string {
+		subject.MaxAttempts = value
+
+		return []string{
+			"MaxAttempts",
+		}
+	}
+}
+
+// MaxAttemptsIfSet sets MaxAttempts to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b RetryPolicyBuilderFunc) MaxAttemptsIfSet(value *int) partial.Option[RetryPolicy] {
+	if value == nil {
+		return func(*RetryPolicy) []string {
+			return nil
+		}
+	}
+
+	return b.MaxAttempts(*value)
+}
+
+func (b RetryPolicyBuilderFunc) Backoff(value time.Duration) partial.Option[RetryPolicy] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "RetryPolicy", "builder", "Backoff")
+
+	return func(subject *RetryPolicy) []string {
+		subject.Backoff = value
+
+		return []string{
+			"Backoff",
+		}
+	}
+}
+
+// BackoffIfSet sets Backoff to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b RetryPolicyBuilderFunc) BackoffIfSet(value *time.Duration) partial.Option[RetryPolicy] {
+	if value == nil {
+		return func(*RetryPolicy) []string {
+			return nil
+		}
+	}
+
+	return b.Backoff(*value)
+}
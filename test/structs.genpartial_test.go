@@ -0,0 +1,352 @@
+// Code generated by github.com/incident-io/partial/gen, DO NOT EDIT.
+// partial generator version: 8
+
+package test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+)
+
+func init() {
+	partial.RequireGeneratorVersion("structs.genpartial_test.go", 8)
+}
+
+// IncidentRoleBuilder initialises a IncidentRole struct with fields from the given setters. Setters
+// are applied first to last, with subsequent sets taking precedence.
+//
+// Setters: ID, IncidentID, Incident, ClearIncident, Name.
+//
+// For example:
+//
+//	model := IncidentRoleBuilder(
+//		IncidentRoleBuilder.ID(id),
+//	)
+var IncidentRoleBuilder = IncidentRoleBuilderFunc(func(opts ...partial.Option[IncidentRole]) partial.Partial[IncidentRole] {
+	apply := func(base IncidentRole) partial.Partial[IncidentRole] {
+		model := partial.Partial[IncidentRole]{
+			Subject: base,
+		}
+
+		fieldNames, err := partial.ApplyOptions(&model.Subject, opts)
+		model.FieldNames = fieldNames
+		model.SetErr(err)
+
+		return model
+	}
+
+	model := apply(IncidentRole{})
+	model.SetApply(func(base IncidentRole) *IncidentRole {
+		patched := apply(base).Subject
+		return &patched
+	})
+
+	model = model.TrackDerived()
+	partial.RunBuildHooks(&model)
+
+	return model
+})
+
+type IncidentRoleBuilderFunc func(opts ...partial.Option[IncidentRole]) partial.Partial[IncidentRole]
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "IncidentRole", "builder",
+		"ID",
+		"IncidentID",
+		"Incident",
+		"ClearIncident",
+		"Name",
+	)
+}
+
+// From sets every database-backed field that has a setter to its value in existing, so
+// tests and updates can start from a loaded record and override a few fields with the
+// setters that follow it.
+func (b IncidentRoleBuilderFunc) From(existing IncidentRole) partial.Option[IncidentRole] {
+	return func(subject *IncidentRole) []string {
+		subject.ID = existing.ID
+		subject.IncidentID = existing.IncidentID
+		subject.Name = existing.Name
+
+		return []string{
+			"ID",
+			"IncidentID",
+			"Name",
+		}
+	}
+}
+
+func (b IncidentRoleBuilderFunc) ID(value string) partial.Option[IncidentRole] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "builder", "ID")
+
+	return func(subject *IncidentRole) []string {
+		subject.ID = value
+
+		return []string{
+			"ID",
+		}
+	}
+}
+
+// IDIfSet sets ID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b IncidentRoleBuilderFunc) IDIfSet(value *string) partial.Option[IncidentRole] {
+	if value == nil {
+		return func(*IncidentRole) []string {
+			return nil
+		}
+	}
+
+	return b.ID(*value)
+}
+
+func (b IncidentRoleBuilderFunc) IncidentID(value string) partial.Option[IncidentRole] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "builder", "IncidentID")
+
+	return func(subject *IncidentRole) []string {
+		subject.IncidentID = value
+
+		return []string{
+			"IncidentID",
+		}
+	}
+}
+
+// IncidentIDIfSet sets IncidentID to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b IncidentRoleBuilderFunc) IncidentIDIfSet(value *string) partial.Option[IncidentRole] {
+	if value == nil {
+		return func(*IncidentRole) []string {
+			return nil
+		}
+	}
+
+	return b.IncidentID(*value)
+}
+
+func (b IncidentRoleBuilderFunc) Incident(value *Incident) partial.Option[IncidentRole] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "builder", "Incident")
+
+	return func(subject *IncidentRole) []string {
+		subject.Incident = value
+
+		return []string{
+			"Incident",
+		}
+	}
+}
+
+// ClearIncident sets Incident to nil and tracks it, so the partial clears the field,
+// such as writing NULL to its column, rather than leaving it untouched.
+func (b IncidentRoleBuilderFunc) ClearIncident() partial.Option[IncidentRole] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "builder", "ClearIncident")
+
+	return func(subject *IncidentRole) []string {
+		subject.Incident = nil
+
+		return []string{
+			"Incident",
+		}
+	}
+}
+
+func (b IncidentRoleBuilderFunc) Name(value string) partial.Option[IncidentRole] {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "builder", "Name")
+
+	return func(subject *IncidentRole) []string {
+		subject.Name = value
+
+		return []string{
+			"Name",
+		}
+	}
+}
+
+// NameIfSet sets Name to what value points to, or does nothing if value is
+// nil, so optional fields of a request can be passed straight through.
+func (b IncidentRoleBuilderFunc) NameIfSet(value *string) partial.Option[IncidentRole] {
+	if value == nil {
+		return func(*IncidentRole) []string {
+			return nil
+		}
+	}
+
+	return b.Name(*value)
+}
+
+// IncidentRoleMatcher creates a Gomega matcher for IncidentRole against the given
+// fields. Matchers are applied first to last, with subsequent matchers taking precedence.
+//
+// Fields, each with a Match variant accepting a GomegaMatcher: ID, IncidentID, Incident,
+// Name.
+//
+// For example:
+//
+//	Expect(incidentRole).To(IncidentRoleMatcher(
+//		IncidentRoleMatcher.ID(id),
+//		IncidentRoleMatcher.MatchIncidentID(Not(BeZero())),
+//	))
+var IncidentRoleMatcher = IncidentRoleMatcherFunc(func(opts ...func(*IncidentRole, *gstruct.Fields)) types.GomegaMatcher {
+	fields := gstruct.Fields{}
+	for _, opt := range opts {
+		opt(nil, &fields)
+	}
+
+	return gstruct.PointTo(
+		gstruct.MatchFields(gstruct.IgnoreExtras, fields),
+	)
+})
+
+// Matcher is added to the base type, permitting other generic functions to build matchers
+// from each of the matcher-setter functions.
+func (b IncidentRole) Matcher(opts ...func(*IncidentRole, *gstruct.Fields)) types.GomegaMatcher {
+	return IncidentRoleMatcher(opts...)
+}
+
+type IncidentRoleMatcherFunc func(opts ...func(*IncidentRole, *gstruct.Fields)) types.GomegaMatcher
+
+type IncidentRoleMatcherMatchers struct{}
+
+// Match returns an interface with the same methods as the base matcher, but accepting
+// GomegaMatcher parameters instead of the exact equality matches.
+func (b IncidentRoleMatcherFunc) Match() IncidentRoleMatcherMatchers {
+	return IncidentRoleMatcherMatchers{}
+}
+
+func init() {
+	partial.RegisterCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher",
+		"ID",
+		"MatchID",
+		"Match().ID",
+		"IncidentID",
+		"MatchIncidentID",
+		"Match().IncidentID",
+		"Incident",
+		"MatchIncident",
+		"Match().Incident",
+		"MatchIncidentWith",
+		"Name",
+		"MatchName",
+		"Match().Name",
+	)
+}
+
+func (b IncidentRoleMatcherFunc) ID(value string) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "ID")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentRoleMatcher.ID")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherFunc) MatchID(value types.GomegaMatcher) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "MatchID")
+	matcher := partial.WithProvenance(value, "IncidentRoleMatcher.MatchID")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherMatchers) ID(value types.GomegaMatcher) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "Match().ID")
+	matcher := partial.WithProvenance(value, "IncidentRoleMatcher.Match().ID")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["ID"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherFunc) IncidentID(value string) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "IncidentID")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentRoleMatcher.IncidentID")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["IncidentID"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherFunc) MatchIncidentID(value types.GomegaMatcher) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "MatchIncidentID")
+	matcher := partial.WithProvenance(value, "IncidentRoleMatcher.MatchIncidentID")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["IncidentID"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherMatchers) IncidentID(value types.GomegaMatcher) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "Match().IncidentID")
+	matcher := partial.WithProvenance(value, "IncidentRoleMatcher.Match().IncidentID")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["IncidentID"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherFunc) Incident(value *Incident) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "Incident")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentRoleMatcher.Incident")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["Incident"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherFunc) MatchIncident(value types.GomegaMatcher) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "MatchIncident")
+	matcher := partial.WithProvenance(value, "IncidentRoleMatcher.MatchIncident")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["Incident"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherMatchers) Incident(value types.GomegaMatcher) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "Match().Incident")
+	matcher := partial.WithProvenance(value, "IncidentRoleMatcher.Match().Incident")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["Incident"] = matcher
+	}
+}
+
+// MatchIncidentWith matches Incident against the given Incident matchers,
+// failing rather than panicking if it is nil.
+func (b IncidentRoleMatcherFunc) MatchIncidentWith(opts ...func(*Incident, *gstruct.Fields)) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "MatchIncidentWith")
+	matcher := partial.WithProvenance(IncidentMatcher(opts...), "IncidentRoleMatcher.MatchIncidentWith")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["Incident"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherFunc) Name(value string) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "Name")
+	matcher := partial.WithProvenance(partial.Equal(value), "IncidentRoleMatcher.Name")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherFunc) MatchName(value types.GomegaMatcher) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "MatchName")
+	matcher := partial.WithProvenance(value, "IncidentRoleMatcher.MatchName")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
+
+func (b IncidentRoleMatcherMatchers) Name(value types.GomegaMatcher) func(*IncidentRole, *gstruct.Fields) {
+	partial.RecordCoverage("github.com/incident-io/partial/test", "IncidentRole", "matcher", "Match().Name")
+	matcher := partial.WithProvenance(value, "IncidentRoleMatcher.Match().Name")
+
+	return func(_ *IncidentRole, fields *gstruct.Fields) {
+		(*fields)["Name"] = matcher
+	}
+}
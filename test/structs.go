@@ -1,24 +1,181 @@
-//go:generate go run ../cmd/partial
+//go:generate go run ../cmd/partial -guard-defaults
 package test
 
 import (
+	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/incident-io/partial"
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/pkg/errors"
 	"gopkg.in/guregu/null.v3"
 )
 
-// codegen-partial:builder,matcher
+// Organisation is a customer account, which owns incidents.
+//
+// codegen-partial:builder,matcher,diff,params
 type Organisation struct {
 	ID             string      `json:"id" gorm:"type:text;primaryKey;default:generate_ulid()"`
-	Name           string      `json:"name"`
+	Name           string      `json:"name" gorm:"not null"`
 	OptionalString null.String `json:"optional_string"`
 	BoolFlag       bool        `json:"bool_flag"`
+	IncidentCount  int         `json:"incident_count"`
+	SigningKey     []byte      `json:"signing_key"` // partial:deep-copy
+	LogoDigest     [4]byte     `json:"logo_digest"`
+	WebhookSecret  string      `json:"webhook_secret" partial:"encrypted"`
+	LatestIncident *Incident   `gorm:"-"`
+	Incidents      []*Incident `gorm:"-"`
 }
 
-// codegen-partial:builder,matcher
+// codegen-partial:builder,matcher,diff,event,constructor,entry
 type Incident struct {
-	ID             string `json:"id" gorm:"type:text;primaryKey;default:generate_ulid()"`
-	OrganisationID string `json:"organisation_id"`
+	ID             string `json:"id" gorm:"type:text;primaryKey;default:generate_ulid()" partial:"immutable,required"`
+	OrganisationID string `json:"organisation_id" partial:"required"`
 	Organisation   *Organisation
-	CreatedAt      time.Time `json:"created_at"`
+	Parent         *Incident `gorm:"-"`
+	CreatedAt      time.Time `json:"created_at" partial:"immutable"`
+	Actions        []Action  `gorm:"-"` // partial:deep-copy
+	SearchVector   string    `json:"search_vector" gorm:"->" partial:"readonly"`
+}
+
+// codegen-partial:builder(testonly),matcher(testonly)
+type IncidentRole struct {
+	ID         string `json:"id"`
+	IncidentID string `json:"incident_id"`
+	Incident   *Incident
+	Name       string `json:"name"`
+}
+
+// codegen-partial:builder,matcher,options
+type Action struct {
+	ID          string        `json:"id"`
+	Description string        `json:"description"`
+	Assignee    string        `json:"assignee"` // partial:matcher-factory=MatchEmail
+	SearchText  string        `json:"search_text"`
+	DueAt       null.Time     `json:"due_at" partial:"group=Timestamps"`
+	CompletedAt null.Time     `json:"completed_at" partial:"group=Timestamps"`
+	Priority    sql.NullInt64 `json:"priority"`
+	Severity    Severity      `json:"severity"` // partial:setter-accepts=string,parse=ParseSeverity
+}
+
+type Severity int
+
+const (
+	SeverityLow Severity = iota + 1
+	SeverityHigh
+)
+
+func ParseSeverity(value string) (Severity, error) {
+	switch value {
+	case "low":
+		return SeverityLow, nil
+	case "high":
+		return SeverityHigh, nil
+	default:
+		return 0, errors.New(fmt.Sprintf("unrecognised severity: %s", value))
+	}
+}
+
+// MatchEmail matches email addresses regardless of case.
+func MatchEmail(value string) types.GomegaMatcher {
+	return gomega.WithTransform(strings.ToLower, gomega.Equal(strings.ToLower(value)))
+}
+
+func init() {
+	partial.Derived[Action]("SearchText", []string{"Description", "Assignee"}, func(action Action) string {
+		return strings.ToLower(action.Description + " " + action.Assignee)
+	})
+}
+
+type (
+	// codegen-partial:builder,matcher
+	CustomField struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Kind        string `json:"kind"`     // partial:default=text
+		Required    bool   `json:"required"` // partial:default=true
+		// Numeric-looking, but still a string.
+		SchemaVersion string `json:"schema_version"` // partial:default=2
+		// Deprecated: use Kind instead.
+		LegacyType string `json:"legacy_type" partial:"-"`
+	}
+
+	// CustomFieldOption is not annotated, so nothing is generated for it.
+	CustomFieldOption struct {
+		ID            string `json:"id"`
+		CustomFieldID string `json:"custom_field_id"`
+		Value         string `json:"value"`
+	}
+)
+
+// Page is a page of results of any type, as returned by paginated list endpoints.
+//
+// codegen-partial:builder,matcher,diff,constructor
+type Page[T any] struct {
+	Items      []T    `json:"items"` // partial:deep-copy
+	NextCursor string `json:"next_cursor" partial:"required"`
+	Total      int    `json:"total" partial:"group=Counts"`
+}
+
+// codegen-partial:builder,matcher
+type Pair[K comparable, V any] struct {
+	Key   K      `json:"key"`
+	Value V      `json:"value"`
+	Label string `json:"label"` // partial:default=unlabelled
+}
+
+// codegen-partial:builder,matcher
+type Listing struct {
+	Heading  Pair[string, int]       `json:"heading"`
+	Page     *Page[Incident]         `json:"page"` // partial:deep-copy
+	Previous map[string]Page[string] `json:"previous"`
+}
+
+// codegen-partial:builder,matcher
+type Preferences struct {
+	ID       string `json:"id" partial:"immutable"`
+	Settings struct {
+		Enabled bool   `json:"enabled"`
+		Channel string `json:"channel"`
+	} `json:"settings"`
+	Limits   *struct{ Daily, Weekly int } `json:"limits"`
+	Token    [tokenLength]byte            `json:"token"`
+	Channels [2]string                    `json:"channels"`
+	OnChange func(Preferences)            // partial:skip
+	Updates  chan string                  // partial:skip
+}
+
+const tokenLength = 16
+
+// codegen-partial:builder
+type RetryPolicy struct {
+	MaxAttempts int           `json:"max_attempts"` // partial:default=3
+	Backoff     time.Duration `json:"backoff"`      // partial:default=30s
+}
+
+// codegen-partial:flags
+type CLIConfig struct {
+	// The API to send requests to.
+	Endpoint string `json:"endpoint"` // partial:default=https://api.incident.io
+	// How many times to retry failed requests.
+	MaxRetries int           `json:"max_retries"` // partial:default=3
+	Timeout    time.Duration `json:"timeout"`     // partial:default=30s
+	Verbose    bool          `json:"verbose"`
+	Tags       []string      `json:"tags"`
+	Priority   Priority      `json:"priority"` // partial:default=p2
+	Token      string        `json:"-"`
+}
+
+// codegen-partial:builder,matcher
+type Alert struct {
+	Priority Priority `json:"priority"`
+	Urgency  Urgency  `json:"urgency"`
+	Labels   Labels   `json:"labels"` // partial:deep-copy
+	Digest   Digest   `json:"digest"`
+	// From would collide with the builder's From method, so its setters are named Sender.
+	From string `json:"from" partial:"name=Sender"`
 }
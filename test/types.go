@@ -0,0 +1,13 @@
+package test
+
+// Types declared apart from the structs that use them, which generated code must refer
+// to by name.
+
+type Priority string
+
+// Urgency is an alias, so is the same type as Priority.
+type Urgency = Priority
+
+type Labels []string
+
+type Digest [8]byte
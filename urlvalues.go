@@ -0,0 +1,118 @@
+package partial
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ToURLValues encodes the tracked fields as form values keyed by their JSON names, for
+// third-party APIs that accept form-encoded partial updates. Cleared fields are sent as
+// empty values, and fields that aren't serialised to JSON are left out.
+//
+// Strings, bools, numbers, byte slices (as base64) and times (as RFC 3339) are supported,
+// along with pointers to them, slices of them as repeated values, and anything that
+// implements driver.Valuer or encoding.TextMarshaler, such as null.String. Forms can't
+// express increments, so incremented fields are an error.
+func (m Partial[T]) ToURLValues() (url.Values, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	values := url.Values{}
+	for _, op := range m.Ops() {
+		if op.JSONName == "" {
+			continue
+		}
+
+		switch op.Kind {
+		case FieldOpIncrement:
+			return nil, errors.New(fmt.Sprintf("cannot encode increment of %s as a url value", op.FieldName))
+		case FieldOpClear:
+			values.Set(op.JSONName, "")
+		default:
+			encoded, err := urlValuesFor(reflect.ValueOf(op.Value))
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("encoding %s", op.FieldName))
+			}
+
+			values[op.JSONName] = encoded
+		}
+	}
+
+	return values, nil
+}
+
+// urlValuesFor encodes a single field, which is only more than one value for slices.
+func urlValuesFor(value reflect.Value) ([]string, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return []string{""}, nil
+		}
+
+		return urlValuesFor(value.Elem())
+	}
+
+	switch typed := value.Interface().(type) {
+	case time.Time:
+		return []string{typed.Format(time.RFC3339Nano)}, nil
+
+	case driver.Valuer:
+		dbValue, err := typed.Value()
+		if err != nil {
+			return nil, err
+		}
+		if dbValue == nil {
+			return []string{""}, nil
+		}
+
+		return urlValuesFor(reflect.ValueOf(dbValue))
+
+	case encoding.TextMarshaler:
+		text, err := typed.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{string(text)}, nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return []string{value.String()}, nil
+	case reflect.Bool:
+		return []string{strconv.FormatBool(value.Bool())}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(value.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(value.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return []string{strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits())}, nil
+
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return []string{base64.StdEncoding.EncodeToString(value.Bytes())}, nil
+		}
+
+		encoded := []string{}
+		for idx := 0; idx < value.Len(); idx++ {
+			elemValues, err := urlValuesFor(value.Index(idx))
+			if err != nil {
+				return nil, err
+			}
+
+			encoded = append(encoded, elemValues...)
+		}
+
+		return encoded, nil
+	}
+
+	return nil, errors.New(fmt.Sprintf("cannot encode %s as a url value", value.Type()))
+}
@@ -0,0 +1,67 @@
+package partial_test
+
+import (
+	"database/sql"
+	"net/url"
+	"time"
+
+	"github.com/incident-io/partial/test"
+	"gopkg.in/guregu/null.v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ToURLValues", func() {
+	It("encodes tracked fields by their JSON names", func() {
+		values, err := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+			test.OrganisationBuilder.BoolFlag(true),
+			test.OrganisationBuilder.IncidentCount(3),
+			test.OrganisationBuilder.SigningKey([]byte("key")),
+		).ToURLValues()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(url.Values{
+			"name":           {"My Org"},
+			"bool_flag":      {"true"},
+			"incident_count": {"3"},
+			"signing_key":    {"a2V5"},
+		}))
+	})
+
+	It("encodes nullable values and times, sending cleared fields as empty", func() {
+		dueAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+		values, err := test.ActionBuilder(
+			test.ActionBuilder.Timestamps().DueAt(null.TimeFrom(dueAt)),
+			test.ActionBuilder.Timestamps().CompletedAtNull(),
+			test.ActionBuilder.Priority(sql.NullInt64{Int64: 2, Valid: true}),
+			test.ActionBuilder.Severity("high"),
+		).ToURLValues()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(url.Values{
+			"due_at":       {"2024-03-01T09:30:00Z"},
+			"completed_at": {""},
+			"priority":     {"2"},
+			"severity":     {"2"},
+		}))
+	})
+
+	It("leaves out fields that aren't serialised", func() {
+		values, err := test.OrganisationBuilder(
+			test.OrganisationBuilder.Name("My Org"),
+			test.OrganisationBuilder.LatestIncident(&test.Incident{ID: "incident-id"}),
+		).ToURLValues()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(url.Values{"name": {"My Org"}}))
+	})
+
+	It("errors on increments, which forms can't express", func() {
+		_, err := test.OrganisationBuilder().Increment("IncidentCount", 1).ToURLValues()
+
+		Expect(err).To(MatchError(ContainSubstring("cannot encode increment of IncidentCount")))
+	})
+})
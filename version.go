@@ -0,0 +1,43 @@
+package partial
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// GeneratorVersion is the version of the code produced by cmd/partial. It is bumped
+// whenever generated code changes in a way that requires a matching runtime.
+const GeneratorVersion = 8
+
+// MinGeneratorVersion is the oldest generated code this runtime still supports. Raise it
+// alongside GeneratorVersion when making a breaking change to the templates.
+const MinGeneratorVersion = 1
+
+// CheckGeneratorVersion returns an error if code generated at the given version can't be
+// used with this runtime, explaining how to fix it.
+func CheckGeneratorVersion(filename string, version int) error {
+	if version < MinGeneratorVersion {
+		return errors.New(fmt.Sprintf(
+			"%s was generated by partial v%d, but this runtime requires at least v%d: re-run go generate",
+			filename, version, MinGeneratorVersion,
+		))
+	}
+	if version > GeneratorVersion {
+		return errors.New(fmt.Sprintf(
+			"%s was generated by partial v%d, but this runtime only supports up to v%d: upgrade github.com/incident-io/partial",
+			filename, version, GeneratorVersion,
+		))
+	}
+
+	return nil
+}
+
+// RequireGeneratorVersion is called from the init function of every generated file, and
+// panics if it was generated by an incompatible version. This fails fast with a clear
+// message, rather than with confusing behaviour at runtime.
+func RequireGeneratorVersion(filename string, version int) {
+	if err := CheckGeneratorVersion(filename, version); err != nil {
+		panic(fmt.Sprintf("partial: %s", err))
+	}
+}
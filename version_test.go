@@ -0,0 +1,31 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckGeneratorVersion", func() {
+	It("accepts the current version", func() {
+		Expect(partial.CheckGeneratorVersion("structs.genpartial.go", partial.GeneratorVersion)).To(Succeed())
+	})
+
+	It("asks for a re-run of go generate when generated code is too old", func() {
+		err := partial.CheckGeneratorVersion("structs.genpartial.go", partial.MinGeneratorVersion-1)
+		Expect(err).To(MatchError(ContainSubstring("structs.genpartial.go was generated by partial v0")))
+		Expect(err).To(MatchError(ContainSubstring("re-run go generate")))
+	})
+
+	It("asks for an upgrade when generated code is too new", func() {
+		err := partial.CheckGeneratorVersion("structs.genpartial.go", partial.GeneratorVersion+1)
+		Expect(err).To(MatchError(ContainSubstring("upgrade github.com/incident-io/partial")))
+	})
+
+	It("panics when requiring an incompatible version", func() {
+		Expect(func() {
+			partial.RequireGeneratorVersion("structs.genpartial.go", partial.MinGeneratorVersion-1)
+		}).To(Panic())
+	})
+})
@@ -0,0 +1,65 @@
+package partial
+
+import (
+	"reflect"
+	"time"
+)
+
+// Versioned wraps a Partial built from a row loaded from the database, recording the
+// version of the row it was computed from, such as a version column or its updated_at.
+// Before applying the patch, compare against the current version of the row to detect
+// that it changed in the meantime, which would make the patch a lost update:
+//
+//	model, err := partial.NewVersioned(&incident, incident.UpdatedAt)
+//	...
+//	if model.StaleAgainst(current.UpdatedAt) {
+//		return ErrConflict
+//	}
+type Versioned[T any] struct {
+	Partial[T]
+	version any
+}
+
+// NewVersioned builds a model from a domain object like New, recording the version of
+// the row it was loaded from.
+func NewVersioned[T any](subjectPtr *T, version any) (Versioned[T], error) {
+	model, err := New(subjectPtr)
+	if err != nil {
+		return Versioned[T]{}, err
+	}
+
+	return Versioned[T]{Partial: model, version: version}, nil
+}
+
+// Version returns the version of the row the partial was computed from.
+func (v Versioned[T]) Version() any {
+	return v.version
+}
+
+// StaleAgainst returns true if current, the version of the row now, differs from the
+// version the partial was computed from. Times are compared by instant, and numbers by
+// value regardless of their type, so versions survive a round trip through the database.
+func (v Versioned[T]) StaleAgainst(current any) bool {
+	return !versionsEqual(v.version, current)
+}
+
+func versionsEqual(a, b any) bool {
+	if aTime, ok := a.(time.Time); ok {
+		bTime, ok := b.(time.Time)
+		return ok && aTime.Equal(bTime)
+	}
+
+	aValue, bValue := reflect.ValueOf(a), reflect.ValueOf(b)
+	if aValue.IsValid() && bValue.IsValid() && isNumeric(aValue.Kind()) && isNumeric(bValue.Kind()) {
+		switch {
+		case aValue.CanInt() && bValue.CanInt():
+			return aValue.Int() == bValue.Int()
+		case aValue.CanUint() && bValue.CanUint():
+			return aValue.Uint() == bValue.Uint()
+		}
+
+		return aValue.Convert(reflect.TypeOf(float64(0))).Float() == bValue.Convert(reflect.TypeOf(float64(0))).Float()
+	}
+
+	return reflect.DeepEqual(a, b)
+}
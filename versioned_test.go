@@ -0,0 +1,54 @@
+package partial_test
+
+import (
+	"time"
+
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Versioned", func() {
+	It("tracks every field like New, recording the version", func() {
+		model, err := partial.NewVersioned(&test.Organisation{Name: "My Org"}, 3)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(model.Subject.Name).To(Equal("My Org"))
+		Expect(model.FieldNames).To(ContainElement("Name"))
+		Expect(model.Version()).To(Equal(3))
+	})
+
+	It("returns errors from New", func() {
+		_, err := partial.NewVersioned[test.Organisation](nil, 3)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("StaleAgainst", func() {
+		It("compares numbers by value", func() {
+			model, _ := partial.NewVersioned(&test.Organisation{}, 3)
+
+			Expect(model.StaleAgainst(int64(3))).To(BeFalse())
+			Expect(model.StaleAgainst(uint(3))).To(BeFalse())
+			Expect(model.StaleAgainst(4)).To(BeTrue())
+		})
+
+		It("compares times by instant", func() {
+			loadedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			model, _ := partial.NewVersioned(&test.Organisation{}, loadedAt)
+
+			Expect(model.StaleAgainst(loadedAt.In(time.FixedZone("BST", 60*60)))).To(BeFalse())
+			Expect(model.StaleAgainst(loadedAt.Add(time.Millisecond))).To(BeTrue())
+			Expect(model.StaleAgainst("2024-01-01")).To(BeTrue())
+		})
+
+		It("compares anything else deeply", func() {
+			model, _ := partial.NewVersioned(&test.Organisation{}, "etag-1")
+
+			Expect(model.StaleAgainst("etag-1")).To(BeFalse())
+			Expect(model.StaleAgainst("etag-2")).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,96 @@
+package partial
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"gorm.io/gorm/schema"
+)
+
+// ZeroValueWarning describes a partial that set a field to its zero value, when the
+// field's column is NOT NULL and has no default.
+type ZeroValueWarning struct {
+	TypeName  string // test.Organisation
+	FieldName string // Name
+	Column    string // name
+}
+
+func (w ZeroValueWarning) String() string {
+	return fmt.Sprintf("partial: %s.%s was set to its zero value, but column %s is NOT NULL with no default",
+		w.TypeName, w.FieldName, w.Column)
+}
+
+var (
+	zeroValueWarningsEnabled int32
+	zeroValueWarningsMu      sync.RWMutex
+	zeroValueWarn            func(ZeroValueWarning)
+	zeroValueSchemaCache     sync.Map // used by gorm to cache parsed schemas
+)
+
+// EnableZeroValueWarnings starts checking every applied partial for fields set to their
+// zero value where the gorm schema says the column is NOT NULL and has no default. That
+// almost always means the partial was built wrong, such as a setter called with a value
+// that was never loaded.
+//
+// Each warning is passed to warn, or logged if warn is nil. Use this in tests and
+// development, as parsing the schema and checking each field has a cost.
+func EnableZeroValueWarnings(warn func(ZeroValueWarning)) {
+	if warn == nil {
+		warn = func(warning ZeroValueWarning) {
+			log.Print(warning.String())
+		}
+	}
+
+	zeroValueWarningsMu.Lock()
+	zeroValueWarn = warn
+	zeroValueWarningsMu.Unlock()
+
+	atomic.StoreInt32(&zeroValueWarningsEnabled, 1)
+}
+
+// DisableZeroValueWarnings stops checking applied partials for zero values.
+func DisableZeroValueWarnings() {
+	atomic.StoreInt32(&zeroValueWarningsEnabled, 0)
+}
+
+// checkZeroValues warns about any of the set fields that are zero in patched, but can't
+// be zero in the database. This does nothing unless warnings have been enabled.
+func (m Partial[T]) checkZeroValues(patched *T) {
+	if atomic.LoadInt32(&zeroValueWarningsEnabled) == 0 {
+		return
+	}
+
+	zeroValueWarningsMu.RLock()
+	warn := zeroValueWarn
+	zeroValueWarningsMu.RUnlock()
+
+	// Types that gorm can't parse aren't database models, so there's nothing to check.
+	parsed, err := schema.Parse(patched, &zeroValueSchemaCache, schema.NamingStrategy{})
+	if err != nil {
+		return
+	}
+
+	patchedValue := reflect.ValueOf(patched).Elem()
+	for _, fieldName := range m.FieldNames {
+		// Incrementing by zero leaves the column as it was
+		if m.increments[fieldName] {
+			continue
+		}
+
+		field := parsed.LookUpField(fieldName)
+		if field == nil || field.DBName == "" || !field.NotNull || field.HasDefaultValue {
+			continue
+		}
+
+		if patchedValue.FieldByName(fieldName).IsZero() {
+			warn(ZeroValueWarning{
+				TypeName:  patchedValue.Type().String(),
+				FieldName: fieldName,
+				Column:    field.DBName,
+			})
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package partial_test
+
+import (
+	"github.com/incident-io/partial"
+	"github.com/incident-io/partial/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Zero value warnings", func() {
+	var warnings []partial.ZeroValueWarning
+
+	BeforeEach(func() {
+		warnings = nil
+		partial.EnableZeroValueWarnings(func(warning partial.ZeroValueWarning) {
+			warnings = append(warnings, warning)
+		})
+	})
+
+	AfterEach(func() {
+		partial.DisableZeroValueWarnings()
+	})
+
+	It("warns when a NOT NULL column without a default is set to zero", func() {
+		test.OrganisationBuilder(
+			test.OrganisationBuilder.Name(""),
+		).Apply(test.Organisation{Name: "My Org"})
+
+		Expect(warnings).To(ConsistOf(partial.ZeroValueWarning{
+			TypeName:  "test.Organisation",
+			FieldName: "Name",
+			Column:    "name",
+		}))
+	})
+
+	It("ignores columns that are nullable or have a default", func() {
+		test.OrganisationBuilder(
+			test.OrganisationBuilder.ID(""),
+			test.OrganisationBuilder.BoolFlag(false),
+			test.OrganisationBuilder.Name("My Org"),
+		).Apply(test.Organisation{})
+
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("does nothing once disabled", func() {
+		partial.DisableZeroValueWarnings()
+
+		test.OrganisationBuilder(
+			test.OrganisationBuilder.Name(""),
+		).Apply(test.Organisation{})
+
+		Expect(warnings).To(BeEmpty())
+	})
+})